@@ -0,0 +1,211 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package cell defines Cell, the single unit of spreadsheet state shared by
+// every package that reads or writes a sheet: table (live editing),
+// fileop (save/load/export), ui (dialogs and conditional formatting), and
+// report (template rendering). Keeping it in its own package, rather than
+// on table.Workbook or fileop.SheetInfo, is what lets fileop and report
+// operate on cells without importing the tview-backed table package.
+package cell
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Color is an RGB triplet, indexable as [3]uint8 so callers can read/compare
+// channels directly (see isEmptyCell's c.Color[0]/[1]/[2] checks) while still
+// being a plain comparable value for a cache key like xlsxCellStyle.
+type Color [3]uint8
+
+// White and Black are a cell's default text/background colors - every new
+// cell starts with Color: White, BgColor: Black, matching a blank terminal
+// cell's look before any formatting is applied.
+var (
+	White = Color{255, 255, 255}
+	Black = Color{0, 0, 0}
+)
+
+// Hex renders c as a "#RRGGBB" string, e.g. for an HTML inline style or an
+// excelize font/fill color (which additionally strips the "#").
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02X%02X%02X", c[0], c[1], c[2])
+}
+
+// IsDefaultWhite reports whether c is the unset-text-color default.
+func (c Color) IsDefaultWhite() bool { return c == White }
+
+// IsDefaultBlack reports whether c is the unset-background-color default.
+func (c Color) IsDefaultBlack() bool { return c == Black }
+
+// Flags is a bitmask of per-cell text formatting toggles, independent of
+// Type/DateTimeFormat (which govern how a value is parsed/displayed, not
+// how its text is decorated).
+type Flags uint16
+
+const (
+	// FlagBold renders a cell's text bold.
+	FlagBold Flags = 1 << iota
+	// FlagItalic renders a cell's text italic.
+	FlagItalic
+	// FlagUnderline renders a cell's text underlined.
+	FlagUnderline
+	// FlagStrikethrough renders a cell's text struck through.
+	FlagStrikethrough
+	// FlagAllCaps upper-cases a cell's Display text on render/export without
+	// altering the underlying RawValue.
+	FlagAllCaps
+	// FlagFormula marks a cell whose RawValue is a "="-prefixed formula, so
+	// exporters can style/annotate it without re-deriving that from
+	// RawValue's text (see IsFormula, which does derive it, for the
+	// recalculation path that can't rely on Flags being kept in sync).
+	FlagFormula
+)
+
+// Cell is a single spreadsheet cell: its raw and displayed text, type and
+// formatting, validation rule, and formula dependency edges. Every field
+// that may legitimately be "not set yet" (as opposed to "set to empty") is
+// a *string, following this package's one convention throughout rather than
+// mixing *string and "" as the distinct-from-unset marker.
+type Cell struct {
+	Row    int32
+	Column int32
+
+	// RawValue is the literal text a user typed ("=A1+B1", "42", "hello").
+	// Display is what's shown/exported: RawValue re-rendered through Type's
+	// formatting for a value, or a formula's last-evaluated result.
+	RawValue *string
+	Display  *string
+
+	// Type is one of "string", "number", "financial", or "datetime" -
+	// cellui's formatters.go enables/disables the matching formatting
+	// dropdowns for whichever of these is selected.
+	Type *string
+
+	Notes      *string
+	Valrule    *string
+	Valrulemsg *string
+
+	// ValEngine is one of ui's ValidationEngine* constants (stored as a
+	// plain *string here so this package doesn't need to import ui just to
+	// name its own field's values); nil means the long-standing govaluate
+	// default, for a cell that predates ValEngine or never set it.
+	ValEngine *string
+
+	Color   Color
+	BgColor Color
+	Flags   Flags
+
+	// Align is a tview.AlignLeft/AlignCenter/AlignRight value.
+	Align int8
+
+	MaxWidth int16
+	MinWidth int16
+
+	// DecimalPoints, ThousandsSeparator, DecimalSeparator, and FinancialSign
+	// format a "number"/"financial" cell's Display; ThousandsSeparator and
+	// DecimalSeparator are 0 to mean "off", and FinancialSign is the
+	// currency glyph ('$', '€', ...) prefixed ahead of a "financial" cell's
+	// digits.
+	DecimalPoints      int8
+	ThousandsSeparator rune
+	DecimalSeparator   rune
+	FinancialSign      rune
+
+	// DateTimeFormat is the go time layout a "datetime" cell parses/renders
+	// through, or "auto" to infer one.
+	DateTimeFormat *string
+
+	// DependsOn/Dependents are the formula dependency graph's edges, each
+	// entry a cell reference string like "B12" or "Sheet2!A1" - see
+	// recalculateDependents, which walks Dependents to propagate a change.
+	DependsOn  []*string
+	Dependents []*string
+}
+
+// NewCell returns a blank string-typed cell at (row, column) displaying
+// label, with every formatting field at this package's defaults - the same
+// shape blankCell/newCellFromXLSX/openTxtFile build by hand for a cell read
+// from a file, but for callers (row/column headers, a freshly-selected
+// cell with no prior data) that aren't reading one off disk.
+func NewCell(row, col int32, label string) *Cell {
+	raw := label
+	display := label
+	typeValue := "string"
+	emptyStr := ""
+	autotype := "auto"
+
+	return &Cell{
+		Row:      row,
+		Column:   col,
+		MaxWidth: 20,
+		MinWidth: 8,
+
+		RawValue: &raw,
+		Display:  &display,
+		Type:     &typeValue,
+
+		Notes:      &emptyStr,
+		Valrule:    &emptyStr,
+		Valrulemsg: &emptyStr,
+
+		Color:   White,
+		BgColor: Black,
+
+		DateTimeFormat: &autotype,
+
+		DependsOn:  []*string{},
+		Dependents: []*string{},
+	}
+}
+
+// HasFlag reports whether flag is set on c.
+func (c *Cell) HasFlag(flag Flags) bool {
+	return c.Flags&flag != 0
+}
+
+// IsFormula reports whether c's RawValue is a formula ("="-prefixed). This
+// is derived from RawValue rather than FlagFormula so the recalculation
+// path (which only ever reads/writes RawValue and Display) doesn't depend
+// on Flags being kept in sync by every caller that sets RawValue.
+func (c *Cell) IsFormula() bool {
+	return c.RawValue != nil && strings.HasPrefix(*c.RawValue, "=")
+}
+
+// tviewTagPattern matches a tview region/color tag, e.g. "[red]", "[-]", or
+// "[yellow::b]" - any bracketed run with no nested brackets.
+var tviewTagPattern = regexp.MustCompile(`\[[^\[\]]*\]`)
+
+// StripTviewTags removes every tview color/style tag from s, for a context
+// (export, recalculation's token scanner) that wants a cell's plain text.
+func StripTviewTags(s string) string {
+	return tviewTagPattern.ReplaceAllString(s, "")
+}
+
+// ToTViewCell renders c as a tview.TableCell: Display's text (tags intact,
+// since tview itself interprets them), c's alignment and bold attribute,
+// and its Color/BgColor as truecolor.
+func (c *Cell) ToTViewCell() *tview.TableCell {
+	text := ""
+	if c.Display != nil {
+		text = *c.Display
+	}
+
+	tvCell := tview.NewTableCell(text).
+		SetAlign(int(c.Align)).
+		SetTextColor(tcell.NewRGBColor(int32(c.Color[0]), int32(c.Color[1]), int32(c.Color[2]))).
+		SetBackgroundColor(tcell.NewRGBColor(int32(c.BgColor[0]), int32(c.BgColor[1]), int32(c.BgColor[2])))
+
+	if c.HasFlag(FlagBold) {
+		tvCell.SetAttributes(tcell.AttrBold)
+	}
+
+	return tvCell
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// charts.go defines ChartSpec, the workbook-model piece of chart support:
+// a sheet's Charts []ChartSpec field (on both SheetData and SheetInfo)
+// round-trips through the gsheet/JSON formats the same way Merges already
+// does. Rendering a ChartSpec into an actual picture is each exporter's own
+// job - save.go's SaveTableAsHTML draws inline SVG, pdf_handler.go draws
+// gofpdf primitives - this file only resolves a ChartSpec's DataRange/
+// CategoryRange against a sheet's cells into plain []float64/[]string
+// series every renderer can share.
+package fileop
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+)
+
+// ChartType is the kind of chart a ChartSpec renders as.
+type ChartType string
+
+const (
+	ChartLine    ChartType = "line"
+	ChartBar     ChartType = "bar"
+	ChartPie     ChartType = "pie"
+	ChartScatter ChartType = "scatter"
+)
+
+// LegendPosition is where a rendered chart places its legend.
+type LegendPosition string
+
+const (
+	LegendNone   LegendPosition = "none"
+	LegendTop    LegendPosition = "top"
+	LegendBottom LegendPosition = "bottom"
+	LegendLeft   LegendPosition = "left"
+	LegendRight  LegendPosition = "right"
+)
+
+// ChartSpec is one chart attached to a sheet: what kind it is, the cell
+// range its series values come from, and how it's labeled.
+type ChartSpec struct {
+	Type           ChartType      `json:"type"`
+	Title          string         `json:"title,omitempty"`
+	DataRange      string         `json:"data_range"`               // e.g. "B2:B10"
+	CategoryRange  string         `json:"category_range,omitempty"` // e.g. "A2:A10"
+	LegendPosition LegendPosition `json:"legend_position,omitempty"`
+	XAxisTitle     string         `json:"x_axis_title,omitempty"`
+	YAxisTitle     string         `json:"y_axis_title,omitempty"`
+}
+
+// ChartSeries is a ChartSpec's DataRange/CategoryRange resolved against a
+// sheet's cells into plain values, ready for SVG/gofpdf rendering.
+type ChartSeries struct {
+	Spec       ChartSpec
+	Values     []float64
+	Categories []string
+}
+
+// ResolveChartSeries evaluates spec's DataRange and CategoryRange against
+// sheet's cells, in range order, defaulting a missing/non-numeric data cell
+// to 0 and a missing category to its 1-indexed position so a chart with a
+// shorter category range still renders something for every value.
+func ResolveChartSeries(sheet SheetInfo, spec ChartSpec) (ChartSeries, error) {
+	r1, c1, r2, c2, err := parseChartRange(spec.DataRange)
+	if err != nil {
+		return ChartSeries{}, fmt.Errorf("chart %q: invalid data range %q: %v", spec.Title, spec.DataRange, err)
+	}
+
+	var values []float64
+	for row := r1; row <= r2; row++ {
+		for col := c1; col <= c2; col++ {
+			values = append(values, cellFloat(sheet.GlobalData[[2]int{int(row), int(col)}]))
+		}
+	}
+
+	categories := make([]string, len(values))
+	for i := range categories {
+		categories[i] = strconv.Itoa(i + 1)
+	}
+	if spec.CategoryRange != "" {
+		cr1, cc1, cr2, cc2, err := parseChartRange(spec.CategoryRange)
+		if err != nil {
+			return ChartSeries{}, fmt.Errorf("chart %q: invalid category range %q: %v", spec.Title, spec.CategoryRange, err)
+		}
+		i := 0
+		for row := cr1; row <= cr2 && i < len(categories); row++ {
+			for col := cc1; col <= cc2 && i < len(categories); col++ {
+				categories[i] = cellText(sheet.GlobalData[[2]int{int(row), int(col)}])
+				i++
+			}
+		}
+	}
+
+	return ChartSeries{Spec: spec, Values: values, Categories: categories}, nil
+}
+
+// parseChartRange splits a "B2:B10" (or bare "B2") range into its 1-indexed
+// row/col bounds, reusing recalc.go's parseCellAddr for the column-letter
+// math.
+func parseChartRange(ref string) (r1, c1, r2, c2 int32, err error) {
+	ref = strings.ReplaceAll(strings.TrimSpace(ref), "$", "")
+	parts := strings.SplitN(ref, ":", 2)
+
+	r1, c1, ok := parseCellAddr(parts[0])
+	if !ok {
+		return 0, 0, 0, 0, fmt.Errorf("not a cell reference")
+	}
+	if len(parts) == 1 {
+		return r1, c1, r1, c1, nil
+	}
+
+	r2, c2, ok = parseCellAddr(parts[1])
+	if !ok {
+		return 0, 0, 0, 0, fmt.Errorf("not a cell reference")
+	}
+	if r1 > r2 {
+		r1, r2 = r2, r1
+	}
+	if c1 > c2 {
+		c1, c2 = c2, c1
+	}
+	return r1, c1, r2, c2, nil
+}
+
+// cellFloat returns c's numeric value, or 0 for an empty/non-numeric cell.
+// It prefers Display over RawValue, like cellText below and recalc.go's
+// cellValue, so a formula cell's computed result is plotted rather than
+// its formula text (which never parses as a float and would silently
+// plot 0).
+func cellFloat(c *cell.Cell) float64 {
+	if c == nil {
+		return 0
+	}
+	text := ""
+	if c.Display != nil {
+		text = *c.Display
+	} else if c.RawValue != nil {
+		text = *c.RawValue
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// cellText returns c's display text, or "" for an empty cell.
+func cellText(c *cell.Cell) string {
+	if c == nil {
+		return ""
+	}
+	if c.Display != nil {
+		return *c.Display
+	}
+	if c.RawValue != nil {
+		return *c.RawValue
+	}
+	return ""
+}
@@ -9,6 +9,7 @@ package fileop
 
 import (
 	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
 )
 
 // CellData represents the data of a single cell in the saved spreadsheet
@@ -19,10 +20,14 @@ type CellData struct {
 
 // SheetData represents the structure of a single sheet
 type SheetData struct {
-	Name  string                  `json:"name"`
-	Rows  int32                   `json:"rows"`
-	Cols  int32                   `json:"cols"`
-	Cells map[string]*CellData    `json:"cells"`
+	Name   string               `json:"name"`
+	Rows   int32                `json:"rows"`
+	Cols   int32                `json:"cols"`
+	Color  string               `json:"color,omitempty"`
+	Group  string               `json:"group,omitempty"`
+	Cells  map[string]*CellData `json:"cells"`
+	Merges []utils.Range        `json:"merges,omitempty"`
+	Charts []ChartSpec          `json:"charts,omitempty"`
 }
 
 // SheetInfo is amost the same as SheetData
@@ -30,12 +35,58 @@ type SheetInfo struct {
 	Name       string
 	Rows       int32
 	Cols       int32
+	Color      string
+	Group      string
 	GlobalData map[[2]int]*cell.Cell
+	Merges     []utils.Range
+	Charts     []ChartSpec
 }
 
 // WorkbookData represents the complete workbook structure for saving/loading
 type WorkbookData struct {
-	Version     string       `json:"version"`
-	ActiveSheet int          `json:"active_sheet"`
-	Sheets      []SheetData  `json:"sheets"`
+	Version     string          `json:"version"`
+	ActiveSheet int             `json:"active_sheet"`
+	Sheets      []SheetData     `json:"sheets"`
+	Names       []NamedRange    `json:"names,omitempty"`
+	Functions   []SavedFunction `json:"functions,omitempty"`
+}
+
+// NamedRange is a user-defined name (e.g. "Revenue") bound to a region of a
+// specific sheet, so formulas can reference it instead of a raw range like
+// Sheet2!B2:B10.
+type NamedRange struct {
+	Name   string `json:"name"`
+	Sheet  string `json:"sheet"`
+	Region string `json:"region"` // e.g. "B2:B10" or "B2" for a single cell
+}
+
+// SavedFunction is a user-defined LAMBDA persisted in the workbook file, so
+// it survives save/load the same way NamedRange does - this package only
+// carries the plain (name, params, body) triple; compiling it back into a
+// callable (utils.RegisterLambda) is the caller's job, same division of
+// responsibility as NamedRange versus the evaluator's named-range lookup.
+type SavedFunction struct {
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+	Body   string   `json:"body"`
+}
+
+// savedFunctionsFromLambdas converts utils.SnapshotUserLambdas' output into
+// the []SavedFunction shape WorkbookData.Functions persists.
+func savedFunctionsFromLambdas(defs []utils.LambdaDef) []SavedFunction {
+	out := make([]SavedFunction, 0, len(defs))
+	for _, def := range defs {
+		out = append(out, SavedFunction{Name: def.Name, Params: def.Params, Body: def.Body})
+	}
+	return out
+}
+
+// lambdaDefsFromSaved is savedFunctionsFromLambdas' inverse, for handing a
+// loaded workbook's Functions to utils.RestoreUserLambdas.
+func lambdaDefsFromSaved(fns []SavedFunction) []utils.LambdaDef {
+	out := make([]utils.LambdaDef, 0, len(fns))
+	for _, fn := range fns {
+		out = append(out, utils.LambdaDef{Name: fn.Name, Params: fn.Params, Body: fn.Body})
+	}
+	return out
 }
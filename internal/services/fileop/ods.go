@@ -0,0 +1,460 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// ods.go adds OpenDocument Spreadsheet (.ods) import/export alongside the
+// .gsheet/.json/.xlsx formats. .ods is a ZIP of XML parts (a
+// stored-not-deflated "mimetype" entry, META-INF/manifest.xml,
+// content.xml), and this file reads and writes the minimum-viable package
+// by hand with archive/zip and encoding/xml rather than vendoring an ODF
+// library this tree has no go.mod to pull in. content.xml nests
+// office:document-content -> office:body -> office:spreadsheet ->
+// table:table -> table:table-row -> table:table-cell, the same structure
+// LibreOffice Calc reads and writes. On write, bold/italic/underline,
+// alignment, and text/background color go into office:automatic-styles,
+// deduplicated by odsStyleTable; the reader does not parse those styles
+// back, so a cell loaded from .ods has default formatting.
+package fileop
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+
+	"github.com/rivo/tview"
+)
+
+// ODSFormatHandler handles .ods import/export, delegating to
+// OpenODSWorkbook/SaveWorkbookAsODS below for the actual codec.
+type ODSFormatHandler struct{}
+
+func (h *ODSFormatHandler) SupportsFormat(format FileFormat) bool {
+	return format == FormatODS
+}
+
+func (h *ODSFormatHandler) Extensions() []string {
+	return []string{".ods"}
+}
+
+func (h *ODSFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	return SaveWorkbookAsODS(sheets, activeSheet, filename)
+}
+
+func (h *ODSFormatHandler) Read(filename string) ([]SheetInfo, int, error) {
+	result, err := OpenODSWorkbook(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sheetInfosFromResult(result), result.ActiveSheet, nil
+}
+
+// --- Writing -----------------------------------------------------------
+
+const odsMimetype = "application/vnd.oasis.opendocument.spreadsheet"
+
+var odsManifestXML = xmlHeader + `<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+	`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimetype + `"/>` +
+	`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+	`</manifest:manifest>`
+
+// SaveWorkbookAsODS writes sheets out as a minimum-viable .ods package: an
+// uncompressed "mimetype" entry (ODF readers require it to be both first
+// and stored rather than deflated to recognize the file), a
+// META-INF/manifest.xml listing the package contents, and a content.xml
+// holding every sheet's table:table.
+func SaveWorkbookAsODS(sheets []SheetInfo, activeSheet int, filename string) error {
+	if !strings.HasSuffix(filename, ".ods") {
+		if idx := strings.Index(filename, "."); idx != -1 {
+			filename = filename[:idx]
+		}
+		filename += ".ods"
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	defer zw.Close()
+
+	mimeWriter, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := mimeWriter.Write([]byte(odsMimetype)); err != nil {
+		return err
+	}
+
+	if err := writeZipEntry(zw, "META-INF/manifest.xml", odsManifestXML); err != nil {
+		return err
+	}
+
+	styles := newODSStyleTable()
+	content := buildODSContentXML(sheets, styles)
+	return writeZipEntry(zw, "content.xml", content)
+}
+
+// buildODSContentXML builds content.xml's document-content root, one
+// table:table per sheet plus the automatic-styles indexFor collected while
+// building them.
+func buildODSContentXML(sheets []SheetInfo, styles *odsStyleTable) string {
+	var tables strings.Builder
+	for _, sheet := range sheets {
+		tables.WriteString(buildODSTableXML(sheet, styles))
+	}
+
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<office:document-content ` +
+		`xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+		`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+		`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+		`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+		`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" ` +
+		`office:version="1.2">`)
+	b.WriteString(`<office:automatic-styles>`)
+	b.WriteString(styles.toXML())
+	b.WriteString(`</office:automatic-styles>`)
+	b.WriteString(`<office:body><office:spreadsheet>`)
+	b.WriteString(tables.String())
+	b.WriteString(`</office:spreadsheet></office:body>`)
+	b.WriteString(`</office:document-content>`)
+	return b.String()
+}
+
+// buildODSTableXML builds one sheet's table:table, expanding
+// sheet.GlobalData into consecutive table:table-row/table:table-cell
+// elements from (1,1) to (sheet.Rows, sheet.Cols).
+func buildODSTableXML(sheet SheetInfo, styles *odsStyleTable) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<table:table table:name="%s">`, xmlEscape(sheet.Name))
+
+	for row := int32(1); row <= sheet.Rows; row++ {
+		b.WriteString("<table:table-row>")
+		for col := int32(1); col <= sheet.Cols; col++ {
+			c, exists := sheet.GlobalData[[2]int{int(row), int(col)}]
+			b.WriteString(odsCellXML(c, exists, styles))
+		}
+		b.WriteString("</table:table-row>")
+	}
+
+	b.WriteString("</table:table>")
+	return b.String()
+}
+
+// odsCellXML renders one table:table-cell, honoring numeric vs. string vs.
+// date office:value-type the way numFmtCode picks an xlsx number format:
+// c.Type drives whether office:value carries a float, office:date-value
+// carries a date, or the cell falls back to a plain string value.
+func odsCellXML(c *cell.Cell, exists bool, styles *odsStyleTable) string {
+	if !exists || c == nil || c.RawValue == nil || *c.RawValue == "" {
+		return "<table:table-cell/>"
+	}
+
+	styleAttr := ""
+	if idx := styles.indexFor(c); idx != 0 {
+		styleAttr = fmt.Sprintf(` table:style-name="%s"`, odsStyleName(idx))
+	}
+
+	display := *c.RawValue
+	if c.Display != nil {
+		display = *c.Display
+	}
+
+	valueType, valueAttr := odsValueTypeAndValue(c)
+
+	return fmt.Sprintf(`<table:table-cell office:value-type="%s"%s%s><text:p>%s</text:p></table:table-cell>`,
+		valueType, valueAttr, styleAttr, xmlEscape(display))
+}
+
+// odsValueTypeAndValue picks office:value-type and, for numeric/date cells,
+// the office:value/office:date-value attribute ODF readers use instead of
+// re-parsing the display text.
+func odsValueTypeAndValue(c *cell.Cell) (valueType, valueAttr string) {
+	if c.Type != nil {
+		switch *c.Type {
+		case "number", "financial":
+			if f, err := strconv.ParseFloat(strings.TrimSpace(*c.RawValue), 64); err == nil {
+				return "float", fmt.Sprintf(` office:value="%s"`, strconv.FormatFloat(f, 'f', -1, 64))
+			}
+		case "datetime":
+			return "date", fmt.Sprintf(` office:date-value="%s"`, xmlEscape(*c.RawValue))
+		}
+	}
+	return "string", ""
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// --- Styles --------------------------------------------------------------
+
+// odsCellStyle is the subset of an ODF style:style/style:text-properties
+// entry this package understands - the .ods counterpart of xlsx.go's
+// xlsxCellStyle.
+type odsCellStyle struct {
+	bold, italic, underline bool
+	align                   int8 // tview.AlignLeft/Center/Right
+	textColor               string
+	bgColor                 string
+}
+
+// odsStyleTable dedupes odsCellStyle combinations the same way xlsx.go's
+// styleIDFor dedupes xlsxCellStyle values; index 0 is the no-override style
+// and is never emitted as an automatic-style.
+type odsStyleTable struct {
+	index   map[odsCellStyle]int
+	ordered []odsCellStyle
+}
+
+func newODSStyleTable() *odsStyleTable {
+	return &odsStyleTable{index: map[odsCellStyle]int{{}: 0}, ordered: []odsCellStyle{{}}}
+}
+
+// indexFor returns c's automatic-style index, interning a new style the
+// first time a given bold/italic/underline/align/color combination is seen.
+func (t *odsStyleTable) indexFor(c *cell.Cell) int {
+	style := odsCellStyle{
+		bold:      c.HasFlag(cell.FlagBold),
+		italic:    c.HasFlag(cell.FlagItalic),
+		underline: c.HasFlag(cell.FlagUnderline),
+		align:     c.Align,
+	}
+	if !c.Color.IsDefaultWhite() {
+		style.textColor = hexDigits(c.Color.Hex())
+	}
+	if !c.BgColor.IsDefaultBlack() && !c.BgColor.IsDefaultWhite() {
+		style.bgColor = hexDigits(c.BgColor.Hex())
+	}
+	if style == (odsCellStyle{}) {
+		return 0
+	}
+	if idx, ok := t.index[style]; ok {
+		return idx
+	}
+	idx := len(t.ordered)
+	t.index[style] = idx
+	t.ordered = append(t.ordered, style)
+	return idx
+}
+
+func odsStyleName(idx int) string {
+	return fmt.Sprintf("ce%d", idx)
+}
+
+// toXML emits one style:style per non-default entry in t.ordered.
+func (t *odsStyleTable) toXML() string {
+	var b strings.Builder
+	for i, s := range t.ordered {
+		if i == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, `<style:style style:name="%s" style:family="table-cell">`, odsStyleName(i))
+		b.WriteString(`<style:text-properties`)
+		if s.bold {
+			b.WriteString(` fo:font-weight="bold"`)
+		}
+		if s.italic {
+			b.WriteString(` fo:font-style="italic"`)
+		}
+		if s.underline {
+			b.WriteString(` style:text-underline-style="solid"`)
+		}
+		if s.textColor != "" {
+			fmt.Fprintf(&b, ` fo:color="#%s"`, s.textColor)
+		}
+		b.WriteString("/>")
+		if s.bgColor != "" {
+			fmt.Fprintf(&b, `<style:table-cell-properties fo:background-color="#%s"/>`, s.bgColor)
+		}
+		if align := odsAlign(s.align); align != "" {
+			fmt.Fprintf(&b, `<style:paragraph-properties fo:text-align="%s"/>`, align)
+		}
+		b.WriteString(`</style:style>`)
+	}
+	return b.String()
+}
+
+func odsAlign(align int8) string {
+	switch align {
+	case tview.AlignCenter:
+		return "center"
+	case tview.AlignRight:
+		return "end"
+	default:
+		return ""
+	}
+}
+
+// --- Reading ---------------------------------------------------------------
+
+type odsDocumentContent struct {
+	Body odsBody `xml:"body"`
+}
+
+type odsBody struct {
+	Spreadsheet odsSpreadsheet `xml:"spreadsheet"`
+}
+
+type odsSpreadsheet struct {
+	Tables []odsTable `xml:"table"`
+}
+
+type odsTable struct {
+	Name string   `xml:"name,attr"`
+	Rows []odsRow `xml:"table-row"`
+}
+
+type odsRow struct {
+	Cells []odsCell `xml:"table-cell"`
+}
+
+type odsCell struct {
+	ValueType       string   `xml:"value-type,attr"`
+	Value           string   `xml:"value,attr"`
+	DateValue       string   `xml:"date-value,attr"`
+	NumColsRepeated int      `xml:"number-columns-repeated,attr"`
+	Paragraphs      []string `xml:"p"`
+}
+
+// OpenODSWorkbook loads a workbook from a .ods file, in the same shape
+// OpenWorkbook/OpenXLSXWorkbook return so callers can treat every format
+// identically once loaded.
+func OpenODSWorkbook(filename string) (*WorkbookResult, error) {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ods: %v", err)
+	}
+	defer zr.Close()
+
+	var contentPart *zip.File
+	for _, f := range zr.File {
+		if f.Name == "content.xml" {
+			contentPart = f
+			break
+		}
+	}
+	if contentPart == nil {
+		return nil, fmt.Errorf("content.xml not found in ods package")
+	}
+
+	rc, err := contentPart.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var doc odsDocumentContent
+	if err := xml.NewDecoder(rc).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid content.xml: %v", err)
+	}
+
+	result := &WorkbookResult{
+		Sheets:      make([]SheetResult, 0, len(doc.Body.Spreadsheet.Tables)),
+		ActiveSheet: 0,
+		Version:     utils.FILEVER,
+	}
+
+	for _, table := range doc.Body.Spreadsheet.Tables {
+		cells, rows, cols := odsTableToCells(table)
+		result.Sheets = append(result.Sheets, SheetResult{
+			Name:  table.Name,
+			Cells: cells,
+			Rows:  rows,
+			Cols:  cols,
+		})
+	}
+
+	return result, nil
+}
+
+// odsTableToCells expands a table:table's rows/cells into GoSheet's cell
+// model, repeating a table:table-cell table:number-columns-repeated times
+// the way LibreOffice collapses long runs of identical/empty cells.
+func odsTableToCells(table odsTable) ([]*cell.Cell, int32, int32) {
+	var cells []*cell.Cell
+	var maxCol int32
+
+	for r, row := range table.Rows {
+		rowNum := int32(r + 1)
+		col := int32(1)
+		for _, oc := range row.Cells {
+			repeat := oc.NumColsRepeated
+			if repeat < 1 {
+				repeat = 1
+			}
+			for i := 0; i < repeat; i++ {
+				if oc.Value != "" || strings.Join(oc.Paragraphs, "") != "" {
+					cells = append(cells, newODSCell(rowNum, col, oc))
+				}
+				if col > maxCol {
+					maxCol = col
+				}
+				col++
+			}
+		}
+	}
+
+	return cells, int32(len(table.Rows)), maxCol
+}
+
+// newODSCell builds a GoSheet cell.Cell from a parsed table:table-cell,
+// filling every pointer field openTxtFile (open.go) also backfills so the
+// cell is safe to render and re-save immediately after load.
+func newODSCell(row, col int32, oc odsCell) *cell.Cell {
+	raw := oc.Value
+	display := strings.Join(oc.Paragraphs, "\n")
+	if raw == "" {
+		raw = display
+	}
+	if display == "" {
+		display = raw
+	}
+
+	typeValue := "string"
+	if oc.ValueType == "float" {
+		typeValue = "number"
+	} else if oc.ValueType == "date" {
+		typeValue = "datetime"
+	}
+
+	emptyStr := ""
+	autotype := "auto"
+
+	return &cell.Cell{
+		Row:      row,
+		Column:   col,
+		MaxWidth: utils.DEFAULT_CELL_MAX_WIDTH,
+		MinWidth: utils.DEFAULT_CELL_MIN_WIDTH,
+		RawValue: &raw,
+		Display:  &display,
+		Type:     &typeValue,
+
+		Notes:   &emptyStr,
+		Valrule: &emptyStr,
+
+		Color:   utils.ColorOptions["White"],
+		BgColor: utils.ColorOptions["Black"],
+
+		DecimalPoints:      utils.DEFAULT_CELL_DECIMAL_POINTS,
+		ThousandsSeparator: utils.DEFAULT_CELL_THOUSANDS_SEPARATOR,
+		DecimalSeparator:   utils.DEFAULT_CELL_DECIMAL_SEPARATOR,
+		FinancialSign:      utils.DEFAULT_CELL_FINANCIAL_SIGN,
+		DateTimeFormat:     &autotype,
+
+		DependsOn:  []*string{},
+		Dependents: []*string{},
+	}
+}
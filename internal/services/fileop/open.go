@@ -24,14 +24,20 @@ type WorkbookResult struct {
 	Sheets      []SheetResult
 	ActiveSheet int
 	Version     string
+	Names       []NamedRange
+	Functions   []SavedFunction
 }
 
 // SheetResult contains the data for a single sheet
 type SheetResult struct {
-	Name  string
-	Cells []*cell.Cell
-	Rows  int32
-	Cols  int32
+	Name   string
+	Cells  []*cell.Cell
+	Rows   int32
+	Cols   int32
+	Color  string
+	Group  string
+	Merges []utils.Range
+	Charts []ChartSpec
 }
 
 // OpenWorkbook loads a workbook from a .gsheet, .json, or .txt file
@@ -40,6 +46,14 @@ func OpenWorkbook(filename string) (*WorkbookResult, error) {
 		return nil, fmt.Errorf("file does not exist")
 	}
 
+	if strings.HasSuffix(filename, ".xlsx") {
+		return OpenXLSXWorkbook(filename)
+	}
+
+	if strings.HasSuffix(filename, ".ods") {
+		return OpenODSWorkbook(filename)
+	}
+
 	if strings.HasSuffix(filename, ".txt") {
 		cells, rows, cols, err := openTxtFile(filename)
 		if err != nil {
@@ -60,7 +74,7 @@ func OpenWorkbook(filename string) (*WorkbookResult, error) {
 	}
 
 	if !(strings.HasSuffix(filename, ".gsheet") || strings.HasSuffix(filename, ".json")) {
-		return nil, fmt.Errorf("invalid file format (expected .gsheet, .json, or .txt)")
+		return nil, fmt.Errorf("invalid file format (expected .gsheet, .json, .xlsx, .ods, or .txt)")
 	}
 
 	file, err := os.Open(filename)
@@ -79,8 +93,16 @@ func OpenWorkbook(filename string) (*WorkbookResult, error) {
 		reader = gz
 	}
 
+	bufReader := bufio.NewReader(reader)
+	if magic, err := bufReader.Peek(len(gsheetNDJSONMagic)); err == nil && string(magic) == gsheetNDJSONMagic {
+		if _, err := bufReader.ReadString('\n'); err != nil {
+			return nil, fmt.Errorf("failed to read ndjson magic line: %v", err)
+		}
+		return loadGSheetNDJSON(bufReader)
+	}
+
 	var wbData WorkbookData
-	if err := json.NewDecoder(reader).Decode(&wbData); err != nil {
+	if err := json.NewDecoder(bufReader).Decode(&wbData); err != nil {
 		return nil, fmt.Errorf("failed to decode workbook: %v", err)
 	}
 
@@ -94,15 +116,21 @@ func OpenWorkbook(filename string) (*WorkbookResult, error) {
 		Sheets:      make([]SheetResult, 0, len(wbData.Sheets)),
 		ActiveSheet: wbData.ActiveSheet,
 		Version:     wbData.Version,
+		Names:       wbData.Names,
+		Functions:   wbData.Functions,
 	}
 
 	for _, sheetData := range wbData.Sheets {
 		cells := processCellData(sheetData.Cells)
 		result.Sheets = append(result.Sheets, SheetResult{
-			Name:  sheetData.Name,
-			Cells: cells,
-			Rows:  sheetData.Rows,
-			Cols:  sheetData.Cols,
+			Name:   sheetData.Name,
+			Cells:  cells,
+			Rows:   sheetData.Rows,
+			Cols:   sheetData.Cols,
+			Color:  sheetData.Color,
+			Group:  sheetData.Group,
+			Merges: sheetData.Merges,
+			Charts: sheetData.Charts,
 		})
 	}
 
@@ -174,7 +202,10 @@ func processCellData(cellDataMap map[string]*CellData) []*cell.Cell {
 	return cells
 }
 
-// Legacy OpenTable function for backward compatibility - returns only first sheet
+// Legacy OpenTable function for backward compatibility - returns only first
+// sheet. Any LAMBDA functions the workbook was saved with are restored into
+// the session-wide UDF registry (see utils.RestoreUserLambdas) so formulas
+// referencing them keep working once the file is reopened.
 func OpenTable(filename string) ([]*cell.Cell, error) {
 	result, err := OpenWorkbook(filename)
 	if err != nil {
@@ -185,6 +216,8 @@ func OpenTable(filename string) ([]*cell.Cell, error) {
 		return nil, fmt.Errorf("no sheets found in workbook")
 	}
 
+	utils.RestoreUserLambdas(lambdaDefsFromSaved(result.Functions))
+
 	firstSheet := result.Sheets[0]
 	return firstSheet.Cells, nil
 }
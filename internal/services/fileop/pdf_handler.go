@@ -9,8 +9,11 @@ package fileop
 
 import (
 	"fmt"
+	"math"
+
 	"gosheet/internal/services/cell"
 	"gosheet/internal/utils"
+
 	"github.com/jung-kurt/gofpdf"
 )
 
@@ -21,25 +24,34 @@ func (h *PDFFormatHandler) SupportsFormat(format FileFormat) bool {
 	return format == FormatPDF
 }
 
+func (h *PDFFormatHandler) Extensions() []string {
+	return []string{".pdf"}
+}
+
 func (h *PDFFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
 	pdf := gofpdf.New("L", "mm", "A4", "")
 	pdf.SetCreator("GoSheet", true)
 	pdf.SetAuthor("GoSheet User", true)
 	pdf.SetTitle("Exported Spreadsheet", true)
 	pdf.SetMargins(15, 15, 15)
-	
+
 	pdf.AddPage()
-	
+
 	for i, sheet := range sheets {
 		if i > 0 {
 			pdf.AddPage()
 		}
-		
+
 		if err := h.writeSheetToPDF(pdf, sheet, i+1, len(sheets)); err != nil {
 			return err
 		}
+
+		if len(sheet.Charts) > 0 {
+			pdf.AddPage()
+			h.writeChartsToPDF(pdf, sheet)
+		}
 	}
-	
+
 	return pdf.OutputFileAndClose(filename)
 }
 
@@ -47,7 +59,7 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 	pdf.SetFont("Helvetica", "B", 16)
 	pdf.CellFormat(0, 10, fmt.Sprintf("Sheet %d/%d: %s", sheetNum, totalSheets, sheet.Name), "", 1, "L", false, 0, "")
 	pdf.Ln(5)
-	
+
 	var maxRow, maxCol int32
 	for k := range sheet.GlobalData {
 		if int32(k[0]) > maxRow {
@@ -57,26 +69,26 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 			maxCol = int32(k[1])
 		}
 	}
-	
+
 	if maxCol == 0 || maxRow == 0 {
 		pdf.SetFont("Helvetica", "I", 12)
 		pdf.Cell(0, 10, "(Empty sheet)")
 		return nil
 	}
-	
+
 	const (
 		margin    = 15.0
 		headerH   = 8.0
 		rowH      = 7.0
 		maxColW   = 40.0
 		minColW   = 12.0
-		pageWidth = 297.0 - 2*margin 
+		pageWidth = 297.0 - 2*margin
 	)
-	
+
 	colCount := int(maxCol)
 	colWidths := make([]float64, colCount)
 	baseWidth := pageWidth / float64(colCount)
-	
+
 	for i := range colWidths {
 		colWidths[i] = baseWidth
 		if colWidths[i] < minColW {
@@ -86,7 +98,7 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 			colWidths[i] = maxColW
 		}
 	}
-	
+
 	for _, c := range sheet.GlobalData {
 		if c.MinWidth > 0 {
 			needed := float64(c.MinWidth) * 1.8
@@ -101,40 +113,40 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 			}
 		}
 	}
-	
+
 	tr := pdf.UnicodeTranslatorFromDescriptor("")
-	
+
 	h.drawHeaderRow(pdf, colWidths, headerH, maxCol)
-	
+
 	pdf.SetFont("Courier", "", 9)
-	
+
 	for row := int32(1); row <= maxRow; row++ {
 		if pdf.GetY() > 185 {
 			pdf.AddPage()
 			h.drawHeaderRow(pdf, colWidths, headerH, maxCol)
 			pdf.SetFont("Courier", "", 9)
 		}
-		
+
 		for col := int32(1); col <= maxCol; col++ {
 			key := [2]int{int(row), int(col)}
 			cellData, exists := sheet.GlobalData[key]
-			
+
 			text := ""
 			align := "L"
 			style := ""
 			fill := false
-			
+
 			if exists && cellData != nil {
 				if cellData.Display != nil {
 					text = tr(*cellData.Display)
 					text = cell.StripTviewTags(text)
-					
+
 					if len([]rune(text)) > 40 {
 						runes := []rune(text)
 						text = string(runes[:37]) + "..."
 					}
 				}
-				
+
 				switch cellData.Align {
 				case 1:
 					align = "L"
@@ -143,7 +155,7 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 				case 3:
 					align = "R"
 				}
-				
+
 				if cellData.HasFlag(cell.FlagBold) && cellData.HasFlag(cell.FlagItalic) {
 					style = "BI"
 				} else if cellData.HasFlag(cell.FlagBold) {
@@ -151,23 +163,23 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 				} else if cellData.HasFlag(cell.FlagItalic) {
 					style = "I"
 				}
-				
+
 				if !cellData.BgColor.IsDefaultBlack() && !cellData.BgColor.IsDefaultWhite() {
 					r, g, b := cellData.BgColor[0], cellData.BgColor[1], cellData.BgColor[2]
 					pdf.SetFillColor(int(r), int(g), int(b))
 					fill = true
 				}
 			}
-			
+
 			if style != "" {
 				pdf.SetFont("Courier", style, 9)
 			}
-			
+
 			colIdx := int(col - 1)
 			if colIdx >= 0 && colIdx < len(colWidths) {
 				pdf.CellFormat(colWidths[colIdx], rowH, text, "1", 0, align, fill, 0, "")
 			}
-			
+
 			if fill {
 				pdf.SetFillColor(255, 255, 255)
 			}
@@ -177,7 +189,7 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 		}
 		pdf.Ln(-1)
 	}
-	
+
 	return nil
 }
 
@@ -185,16 +197,181 @@ func (h *PDFFormatHandler) writeSheetToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo, sh
 func (h *PDFFormatHandler) drawHeaderRow(pdf *gofpdf.Fpdf, colWidths []float64, height float64, maxCol int32) {
 	pdf.SetFont("Helvetica", "B", 10)
 	pdf.SetFillColor(220, 220, 220)
-	
+
 	for i := range maxCol {
 		colName := utils.ColumnName(i + 1)
-		
+
 		colIdx := int(i)
 		if colIdx >= 0 && colIdx < len(colWidths) {
 			pdf.CellFormat(colWidths[colIdx], height, colName, "1", 0, "C", true, 0, "")
 		}
 	}
 	pdf.Ln(-1)
-	
+
 	pdf.SetFillColor(255, 255, 255)
 }
+
+// pdfChartColors mirrors save.go's chartColors palette, given as RGB triples
+// gofpdf's SetFillColor/SetDrawColor can use directly.
+var pdfChartColors = [][3]int{
+	{76, 175, 80}, {33, 150, 243}, {255, 152, 0}, {156, 39, 176}, {244, 67, 54}, {0, 188, 212}, {255, 193, 7},
+}
+
+// writeChartsToPDF draws one of sheet's charts per page of its own, using
+// gofpdf primitives (Line, Rect, Polygon, Circle) against each chart's
+// resolved ChartSeries - a plain-shapes equivalent of SaveTableAsHTML's SVG
+// rendering, skipping any chart whose range fails to resolve.
+func (h *PDFFormatHandler) writeChartsToPDF(pdf *gofpdf.Fpdf, sheet SheetInfo) {
+	for i, spec := range sheet.Charts {
+		if i > 0 {
+			pdf.AddPage()
+		}
+
+		series, err := ResolveChartSeries(sheet, spec)
+		if err != nil {
+			pdf.SetFont("Helvetica", "I", 12)
+			pdf.Cell(0, 10, fmt.Sprintf("Chart %q: %v", spec.Title, err))
+			continue
+		}
+
+		pdf.SetFont("Helvetica", "B", 14)
+		title := spec.Title
+		if title == "" {
+			title = "Chart"
+		}
+		pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+		pdf.Ln(5)
+
+		if spec.Type == ChartPie {
+			h.drawPieChartPDF(pdf, series)
+		} else {
+			h.drawAxesChartPDF(pdf, series)
+		}
+	}
+}
+
+// Chart plot area, in mm, for the PDF renderer.
+const (
+	pdfChartX    = 30.0
+	pdfChartY    = 60.0
+	pdfChartW    = 200.0
+	pdfChartH    = 100.0
+	pdfPieRadius = 45.0
+)
+
+// drawAxesChartPDF draws line/bar/scatter charts via Line/Rect/Circle against
+// a shared x/y axis, the same scaling approach as save.go's renderAxesSVG.
+func (h *PDFFormatHandler) drawAxesChartPDF(pdf *gofpdf.Fpdf, series ChartSeries) {
+	n := len(series.Values)
+	if n == 0 {
+		return
+	}
+
+	maxVal, minVal := series.Values[0], series.Values[0]
+	for _, v := range series.Values {
+		if v > maxVal {
+			maxVal = v
+		}
+		if v < minVal {
+			minVal = v
+		}
+	}
+	if minVal > 0 {
+		minVal = 0
+	}
+	valRange := maxVal - minVal
+	if valRange == 0 {
+		valRange = 1
+	}
+
+	x := func(i int) float64 {
+		if n == 1 {
+			return pdfChartX + pdfChartW/2
+		}
+		return pdfChartX + pdfChartW*float64(i)/float64(n-1)
+	}
+	y := func(v float64) float64 {
+		return pdfChartY + pdfChartH*(1-(v-minVal)/valRange)
+	}
+
+	pdf.SetDrawColor(51, 51, 51)
+	pdf.Line(pdfChartX, pdfChartY+pdfChartH, pdfChartX+pdfChartW, pdfChartY+pdfChartH)
+	pdf.Line(pdfChartX, pdfChartY, pdfChartX, pdfChartY+pdfChartH)
+
+	r, g, b := pdfChartColors[0][0], pdfChartColors[0][1], pdfChartColors[0][2]
+	pdf.SetDrawColor(r, g, b)
+	pdf.SetFillColor(r, g, b)
+
+	switch series.Spec.Type {
+	case ChartBar:
+		barW := pdfChartW / float64(n) * 0.6
+		for i, v := range series.Values {
+			cx, cy := x(i), y(v)
+			base := y(minVal)
+			pdf.Rect(cx-barW/2, math.Min(cy, base), barW, math.Abs(base-cy), "F")
+		}
+	case ChartScatter:
+		for i, v := range series.Values {
+			pdf.Circle(x(i), y(v), 1.2, "F")
+		}
+	default: // ChartLine
+		for i := 0; i < n-1; i++ {
+			pdf.Line(x(i), y(series.Values[i]), x(i+1), y(series.Values[i+1]))
+		}
+		for i, v := range series.Values {
+			pdf.Circle(x(i), y(v), 1, "F")
+		}
+	}
+
+	pdf.SetFont("Helvetica", "", 7)
+	for i, label := range series.Categories {
+		pdf.Text(x(i)-5, pdfChartY+pdfChartH+5, label)
+	}
+}
+
+// drawPieChartPDF draws series as pie wedges via gofpdf's Polygon, each wedge
+// approximated as a fan of triangles from the chart center since gofpdf has
+// no arc/wedge primitive, colored from pdfChartColors cycled across slices.
+func (h *PDFFormatHandler) drawPieChartPDF(pdf *gofpdf.Fpdf, series ChartSeries) {
+	total := 0.0
+	for _, v := range series.Values {
+		total += v
+	}
+	if total == 0 {
+		return
+	}
+
+	cx := pdfChartX + pdfChartW/2
+	cy := pdfChartY + pdfChartH/2
+
+	const steps = 24
+	angle := -math.Pi / 2
+	for i, v := range series.Values {
+		sweep := v / total * 2 * math.Pi
+		color := pdfChartColors[i%len(pdfChartColors)]
+		pdf.SetFillColor(color[0], color[1], color[2])
+
+		points := []gofpdf.PointType{{X: cx, Y: cy}}
+		wedgeSteps := int(math.Max(1, float64(steps)*sweep/(2*math.Pi)))
+		for s := 0; s <= wedgeSteps; s++ {
+			a := angle + sweep*float64(s)/float64(wedgeSteps)
+			points = append(points, gofpdf.PointType{
+				X: cx + pdfPieRadius*math.Cos(a),
+				Y: cy + pdfPieRadius*math.Sin(a),
+			})
+		}
+		pdf.Polygon(points, "F")
+		angle += sweep
+	}
+
+	if series.Spec.LegendPosition != LegendNone {
+		pdf.SetFont("Helvetica", "", 8)
+		for i, label := range series.Categories {
+			ly := pdfChartY + pdfChartH + 10 + float64(i)*6
+			color := pdfChartColors[i%len(pdfChartColors)]
+			pdf.SetFillColor(color[0], color[1], color[2])
+			pdf.Rect(pdfChartX, ly, 4, 4, "F")
+			pdf.Text(pdfChartX+6, ly+4, label)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// recalc.go adds a dependency-graph-driven recalculation pass for sheets
+// loaded via LoadWorkbook/OpenWorkbook. The .gsheet/.json format persists
+// each formula cell's raw text plus a cached Display rather than rebuilding
+// it from a live table.Session, so after a load every formula's Display is
+// only as fresh as it was when last saved - Recalculate brings it back in
+// sync. It tokenizes every formula cell's (cell.IsFormula) references (same-sheet
+// B12 and cross-sheet Sheet2!A1/'Sales 2024'!A1:B3) into
+// evaluatefuncs.DependencyGraph edges - the graph/cycle-detection engine in
+// evaluatefuncs/dependency.go - topologically
+// sorts it, and evaluates through evaluatefuncs.Evaluator so SUM/AVERAGE/IF/
+// VLOOKUP/SUBTOTAL and cross-sheet references resolve the same way they do
+// live. A cell caught in a cycle is marked "#CIRC!"; a cell that depends on
+// one is marked "#REF!".
+package fileop
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+	"gosheet/internal/utils/evaluatefuncs"
+)
+
+// Recalculate re-evaluates every formula cell across sheets, in dependency
+// order, updating each cell's Display in place. When TopoOrder reports a
+// cycle, only that cycle's cells ("#CIRC!") and the cells depending on them
+// ("#REF!") are updated; every other formula cell keeps its loaded Display.
+func Recalculate(sheets []SheetInfo) {
+	graph, formulaCells := buildDependencyGraph(sheets)
+	order, cycle := graph.TopoOrder()
+	if cycle != nil {
+		markCycle(graph, cycle, formulaCells)
+		return
+	}
+	evaluateInOrder(sheets, graph, formulaCells, order, func(string) bool { return true })
+}
+
+// RecalculateCell re-evaluates every formula cell across sheets that
+// transitively depends on sheets[sheetIdx]'s (row, col) cell, without
+// re-evaluating the rest of the workbook. It would let a single cell edit
+// update only its dependents instead of paying LoadWorkbook's full-sweep
+// Recalculate cost, but nothing calls it yet: live editing works against
+// the ui package's own map[[2]int]*cell.Cell, not the []SheetInfo this
+// file (and the rest of fileop) operates on, and there's no point in the
+// tree that assembles one from the other on a per-edit basis. It's kept
+// here, built on the same buildDependencyGraph/transitiveDependents
+// helpers, for whatever edit path ends up bridging the two.
+func RecalculateCell(sheets []SheetInfo, sheetIdx int, row, col int32) {
+	graph, formulaCells := buildDependencyGraph(sheets)
+	changed := recalcNode(sheetIdx, row, col)
+	affected := transitiveDependents(graph, formulaCells, changed)
+	if len(affected) == 0 {
+		return
+	}
+
+	order, cycle := graph.TopoOrder()
+	if cycle != nil {
+		markCycle(graph, cycle, formulaCells)
+		return
+	}
+	evaluateInOrder(sheets, graph, formulaCells, order, func(node string) bool { return affected[node] })
+}
+
+// recalcNode addresses one cell as "sheetIdx:row:col", the
+// evaluatefuncs.DependencyGraph node ID this file uses - a numeric sheet
+// index rather than a name, so a sheet rename mid-recalculation can't
+// collide two distinct sheets onto the same node.
+func recalcNode(sheetIdx int, row, col int32) string {
+	return fmt.Sprintf("%d:%d:%d", sheetIdx, row, col)
+}
+
+// buildDependencyGraph scans every sheet for formula cells and wires a
+// DependencyGraph edge from each to every cell (same-sheet or cross-sheet)
+// its formula text references.
+func buildDependencyGraph(sheets []SheetInfo) (*evaluatefuncs.DependencyGraph, map[string]*cell.Cell) {
+	graph := evaluatefuncs.NewDependencyGraph()
+	formulaCells := make(map[string]*cell.Cell)
+
+	for sheetIdx, sheet := range sheets {
+		for key, c := range sheet.GlobalData {
+			if c == nil || c.RawValue == nil || !c.IsFormula() {
+				continue
+			}
+			node := recalcNode(sheetIdx, int32(key[0]), int32(key[1]))
+			formulaCells[node] = c
+			for _, dep := range formulaRefs(sheets, sheetIdx, *c.RawValue) {
+				graph.AddEdge(node, dep)
+			}
+		}
+	}
+
+	return graph, formulaCells
+}
+
+// transitiveDependents returns every formula node reachable by walking
+// graph's edges backwards from changed (i.e. every node whose formula
+// depends, directly or indirectly, on changed).
+func transitiveDependents(graph *evaluatefuncs.DependencyGraph, formulaCells map[string]*cell.Cell, changed string) map[string]bool {
+	reverse := make(map[string][]string)
+	for node := range formulaCells {
+		for _, dep := range graph.Dependencies(node) {
+			reverse[dep] = append(reverse[dep], node)
+		}
+	}
+
+	affected := make(map[string]bool)
+	queue := []string{changed}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, dependent := range reverse[n] {
+			if affected[dependent] {
+				continue
+			}
+			affected[dependent] = true
+			queue = append(queue, dependent)
+		}
+	}
+	return affected
+}
+
+// markCycle sets every formula cell named in cycle's CycleError to "#CIRC!"
+// and every formula cell that transitively depends on one of them to "#REF!".
+func markCycle(graph *evaluatefuncs.DependencyGraph, err error, formulaCells map[string]*cell.Cell) {
+	cycleErr, ok := err.(*evaluatefuncs.CycleError)
+	if !ok {
+		return
+	}
+	inCycle := make(map[string]bool, len(cycleErr.Cells))
+	for _, node := range cycleErr.Cells {
+		inCycle[node] = true
+		if c, ok := formulaCells[node]; ok {
+			setDisplay(c, "#CIRC!")
+		}
+	}
+	for _, node := range cycleErr.Cells {
+		for dependent := range transitiveDependents(graph, formulaCells, node) {
+			if c, ok := formulaCells[dependent]; ok && !inCycle[dependent] {
+				setDisplay(c, "#REF!")
+			}
+		}
+	}
+}
+
+// evaluateInOrder evaluates every node in order that include accepts,
+// skipping (and marking "#REF!") any whose dependency already errored, so a
+// single broken upstream cell doesn't cascade into unrelated #ERROR!s.
+func evaluateInOrder(sheets []SheetInfo, graph *evaluatefuncs.DependencyGraph, formulaCells map[string]*cell.Cell, order []string, include func(node string) bool) {
+	resolver := newSheetsResolver(sheets)
+	evaluator := evaluatefuncs.NewEvaluator(resolver)
+	errored := make(map[string]bool)
+
+	for _, node := range order {
+		c, ok := formulaCells[node]
+		if !ok || !include(node) {
+			continue
+		}
+
+		if dependsOnError(graph, node, errored) {
+			setDisplay(c, "#REF!")
+			errored[node] = true
+			continue
+		}
+
+		expr := strings.TrimPrefix(*c.RawValue, "=")
+		result, err := evaluator.Evaluate(expr)
+		if err != nil {
+			setDisplay(c, "#ERROR!")
+			errored[node] = true
+			continue
+		}
+		if fe, isErr := utils.IsFormulaError(result); isErr {
+			setDisplay(c, string(fe))
+			errored[node] = true
+			continue
+		}
+		setDisplay(c, fmt.Sprintf("%v", result))
+	}
+}
+
+// dependsOnError reports whether any of node's direct dependencies already
+// evaluated to an error this pass.
+func dependsOnError(graph *evaluatefuncs.DependencyGraph, node string, errored map[string]bool) bool {
+	for _, dep := range graph.Dependencies(node) {
+		if errored[dep] {
+			return true
+		}
+	}
+	return false
+}
+
+func setDisplay(c *cell.Cell, text string) {
+	c.Display = &text
+}
+
+// recalcRefPattern matches a cell or range reference with an optional sheet
+// qualifier: B12, A1:B3, Sheet2!A1, or 'Sales 2024'!B2:B10 - the same shape
+// evaluatefuncs.ExpandCrossSheetRefs recognizes, plus the bare same-sheet
+// case it leaves for ExpandRangeRefs/govaluate's variable lookup.
+var recalcRefPattern = regexp.MustCompile(`(?:'([^']+)'!|([A-Za-z_][A-Za-z0-9_]*)!)?(\$?[A-Z]{1,3}\$?\d+)(?::(\$?[A-Z]{1,3}\$?\d+))?`)
+
+// formulaRefs tokenizes raw (a formula cell's RawValue, "=" optional) into
+// the DependencyGraph node IDs it reads, expanding a range reference into
+// every cell it covers and resolving a sheet qualifier to its index by
+// name, defaulting to sheetIdx (the formula's own sheet) when there isn't
+// one.
+func formulaRefs(sheets []SheetInfo, sheetIdx int, raw string) []string {
+	expr := strings.TrimPrefix(raw, "=")
+	var nodes []string
+
+	for _, m := range recalcRefPattern.FindAllStringSubmatch(expr, -1) {
+		sheetName := m[1]
+		if sheetName == "" {
+			sheetName = m[2]
+		}
+
+		targetIdx := sheetIdx
+		if sheetName != "" {
+			found := -1
+			for i, s := range sheets {
+				if s.Name == sheetName {
+					found = i
+					break
+				}
+			}
+			if found == -1 {
+				continue
+			}
+			targetIdx = found
+		}
+
+		from := strings.ReplaceAll(m[3], "$", "")
+		to := strings.ReplaceAll(m[4], "$", "")
+
+		r1, c1, ok := parseCellAddr(from)
+		if !ok {
+			continue
+		}
+		if to == "" {
+			nodes = append(nodes, recalcNode(targetIdx, r1, c1))
+			continue
+		}
+		r2, c2, ok := parseCellAddr(to)
+		if !ok {
+			continue
+		}
+		for row := r1; row <= r2; row++ {
+			for col := c1; col <= c2; col++ {
+				nodes = append(nodes, recalcNode(targetIdx, row, col))
+			}
+		}
+	}
+
+	return nodes
+}
+
+var cellAddrPattern = regexp.MustCompile(`^([A-Z]+)(\d+)$`)
+
+// parseCellAddr parses a bare "B12" reference into its 1-indexed row/col,
+// the same column-letter algorithm table/formularefs.go's parseA1Coords and
+// evaluatefuncs/evaluator.go's parseCellRef each already implement for their
+// own package.
+func parseCellAddr(ref string) (row, col int32, ok bool) {
+	m := cellAddrPattern.FindStringSubmatch(strings.ToUpper(ref))
+	if m == nil {
+		return 0, 0, false
+	}
+
+	col = 0
+	for _, ch := range m[1] {
+		col = col*26 + int32(ch-'A') + 1
+	}
+
+	row = 0
+	for _, ch := range m[2] {
+		row = row*10 + int32(ch-'0')
+	}
+
+	return row, col, true
+}
+
+// sheetsResolver implements evaluatefuncs.WorkbookResolver against a plain
+// []SheetInfo. Named ranges aren't available at this layer (SheetInfo
+// doesn't carry WorkbookData.Names), so ResolveName always errors - a
+// formula using a named range recalculates to a formula error value
+// (Evaluator.Evaluate's #VALUE!/#NAME?), not its last-saved Display.
+type sheetsResolver struct {
+	sheets []SheetInfo
+	byName map[string]int
+}
+
+func newSheetsResolver(sheets []SheetInfo) *sheetsResolver {
+	byName := make(map[string]int, len(sheets))
+	for i, s := range sheets {
+		byName[s.Name] = i
+	}
+	return &sheetsResolver{sheets: sheets, byName: byName}
+}
+
+func (r *sheetsResolver) ResolveCell(sheet string, row, col int) (any, error) {
+	idx, ok := r.byName[sheet]
+	if !ok {
+		return nil, fmt.Errorf("unknown sheet %q", sheet)
+	}
+	c, ok := r.sheets[idx].GlobalData[[2]int{row, col}]
+	if !ok {
+		return "", nil
+	}
+	return cellValue(c), nil
+}
+
+func (r *sheetsResolver) ResolveRange(sheet string, r1, c1, r2, c2 int) (utils.CellRange, error) {
+	idx, ok := r.byName[sheet]
+	if !ok {
+		return utils.CellRange{}, fmt.Errorf("unknown sheet %q", sheet)
+	}
+
+	data := r.sheets[idx].GlobalData
+	rows, cols := r2-r1+1, c2-c1+1
+	values := make([]any, 0, rows*cols)
+	for row := r1; row <= r2; row++ {
+		for col := c1; col <= c2; col++ {
+			if c, ok := data[[2]int{row, col}]; ok {
+				values = append(values, cellValue(c))
+			} else {
+				values = append(values, "")
+			}
+		}
+	}
+	return utils.CellRange{Values: values, Rows: rows, Cols: cols}, nil
+}
+
+func (r *sheetsResolver) ResolveName(name string) (any, error) {
+	return nil, fmt.Errorf("named ranges are not available during recalculation")
+}
+
+// cellValue returns c's display value, favoring the cached Display (a
+// formula cell's already-evaluated result) and falling back to RawValue for
+// a plain literal cell.
+func cellValue(c *cell.Cell) any {
+	if c == nil {
+		return ""
+	}
+	if c.Display != nil {
+		return *c.Display
+	}
+	if c.RawValue != nil {
+		return *c.RawValue
+	}
+	return ""
+}
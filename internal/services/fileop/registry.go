@@ -0,0 +1,376 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// registry.go defines the FileFormat/FormatHandler abstraction that unifies
+// every import/export path (.gsheet, .json, .csv, .tsv, .txt, .html, .pdf,
+// .xlsx, .ods) behind one Registry, so a new format registers a handler
+// instead of growing OpenWorkbook/SaveWorkbook's suffix-dispatch chains. The
+// existing SaveWorkbook/SaveWorkbookAsJSON/SaveTableAsCSV/etc. functions in
+// save.go stay in place as thin wrappers - this file just gives them (and
+// PDFFormatHandler/XLSXFormatHandler/ODSFormatHandler, already defined
+// alongside their codecs) one shared entry point for callers like ui/file that only know a
+// FileFormat, not which function to call.
+package fileop
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+)
+
+// FileFormat identifies one of the spreadsheet formats GoSheet can read
+// and/or write.
+type FileFormat int
+
+const (
+	FormatGSheet FileFormat = iota
+	FormatJSON
+	FormatCSV
+	FormatTSV
+	FormatTXT
+	FormatHTML
+	FormatPDF
+	FormatXLSX
+	FormatODS
+)
+
+// String returns the format's file extension, including the leading dot.
+func (f FileFormat) String() string {
+	switch f {
+	case FormatGSheet:
+		return ".gsheet"
+	case FormatJSON:
+		return ".json"
+	case FormatCSV:
+		return ".csv"
+	case FormatTSV:
+		return ".tsv"
+	case FormatTXT:
+		return ".txt"
+	case FormatHTML:
+		return ".html"
+	case FormatPDF:
+		return ".pdf"
+	case FormatXLSX:
+		return ".xlsx"
+	case FormatODS:
+		return ".ods"
+	default:
+		return ""
+	}
+}
+
+// Description returns a short human-readable label for the format, for the
+// UI's save-as dropdown.
+func (f FileFormat) Description() string {
+	switch f {
+	case FormatGSheet:
+		return "GoSheet Workbook (.gsheet)"
+	case FormatJSON:
+		return "JSON Workbook (.json)"
+	case FormatCSV:
+		return "CSV (.csv)"
+	case FormatTSV:
+		return "TSV (.tsv)"
+	case FormatTXT:
+		return "Tab-delimited Text (.txt)"
+	case FormatHTML:
+		return "HTML Page (.html)"
+	case FormatPDF:
+		return "PDF Document (.pdf)"
+	case FormatXLSX:
+		return "Excel Workbook (.xlsx)"
+	case FormatODS:
+		return "OpenDocument Spreadsheet (.ods)"
+	default:
+		return "Unknown"
+	}
+}
+
+// FormatHandler writes one FileFormat. Every registered handler can Write;
+// a handler for a format GoSheet can also load implements
+// ReadableFormatHandler instead, following the standard optional-interface
+// pattern instead of giving every handler a Read that errors for PDF/CSV/
+// TSV/HTML, which GoSheet only ever exports.
+type FormatHandler interface {
+	SupportsFormat(format FileFormat) bool
+	Extensions() []string
+	Write(filename string, sheets []SheetInfo, activeSheet int) error
+}
+
+// ReadableFormatHandler is a FormatHandler that can also load a workbook
+// back from disk.
+type ReadableFormatHandler interface {
+	FormatHandler
+	Read(filename string) (sheets []SheetInfo, activeSheet int, err error)
+}
+
+// Registry resolves a FileFormat or file extension to the FormatHandler
+// that implements it.
+type Registry struct {
+	handlers []FormatHandler
+}
+
+// NewRegistry builds a Registry with every format GoSheet currently knows
+// how to write, in extension order.
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: []FormatHandler{
+			&gsheetFormatHandler{},
+			&jsonFormatHandler{},
+			&csvFormatHandler{},
+			&tsvFormatHandler{},
+			&txtFormatHandler{},
+			&htmlFormatHandler{},
+			&PDFFormatHandler{},
+			&XLSXFormatHandler{},
+			&ODSFormatHandler{},
+		},
+	}
+}
+
+// defaultRegistry is the Registry every package-level helper below goes
+// through - process-wide state, like defaultSession in the table package or
+// userFunctions in internal/utils.
+var defaultRegistry = NewRegistry()
+
+// HandlerFor returns the registered handler for format, or nil if none is
+// registered.
+func (r *Registry) HandlerFor(format FileFormat) FormatHandler {
+	for _, h := range r.handlers {
+		if h.SupportsFormat(format) {
+			return h
+		}
+	}
+	return nil
+}
+
+// HandlerForExtension resolves a handler from filename's extension (e.g.
+// "report.xlsx" -> the XLSX handler), case-insensitively.
+func (r *Registry) HandlerForExtension(filename string) FormatHandler {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, h := range r.handlers {
+		for _, e := range h.Extensions() {
+			if e == ext {
+				return h
+			}
+		}
+	}
+	return nil
+}
+
+// WorkbookProvider supplies the currently active workbook's sheets for
+// GetWorkbookForSave, so the UI's save-as dialog can ask fileop for
+// "whatever is open right now" without fileop importing the table package
+// back (table already imports fileop for OpenWorkbook/SaveWorkbook). The
+// table package sets this in an init function - the same process-wide
+// registration idiom internal/utils/udffuncs.go uses for userFunctions.
+var WorkbookProvider func() (sheets []SheetInfo, activeSheet int, ok bool)
+
+// GetWorkbookForSave returns the active workbook's sheets via
+// WorkbookProvider, or ok=false if no provider is registered or no
+// workbook is currently open.
+func GetWorkbookForSave() (sheets []SheetInfo, activeSheet int, ok bool) {
+	if WorkbookProvider == nil {
+		return nil, 0, false
+	}
+	return WorkbookProvider()
+}
+
+// GetWritableFormats returns every FileFormat the default registry can
+// write, in registration order, for populating the UI's save-as dropdown.
+func GetWritableFormats() []FileFormat {
+	formats := make([]FileFormat, 0, len(defaultRegistry.handlers))
+	for f := FormatGSheet; f <= FormatODS; f++ {
+		if defaultRegistry.HandlerFor(f) != nil {
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+// SaveWorkbookAs writes sheets to filename through the handler registered
+// for format, appending that format's extension if filename doesn't already
+// have it.
+func SaveWorkbookAs(sheets []SheetInfo, activeSheet int, filename string, format FileFormat) error {
+	handler := defaultRegistry.HandlerFor(format)
+	if handler == nil {
+		return fmt.Errorf("unsupported format: %v", format)
+	}
+
+	if !strings.HasSuffix(strings.ToLower(filename), format.String()) {
+		if idx := strings.LastIndex(filename, "."); idx != -1 {
+			filename = filename[:idx]
+		}
+		filename += format.String()
+	}
+
+	return handler.Write(filename, sheets, activeSheet)
+}
+
+// LoadWorkbook loads filename through the ReadableFormatHandler registered
+// for its extension, falling back to OpenWorkbook's own suffix dispatch for
+// legacy callers and for any format that hasn't grown a handler yet.
+// Every formula cell's Display is re-derived from its RawValue in
+// dependency order before returning (Recalculate documents what a formula
+// cycle limits this to), so a workbook edited outside the app
+// (or last saved by an older build, before a formula's inputs changed)
+// loads with up-to-date values rather than stale cached ones.
+func LoadWorkbook(filename string) (sheets []SheetInfo, activeSheet int, err error) {
+	if handler := defaultRegistry.HandlerForExtension(filename); handler != nil {
+		if readable, ok := handler.(ReadableFormatHandler); ok {
+			sheets, activeSheet, err = readable.Read(filename)
+			if err != nil {
+				return nil, 0, err
+			}
+			Recalculate(sheets)
+			return sheets, activeSheet, nil
+		}
+	}
+
+	result, err := OpenWorkbook(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	sheets = sheetInfosFromResult(result)
+	Recalculate(sheets)
+	return sheets, result.ActiveSheet, nil
+}
+
+// sheetInfosFromResult converts the []SheetResult shape OpenWorkbook/
+// OpenXLSXWorkbook return into the []SheetInfo shape SaveWorkbookAs and
+// ReadableFormatHandler.Read share, keying each cell the same [row, col]
+// way RenderVisible/table.go do.
+func sheetInfosFromResult(result *WorkbookResult) []SheetInfo {
+	infos := make([]SheetInfo, 0, len(result.Sheets))
+	for _, sr := range result.Sheets {
+		data := make(map[[2]int]*cell.Cell, len(sr.Cells))
+		for _, c := range sr.Cells {
+			data[[2]int{int(c.Row), int(c.Column)}] = c
+		}
+		infos = append(infos, SheetInfo{
+			Name:       sr.Name,
+			Rows:       sr.Rows,
+			Cols:       sr.Cols,
+			Color:      sr.Color,
+			Group:      sr.Group,
+			GlobalData: data,
+			Merges:     sr.Merges,
+			Charts:     sr.Charts,
+		})
+	}
+	return infos
+}
+
+// activeSheetData returns sheets[activeSheet]'s GlobalData, for formats
+// (CSV/TSV/TXT/HTML) that have no sheet concept of their own and only ever
+// export one flat sheet.
+func activeSheetData(sheets []SheetInfo, activeSheet int) (map[[2]int]*cell.Cell, error) {
+	if activeSheet < 0 || activeSheet >= len(sheets) {
+		return nil, fmt.Errorf("no active sheet to export")
+	}
+	return sheets[activeSheet].GlobalData, nil
+}
+
+// gsheetFormatHandler adapts SaveWorkbookWithNames/OpenWorkbook to
+// FormatHandler for the native .gsheet format.
+type gsheetFormatHandler struct{}
+
+func (h *gsheetFormatHandler) SupportsFormat(format FileFormat) bool { return format == FormatGSheet }
+func (h *gsheetFormatHandler) Extensions() []string                  { return []string{".gsheet"} }
+
+func (h *gsheetFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	functions := savedFunctionsFromLambdas(utils.SnapshotUserLambdas())
+	return SaveWorkbookWithNames(sheets, activeSheet, filename, nil, functions)
+}
+
+func (h *gsheetFormatHandler) Read(filename string) ([]SheetInfo, int, error) {
+	result, err := OpenWorkbook(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	utils.RestoreUserLambdas(lambdaDefsFromSaved(result.Functions))
+	return sheetInfosFromResult(result), result.ActiveSheet, nil
+}
+
+// jsonFormatHandler is gsheetFormatHandler's .json counterpart.
+type jsonFormatHandler struct{}
+
+func (h *jsonFormatHandler) SupportsFormat(format FileFormat) bool { return format == FormatJSON }
+func (h *jsonFormatHandler) Extensions() []string                  { return []string{".json"} }
+
+func (h *jsonFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	return SaveWorkbookAsJSON(sheets, activeSheet, filename)
+}
+
+func (h *jsonFormatHandler) Read(filename string) ([]SheetInfo, int, error) {
+	result, err := OpenWorkbook(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sheetInfosFromResult(result), result.ActiveSheet, nil
+}
+
+// csvFormatHandler adapts SaveTableAsCSV to FormatHandler. CSV is write-only
+// in GoSheet (there's no CSV importer), so it doesn't implement
+// ReadableFormatHandler.
+type csvFormatHandler struct{}
+
+func (h *csvFormatHandler) SupportsFormat(format FileFormat) bool { return format == FormatCSV }
+func (h *csvFormatHandler) Extensions() []string                  { return []string{".csv"} }
+
+func (h *csvFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	data, err := activeSheetData(sheets, activeSheet)
+	if err != nil {
+		return err
+	}
+	return SaveTableAsCSV(nil, filename, data)
+}
+
+// tsvFormatHandler writes the same tab-delimited layout as txtFormatHandler
+// under a .tsv extension, sharing writeTabDelimited with SaveTableAsTXT.
+type tsvFormatHandler struct{}
+
+func (h *tsvFormatHandler) SupportsFormat(format FileFormat) bool { return format == FormatTSV }
+func (h *tsvFormatHandler) Extensions() []string                  { return []string{".tsv"} }
+
+func (h *tsvFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	data, err := activeSheetData(sheets, activeSheet)
+	if err != nil {
+		return err
+	}
+	return writeTabDelimited(filename, data)
+}
+
+// txtFormatHandler adapts SaveTableAsTXT to FormatHandler.
+type txtFormatHandler struct{}
+
+func (h *txtFormatHandler) SupportsFormat(format FileFormat) bool { return format == FormatTXT }
+func (h *txtFormatHandler) Extensions() []string                  { return []string{".txt"} }
+
+func (h *txtFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	data, err := activeSheetData(sheets, activeSheet)
+	if err != nil {
+		return err
+	}
+	return SaveTableAsTXT(nil, filename, data)
+}
+
+// htmlFormatHandler adapts SaveTableAsHTML to FormatHandler.
+type htmlFormatHandler struct{}
+
+func (h *htmlFormatHandler) SupportsFormat(format FileFormat) bool { return format == FormatHTML }
+func (h *htmlFormatHandler) Extensions() []string                  { return []string{".html", ".htm"} }
+
+func (h *htmlFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	data, err := activeSheetData(sheets, activeSheet)
+	if err != nil {
+		return err
+	}
+	return SaveTableAsHTML(nil, filename, data, sheets[activeSheet].Charts)
+}
@@ -8,12 +8,13 @@
 package fileop
 
 import (
+	"compress/gzip"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"strings"
-	"compress/gzip"
 
 	"gosheet/internal/services/cell"
 	"gosheet/internal/utils"
@@ -21,8 +22,16 @@ import (
 	"github.com/rivo/tview"
 )
 
-// SaveWorkbook saves multiple sheets in native .gsheet format
+// SaveWorkbook saves multiple sheets in native .gsheet format, carrying
+// along whatever LAMBDA functions are currently registered (see
+// utils.SnapshotUserLambdas) so they survive the round trip.
 func SaveWorkbook(sheets []SheetInfo, activeSheet int, filename string) error {
+	return SaveWorkbookWithNames(sheets, activeSheet, filename, nil, savedFunctionsFromLambdas(utils.SnapshotUserLambdas()))
+}
+
+// SaveWorkbookWithNames is SaveWorkbook plus the workbook's named ranges and
+// its user-defined LAMBDA functions (see udf.Registry/RegisterLambda).
+func SaveWorkbookWithNames(sheets []SheetInfo, activeSheet int, filename string, names []NamedRange, functions []SavedFunction) error {
 	if !strings.HasSuffix(filename, ".gsheet") {
 		if idx := strings.Index(filename, "."); idx != -1 {
 			filename = filename[:idx]
@@ -34,14 +43,20 @@ func SaveWorkbook(sheets []SheetInfo, activeSheet int, filename string) error {
 		Version:     utils.FILEVER,
 		ActiveSheet: activeSheet,
 		Sheets:      make([]SheetData, 0, len(sheets)),
+		Names:       names,
+		Functions:   functions,
 	}
 
 	for _, sheet := range sheets {
 		sheetData := SheetData{
-			Name:  sheet.Name,
-			Rows:  sheet.Rows,
-			Cols:  sheet.Cols,
-			Cells: make(map[string]*CellData),
+			Name:   sheet.Name,
+			Rows:   sheet.Rows,
+			Cols:   sheet.Cols,
+			Color:  sheet.Color,
+			Group:  sheet.Group,
+			Cells:  make(map[string]*CellData),
+			Merges: sheet.Merges,
+			Charts: sheet.Charts,
 		}
 
 		for _, c := range sheet.GlobalData {
@@ -92,10 +107,14 @@ func SaveWorkbookAsJSON(sheets []SheetInfo, activeSheet int, filename string) er
 
 	for _, sheet := range sheets {
 		sheetData := SheetData{
-			Name:  sheet.Name,
-			Rows:  sheet.Rows,
-			Cols:  sheet.Cols,
-			Cells: make(map[string]*CellData),
+			Name:   sheet.Name,
+			Rows:   sheet.Rows,
+			Cols:   sheet.Cols,
+			Color:  sheet.Color,
+			Group:  sheet.Group,
+			Cells:  make(map[string]*CellData),
+			Merges: sheet.Merges,
+			Charts: sheet.Charts,
 		}
 
 		for _, c := range sheet.GlobalData {
@@ -248,8 +267,9 @@ func htmlEscape(s string) string {
 	return s
 }
 
-// SaveTableAsHTML exports table as a HTML webpage
-func SaveTableAsHTML(table *tview.Table, filename string, globalData map[[2]int]*cell.Cell) error {
+// SaveTableAsHTML exports table as a HTML webpage, followed by inline SVG
+// renderings of charts (see charts.go's ChartSpec/ResolveChartSeries).
+func SaveTableAsHTML(table *tview.Table, filename string, globalData map[[2]int]*cell.Cell, charts []ChartSpec) error {
 	var maxRow, maxCol int32
 	for key := range globalData {
 		r, c := int32(key[0]), int32(key[1])
@@ -340,12 +360,206 @@ func SaveTableAsHTML(table *tview.Table, filename string, globalData map[[2]int]
 		html.WriteString("</tr>\n")
 	}
 
-	html.WriteString("</tbody>\n</table>\n</body>\n</html>")
+	html.WriteString("</tbody>\n</table>\n")
+	html.WriteString(buildChartsHTML(charts, globalData))
+	html.WriteString("</body>\n</html>")
 
 	_, err = file.WriteString(html.String())
 	return err
 }
 
+// Chart SVG canvas dimensions, shared by all chart types.
+const (
+	chartWidth  = 600
+	chartHeight = 360
+	chartPad    = 40
+)
+
+// chartColors is a small fixed palette, cycled through for multi-slice pies
+// and reused as the single series color for line/bar/scatter charts.
+var chartColors = []string{
+	"#4CAF50", "#2196F3", "#FF9800", "#9C27B0", "#F44336", "#00BCD4", "#FFC107",
+}
+
+// buildChartsHTML resolves each of charts against globalData and renders it
+// as a <figure> containing an inline SVG, skipping any chart whose range
+// fails to resolve rather than failing the whole export.
+func buildChartsHTML(charts []ChartSpec, globalData map[[2]int]*cell.Cell) string {
+	if len(charts) == 0 {
+		return ""
+	}
+
+	sheet := SheetInfo{GlobalData: globalData}
+
+	var b strings.Builder
+	for _, spec := range charts {
+		series, err := ResolveChartSeries(sheet, spec)
+		if err != nil {
+			continue
+		}
+
+		b.WriteString("<figure style=\"margin: 20px 0;\">\n")
+		b.WriteString(renderChartSVG(series))
+		if spec.Title != "" {
+			b.WriteString(fmt.Sprintf("<figcaption>%s</figcaption>\n", htmlEscape(spec.Title)))
+		}
+		b.WriteString("</figure>\n")
+	}
+	return b.String()
+}
+
+// renderChartSVG dispatches series to the SVG builder matching its Spec.Type,
+// defaulting to a line chart for an unrecognized/empty type.
+func renderChartSVG(series ChartSeries) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("<svg width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" xmlns=\"http://www.w3.org/2000/svg\">\n",
+		chartWidth, chartHeight, chartWidth, chartHeight))
+
+	switch series.Spec.Type {
+	case ChartPie:
+		renderPieSVG(&b, series)
+	default:
+		renderAxesSVG(&b, series)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// renderAxesSVG draws line/bar/scatter charts against a shared x/y axis:
+// a category on the x-axis, value scaled to [chartPad, chartHeight-chartPad]
+// on the y-axis.
+func renderAxesSVG(b *strings.Builder, series ChartSeries) {
+	n := len(series.Values)
+	if n == 0 {
+		return
+	}
+
+	maxVal := series.Values[0]
+	minVal := series.Values[0]
+	for _, v := range series.Values {
+		if v > maxVal {
+			maxVal = v
+		}
+		if v < minVal {
+			minVal = v
+		}
+	}
+	if minVal > 0 {
+		minVal = 0
+	}
+	valRange := maxVal - minVal
+	if valRange == 0 {
+		valRange = 1
+	}
+
+	plotW := float64(chartWidth - 2*chartPad)
+	plotH := float64(chartHeight - 2*chartPad)
+
+	x := func(i int) float64 {
+		if n == 1 {
+			return float64(chartPad) + plotW/2
+		}
+		return float64(chartPad) + plotW*float64(i)/float64(n-1)
+	}
+	y := func(v float64) float64 {
+		return float64(chartPad) + plotH*(1-(v-minVal)/valRange)
+	}
+
+	color := chartColors[0]
+
+	// axes
+	fmt.Fprintf(b, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#333\" stroke-width=\"1\"/>\n",
+		chartPad, chartHeight-chartPad, chartWidth-chartPad, chartHeight-chartPad)
+	fmt.Fprintf(b, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#333\" stroke-width=\"1\"/>\n",
+		chartPad, chartPad, chartPad, chartHeight-chartPad)
+
+	switch series.Spec.Type {
+	case ChartBar:
+		barW := plotW / float64(n) * 0.6
+		for i, v := range series.Values {
+			cx := x(i)
+			cy := y(v)
+			fmt.Fprintf(b, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\"/>\n",
+				cx-barW/2, cy, barW, y(minVal)-cy, color)
+		}
+	case ChartScatter:
+		for i, v := range series.Values {
+			fmt.Fprintf(b, "<circle cx=\"%.1f\" cy=\"%.1f\" r=\"3\" fill=\"%s\"/>\n", x(i), y(v), color)
+		}
+	default: // ChartLine
+		var points []string
+		for i, v := range series.Values {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(i), y(v)))
+		}
+		fmt.Fprintf(b, "<polyline points=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"2\"/>\n",
+			strings.Join(points, " "), color)
+		for i, v := range series.Values {
+			fmt.Fprintf(b, "<circle cx=\"%.1f\" cy=\"%.1f\" r=\"2.5\" fill=\"%s\"/>\n", x(i), y(v), color)
+		}
+	}
+
+	for i, label := range series.Categories {
+		fmt.Fprintf(b, "<text x=\"%.1f\" y=\"%d\" font-size=\"10\" text-anchor=\"middle\">%s</text>\n",
+			x(i), chartHeight-chartPad+14, htmlEscape(label))
+	}
+
+	if series.Spec.XAxisTitle != "" {
+		fmt.Fprintf(b, "<text x=\"%d\" y=\"%d\" font-size=\"12\" text-anchor=\"middle\">%s</text>\n",
+			chartWidth/2, chartHeight-8, htmlEscape(series.Spec.XAxisTitle))
+	}
+	if series.Spec.YAxisTitle != "" {
+		fmt.Fprintf(b, "<text x=\"12\" y=\"%d\" font-size=\"12\" text-anchor=\"middle\" transform=\"rotate(-90 12 %d)\">%s</text>\n",
+			chartHeight/2, chartHeight/2, htmlEscape(series.Spec.YAxisTitle))
+	}
+}
+
+// renderPieSVG draws series as pie wedges around the canvas center, each
+// wedge colored from chartColors (cycled if there are more slices than
+// colors) and labeled with its category name when the legend isn't hidden.
+func renderPieSVG(b *strings.Builder, series ChartSeries) {
+	total := 0.0
+	for _, v := range series.Values {
+		total += v
+	}
+	if total == 0 {
+		return
+	}
+
+	cx, cy := float64(chartWidth)/2, float64(chartHeight)/2
+	r := math.Min(cx, cy) - chartPad
+
+	angle := -math.Pi / 2
+	for i, v := range series.Values {
+		frac := v / total
+		sweep := frac * 2 * math.Pi
+		x1 := cx + r*math.Cos(angle)
+		y1 := cy + r*math.Sin(angle)
+		angle += sweep
+		x2 := cx + r*math.Cos(angle)
+		y2 := cy + r*math.Sin(angle)
+
+		large := 0
+		if sweep > math.Pi {
+			large = 1
+		}
+
+		color := chartColors[i%len(chartColors)]
+		fmt.Fprintf(b, "<path d=\"M%.1f,%.1f L%.1f,%.1f A%.1f,%.1f 0 %d 1 %.1f,%.1f Z\" fill=\"%s\" stroke=\"white\" stroke-width=\"1\"/>\n",
+			cx, cy, x1, y1, r, r, large, x2, y2, color)
+	}
+
+	if series.Spec.LegendPosition != LegendNone {
+		for i, label := range series.Categories {
+			ly := chartPad + i*14
+			fmt.Fprintf(b, "<rect x=\"%d\" y=\"%d\" width=\"10\" height=\"10\" fill=\"%s\"/>\n",
+				chartWidth-chartPad-80, ly, chartColors[i%len(chartColors)])
+			fmt.Fprintf(b, "<text x=\"%d\" y=\"%d\" font-size=\"10\">%s</text>\n",
+				chartWidth-chartPad-65, ly+9, htmlEscape(label))
+		}
+	}
+}
+
 // SaveTableAsTXT exports table as tab-delimited text file
 func SaveTableAsTXT(table *tview.Table, filename string, globalData map[[2]int]*cell.Cell) error {
 	if !strings.HasSuffix(filename, ".txt") {
@@ -354,7 +568,14 @@ func SaveTableAsTXT(table *tview.Table, filename string, globalData map[[2]int]*
 		}
 		filename += ".txt"
 	}
+	return writeTabDelimited(filename, globalData)
+}
 
+// writeTabDelimited writes globalData as tab-delimited rows to filename
+// as-is, without forcing an extension - shared by SaveTableAsTXT and
+// registry.go's tsvFormatHandler, which differ only in what suffix they
+// append before calling this.
+func writeTabDelimited(filename string, globalData map[[2]int]*cell.Cell) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -393,14 +614,34 @@ func SaveTableAsTXT(table *tview.Table, filename string, globalData map[[2]int]*
 	return nil
 }
 
-// SaveTableAsExcel will save the table in the excel format. Currently not implemented.
-func SaveTableAsExcel(table *tview.Table, filename string) error {
-	// github.com/xuri/excelize/v2
-	return fmt.Errorf("Excel export not yet implemented. Use CSV or HTML format instead.")
+// SaveTableAsExcel exports the table as .xlsx (legacy single-sheet wrapper),
+// delegating to SaveWorkbookAsXLSX - see xlsx.go - which already covers
+// this function's bold/italic/underline/strikethrough/alignment/colors,
+// number-and-currency/date format codes, formulas, column widths, and
+// active-sheet requirements.
+func SaveTableAsExcel(table *tview.Table, filename string, globalData map[[2]int]*cell.Cell) error {
+	sheets := []SheetInfo{
+		{
+			Name:       "Sheet1",
+			Rows:       int32(table.GetRowCount()),
+			Cols:       int32(table.GetColumnCount()),
+			GlobalData: globalData,
+		},
+	}
+	return SaveWorkbookAsXLSX(sheets, 0, filename)
 }
 
-// SaveTableAsPDF will save the table as a PDF
-func SaveTableAsPDF(table *tview.Table, filename string) error {
-	// gopdf
-	return fmt.Errorf("PDF export not yet implemented. Use CSV or HTML format instead.")
+// SaveTableAsPDF exports the table as .pdf (legacy single-sheet wrapper),
+// delegating to PDFFormatHandler the same way SaveTableAsExcel delegates to
+// SaveWorkbookAsXLSX.
+func SaveTableAsPDF(table *tview.Table, filename string, globalData map[[2]int]*cell.Cell) error {
+	sheets := []SheetInfo{
+		{
+			Name:       "Sheet1",
+			Rows:       int32(table.GetRowCount()),
+			Cols:       int32(table.GetColumnCount()),
+			GlobalData: globalData,
+		},
+	}
+	return (&PDFFormatHandler{}).Write(filename, sheets, 0)
 }
@@ -0,0 +1,647 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// streaming.go adds a row-at-a-time write path alongside save.go/xlsx.go's
+// build-the-whole-thing-then-write-it-once approach. SaveWorkbook/
+// SaveWorkbookAsXLSX and the CSV/TXT/HTML writers all either marshal the
+// full WorkbookData into one byte slice before gzipping it, or pre-scan
+// globalData for maxRow/maxCol and then iterate the full dense rectangle -
+// fine for a spreadsheet-sized sheet, not for one with millions of cells.
+// StreamWriter lets a caller emit a sheet row by row instead; StreamWorkbook
+// drives one from a []SheetInfo already in memory, visiting only rows that
+// actually have a cell rather than the full maxRow x maxCol rectangle.
+package fileop
+
+import (
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+)
+
+// StreamWriter writes one sheet at a time, row by row, to a file format.
+// BeginSheet/EndSheet bracket each sheet; formats with no multi-sheet
+// concept of their own (CSV, TXT) error out of a second BeginSheet rather
+// than silently overwriting the first, the same restriction
+// activeSheetData's doc comment already documents for their non-streaming
+// writers.
+type StreamWriter interface {
+	BeginSheet(name string, rows, cols int32) error
+	WriteRow(rowIdx int32, cells map[int32]*cell.Cell) error
+	EndSheet() error
+	Close() error
+}
+
+// StreamWorkbook drives w from sheets already held in memory, one sheet and
+// one populated row at a time, so every StreamWriter visits only rows that
+// actually have a cell instead of each walking the full 1..Rows x 1..Cols
+// rectangle itself.
+func StreamWorkbook(w StreamWriter, sheets []SheetInfo) error {
+	for _, sheet := range sheets {
+		if err := w.BeginSheet(sheet.Name, sheet.Rows, sheet.Cols); err != nil {
+			return err
+		}
+
+		byRow := make(map[int32]map[int32]*cell.Cell)
+		var maxRow int32
+		for key, c := range sheet.GlobalData {
+			row := int32(key[0])
+			if byRow[row] == nil {
+				byRow[row] = make(map[int32]*cell.Cell)
+			}
+			byRow[row][int32(key[1])] = c
+			if row > maxRow {
+				maxRow = row
+			}
+		}
+
+		for row := int32(1); row <= maxRow; row++ {
+			cells, ok := byRow[row]
+			if !ok {
+				continue
+			}
+			if err := w.WriteRow(row, cells); err != nil {
+				return err
+			}
+		}
+
+		if err := w.EndSheet(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- gsheet (gzipped NDJSON) -------------------------------------------------
+
+// gsheetNDJSONMagic is the literal first line of a streamed .gsheet file,
+// letting OpenWorkbook tell a streamed file apart from the ordinary
+// gzip+json.MarshalIndent format without trying to decode it first.
+const gsheetNDJSONMagic = "GSHEET-NDJSON-V1"
+
+// ndjsonRecord is the one envelope shape every line after the magic line
+// decodes to; Type says which of ndjsonHeader/ndjsonSheetHeader/
+// ndjsonCellRecord Data holds.
+type ndjsonRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type ndjsonHeader struct {
+	Version     string       `json:"version"`
+	ActiveSheet int          `json:"active_sheet"`
+	Names       []NamedRange `json:"names,omitempty"`
+}
+
+type ndjsonSheetHeader struct {
+	Index  int           `json:"index"`
+	Name   string        `json:"name"`
+	Rows   int32         `json:"rows"`
+	Cols   int32         `json:"cols"`
+	Color  string        `json:"color,omitempty"`
+	Group  string        `json:"group,omitempty"`
+	Merges []utils.Range `json:"merges,omitempty"`
+}
+
+type ndjsonCellRecord struct {
+	Sheet int       `json:"sheet"`
+	Ref   string    `json:"ref"`
+	Data  *CellData `json:"cell"`
+}
+
+// gsheetStreamWriter writes a .gsheet file as gzipped NDJSON: a magic line,
+// a header record, then one "sheet" record per BeginSheet and one "cell"
+// record per non-empty cell - never holding the whole workbook's JSON tree
+// in memory the way SaveWorkbook's json.MarshalIndent does.
+type gsheetStreamWriter struct {
+	f        *os.File
+	gz       *gzip.Writer
+	enc      *json.Encoder
+	sheetIdx int
+}
+
+// NewGSheetStreamWriter opens filename (forcing a .gsheet extension) and
+// writes the magic line and header record, ready for BeginSheet/WriteRow.
+func NewGSheetStreamWriter(filename string, activeSheet int, names []NamedRange) (*gsheetStreamWriter, error) {
+	if !strings.HasSuffix(filename, ".gsheet") {
+		if idx := strings.Index(filename, "."); idx != -1 {
+			filename = filename[:idx]
+		}
+		filename += ".gsheet"
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(f)
+	w := &gsheetStreamWriter{f: f, gz: gz, enc: json.NewEncoder(gz), sheetIdx: -1}
+
+	if _, err := gz.Write([]byte(gsheetNDJSONMagic + "\n")); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.writeRecord("header", ndjsonHeader{Version: utils.FILEVER, ActiveSheet: activeSheet, Names: names}); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *gsheetStreamWriter) writeRecord(kind string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return w.enc.Encode(ndjsonRecord{Type: kind, Data: data})
+}
+
+func (w *gsheetStreamWriter) BeginSheet(name string, rows, cols int32) error {
+	w.sheetIdx++
+	return w.writeRecord("sheet", ndjsonSheetHeader{Index: w.sheetIdx, Name: name, Rows: rows, Cols: cols})
+}
+
+func (w *gsheetStreamWriter) WriteRow(rowIdx int32, cells map[int32]*cell.Cell) error {
+	for col, c := range cells {
+		if c == nil || c.RawValue == nil {
+			continue
+		}
+		ref := fmt.Sprintf("%s%d", utils.ColumnName(col), rowIdx)
+		cleanRaw := cell.StripTviewTags(strings.TrimSpace(*c.RawValue))
+		rec := ndjsonCellRecord{Sheet: w.sheetIdx, Ref: ref, Data: &CellData{Cell: c, RawValue: cleanRaw}}
+		if err := w.writeRecord("cell", rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EndSheet is a no-op: every "sheet"/"cell" record already names its own
+// sheet index, so the NDJSON stream needs no explicit sheet terminator.
+func (w *gsheetStreamWriter) EndSheet() error {
+	return nil
+}
+
+func (w *gsheetStreamWriter) Close() error {
+	if err := w.gz.Close(); err != nil {
+		w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// SaveWorkbookStreaming is SaveWorkbook's streaming counterpart, writing
+// gzipped NDJSON via gsheetStreamWriter instead of gzipping one
+// json.MarshalIndent buffer.
+func SaveWorkbookStreaming(sheets []SheetInfo, activeSheet int, filename string) error {
+	w, err := NewGSheetStreamWriter(filename, activeSheet, nil)
+	if err != nil {
+		return err
+	}
+	if err := StreamWorkbook(w, sheets); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// loadGSheetNDJSON reads a gsheet-ndjson-framed stream (everything after the
+// magic line OpenWorkbook already consumed) with bufio.Scanner, one record
+// at a time, so opening a huge workbook never holds the whole decoded JSON
+// tree in memory the way json.Decoder.Decode(&WorkbookData{}) would.
+func loadGSheetNDJSON(r *bufio.Reader) (*WorkbookResult, error) {
+	result := &WorkbookResult{Version: "gsheet-ndjson"}
+	var sheets []SheetResult
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	ensureSheet := func(idx int) {
+		for len(sheets) <= idx {
+			sheets = append(sheets, SheetResult{})
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var rec ndjsonRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("invalid ndjson record: %v", err)
+		}
+
+		switch rec.Type {
+		case "header":
+			var h ndjsonHeader
+			if err := json.Unmarshal(rec.Data, &h); err != nil {
+				return nil, fmt.Errorf("invalid ndjson header: %v", err)
+			}
+			result.ActiveSheet = h.ActiveSheet
+			result.Names = h.Names
+
+		case "sheet":
+			var sh ndjsonSheetHeader
+			if err := json.Unmarshal(rec.Data, &sh); err != nil {
+				return nil, fmt.Errorf("invalid ndjson sheet header: %v", err)
+			}
+			ensureSheet(sh.Index)
+			sheets[sh.Index] = SheetResult{Name: sh.Name, Rows: sh.Rows, Cols: sh.Cols, Color: sh.Color, Group: sh.Group, Merges: sh.Merges}
+
+		case "cell":
+			var cr ndjsonCellRecord
+			if err := json.Unmarshal(rec.Data, &cr); err != nil {
+				return nil, fmt.Errorf("invalid ndjson cell record: %v", err)
+			}
+			if cr.Data == nil || cr.Data.Cell == nil {
+				continue
+			}
+			ensureSheet(cr.Sheet)
+			sheets[cr.Sheet].Cells = append(sheets[cr.Sheet].Cells, ndjsonToCell(cr.Data))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading ndjson stream: %v", err)
+	}
+
+	result.Sheets = sheets
+	return result, nil
+}
+
+// ndjsonToCell applies processCellData's same field-defaulting (a cell
+// decoded off the wire is missing RawValue/Display/Type/etc. until they're
+// filled in) to one streamed cell record.
+func ndjsonToCell(cd *CellData) *cell.Cell {
+	c := cd.Cell
+	c.RawValue = &cd.RawValue
+
+	if c.Display == nil {
+		displayValue := cd.RawValue
+		c.Display = &displayValue
+	}
+	if c.Type == nil {
+		typeValue := "string"
+		c.Type = &typeValue
+	}
+	if c.Notes == nil {
+		emptyStr := ""
+		c.Notes = &emptyStr
+	}
+	if c.Valrule == nil {
+		emptyStr := ""
+		c.Valrule = &emptyStr
+	}
+	if c.Valrulemsg == nil {
+		emptyStr := ""
+		c.Valrulemsg = &emptyStr
+	}
+	if c.DependsOn == nil {
+		c.DependsOn = []*string{}
+	}
+	if c.Dependents == nil {
+		c.Dependents = []*string{}
+	}
+
+	return c
+}
+
+// --- CSV/TXT ------------------------------------------------------------
+
+// csvStreamWriter streams rows straight to encoding/csv.Writer instead of
+// SaveTableAsCSV's pre-scan-then-iterate-the-dense-rectangle approach. CSV
+// has no sheet concept, so a second BeginSheet errors instead of silently
+// overwriting the first sheet's rows.
+type csvStreamWriter struct {
+	f       *os.File
+	w       *csv.Writer
+	cols    int32
+	lastRow int32
+	began   bool
+}
+
+func NewCSVStreamWriter(filename string) (*csvStreamWriter, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &csvStreamWriter{f: f, w: csv.NewWriter(f)}, nil
+}
+
+func (w *csvStreamWriter) BeginSheet(name string, rows, cols int32) error {
+	if w.began {
+		return fmt.Errorf("CSV supports only a single sheet")
+	}
+	w.began = true
+	w.cols = cols
+	return nil
+}
+
+func (w *csvStreamWriter) WriteRow(rowIdx int32, cells map[int32]*cell.Cell) error {
+	for ; w.lastRow < rowIdx-1; w.lastRow++ {
+		if err := w.w.Write(make([]string, w.cols)); err != nil {
+			return err
+		}
+	}
+	record := make([]string, w.cols)
+	for col, c := range cells {
+		if col < 1 || col > w.cols || c == nil || c.RawValue == nil {
+			continue
+		}
+		record[col-1] = *c.RawValue
+	}
+	w.lastRow = rowIdx
+	return w.w.Write(record)
+}
+
+func (w *csvStreamWriter) EndSheet() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvStreamWriter) Close() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// txtStreamWriter is csvStreamWriter's tab-delimited counterpart, matching
+// writeTabDelimited's output shape a row at a time.
+type txtStreamWriter struct {
+	f       *os.File
+	w       *bufio.Writer
+	cols    int32
+	lastRow int32
+	began   bool
+}
+
+func NewTXTStreamWriter(filename string) (*txtStreamWriter, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &txtStreamWriter{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+func (w *txtStreamWriter) BeginSheet(name string, rows, cols int32) error {
+	if w.began {
+		return fmt.Errorf("TXT supports only a single sheet")
+	}
+	w.began = true
+	w.cols = cols
+	return nil
+}
+
+func (w *txtStreamWriter) writeBlankLine() error {
+	_, err := w.w.WriteString(strings.Repeat("\t", int(w.cols-1)) + "\n")
+	return err
+}
+
+func (w *txtStreamWriter) WriteRow(rowIdx int32, cells map[int32]*cell.Cell) error {
+	for ; w.lastRow < rowIdx-1; w.lastRow++ {
+		if err := w.writeBlankLine(); err != nil {
+			return err
+		}
+	}
+	values := make([]string, w.cols)
+	for col, c := range cells {
+		if col < 1 || col > w.cols || c == nil || c.RawValue == nil {
+			continue
+		}
+		values[col-1] = *c.RawValue
+	}
+	w.lastRow = rowIdx
+	_, err := w.w.WriteString(strings.Join(values, "\t") + "\n")
+	return err
+}
+
+func (w *txtStreamWriter) EndSheet() error {
+	return w.w.Flush()
+}
+
+func (w *txtStreamWriter) Close() error {
+	if err := w.w.Flush(); err != nil {
+		w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// --- XLSX -----------------------------------------------------------------
+
+// packageRelsXML/buildContentTypesXML/buildWorkbookXML/buildWorkbookRelsXML
+// and emptyXLSXStylesXML/emptySharedStringsXML below are the minimum-viable
+// OOXML scaffolding xlsxStreamWriter needs around its streamed <sheetData> -
+// xlsx.go's real codec builds all of this (and styling/shared strings) via
+// excelize instead, but excelize's in-memory *excelize.File model means
+// building the whole workbook before a single byte is written, which is
+// exactly what xlsxStreamWriter exists to avoid for a huge sheet.
+const packageRelsXML = xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func buildContentTypesXML(sheetCount int, sheetHasComments []bool) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+		if i-1 < len(sheetHasComments) && sheetHasComments[i-1] {
+			fmt.Fprintf(&b, `<Override PartName="/xl/comments%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"/>`, i)
+		}
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func buildWorkbookXML(sheets []SheetInfo, activeSheet int) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	fmt.Fprintf(&b, `<bookViews><workbookView activeTab="%d"/></bookViews>`, activeSheet)
+	b.WriteString(`<sheets>`)
+	for i, sheet := range sheets {
+		name := sheet.Name
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+		var esc strings.Builder
+		xml.EscapeText(&esc, []byte(name))
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, esc.String(), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func buildWorkbookRelsXML(sheetCount int) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>`, sheetCount+1)
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheetCount+2)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// emptyXLSXStylesXML/emptySharedStringsXML are xlsxStreamWriter's styles.xml/
+// sharedStrings.xml parts - always the empty table, since it writes every
+// value inline (t="str") rather than through a shared-string table (deduping
+// strings would mean buffering them all before the first row can be
+// written) and drops styling entirely (see xlsxStreamWriter's doc comment).
+func emptyXLSXStylesXML() string {
+	return xmlHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font/></fonts><fills count="1"><fill/></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+		`<cellXfs count="1"><xf/></cellXfs></styleSheet>`
+}
+
+func emptySharedStringsXML() string {
+	return xmlHeader + `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="0" uniqueCount="0"/>`
+}
+
+// xlsxStreamWriter writes worksheet XML straight to its zip entry as rows
+// arrive, rather than SaveWorkbookAsXLSX's (xlsx.go) build-the-whole-
+// excelize-File-then-write-it-once approach. It intentionally drops styling,
+// merges, comments, and data validation - this is the "good enough for a
+// huge sheet" reduced-fidelity mode, same kind of tradeoff SaveWorkbookAsXLSX
+// already takes against real Excel by not exporting charts, just narrower -
+// and it writes values inline (t="str") rather than through a shared-string
+// table, since deduping strings would mean buffering them all before the
+// first row can be written.
+type xlsxStreamWriter struct {
+	f           *os.File
+	zw          *zip.Writer
+	sheetNames  []string
+	activeSheet int
+	sheetIdx    int
+	sheetW      io.Writer
+}
+
+func NewXLSXStreamWriter(filename string, sheetNames []string, activeSheet int) (*xlsxStreamWriter, error) {
+	if !strings.HasSuffix(filename, ".xlsx") {
+		if idx := strings.Index(filename, "."); idx != -1 {
+			filename = filename[:idx]
+		}
+		filename += ".xlsx"
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	zw := zip.NewWriter(f)
+	w := &xlsxStreamWriter{f: f, zw: zw, sheetNames: sheetNames, activeSheet: activeSheet, sheetIdx: -1}
+
+	hasComments := make([]bool, len(sheetNames))
+	if err := writeZipEntry(zw, "[Content_Types].xml", buildContentTypesXML(len(sheetNames), hasComments)); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := writeZipEntry(zw, "_rels/.rels", packageRelsXML); err != nil {
+		w.Close()
+		return nil, err
+	}
+	infos := make([]SheetInfo, len(sheetNames))
+	for i, name := range sheetNames {
+		infos[i] = SheetInfo{Name: name}
+	}
+	if err := writeZipEntry(zw, "xl/workbook.xml", buildWorkbookXML(infos, activeSheet)); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := writeZipEntry(zw, "xl/_rels/workbook.xml.rels", buildWorkbookRelsXML(len(sheetNames))); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := writeZipEntry(zw, "xl/styles.xml", emptyXLSXStylesXML()); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := writeZipEntry(zw, "xl/sharedStrings.xml", emptySharedStringsXML()); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *xlsxStreamWriter) BeginSheet(name string, rows, cols int32) error {
+	w.sheetIdx++
+	sw, err := w.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", w.sheetIdx+1))
+	if err != nil {
+		return err
+	}
+	w.sheetW = sw
+	_, err = io.WriteString(w.sheetW, xmlHeader+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return err
+}
+
+func (w *xlsxStreamWriter) WriteRow(rowIdx int32, cells map[int32]*cell.Cell) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, rowIdx)
+	for col, c := range cells {
+		if c == nil || c.RawValue == nil {
+			continue
+		}
+		ref := columnIndexToLetter(col) + strconv.Itoa(int(rowIdx))
+		raw := *c.RawValue
+		switch {
+		case strings.HasPrefix(raw, "="):
+			b.WriteString(`<c r="` + ref + `"><f>`)
+			xml.EscapeText(&b, []byte(strings.TrimPrefix(raw, "=")))
+			b.WriteString(`</f></c>`)
+		default:
+			if f, err := strconv.ParseFloat(raw, 64); err == nil {
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(f, 'g', -1, 64))
+			} else {
+				b.WriteString(`<c r="` + ref + `" t="str"><v>`)
+				xml.EscapeText(&b, []byte(raw))
+				b.WriteString(`</v></c>`)
+			}
+		}
+	}
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(w.sheetW, b.String())
+	return err
+}
+
+func (w *xlsxStreamWriter) EndSheet() error {
+	_, err := io.WriteString(w.sheetW, `</sheetData></worksheet>`)
+	w.sheetW = nil
+	return err
+}
+
+func (w *xlsxStreamWriter) Close() error {
+	if err := w.zw.Close(); err != nil {
+		w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
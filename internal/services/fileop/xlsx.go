@@ -0,0 +1,946 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// xlsx.go adds native Microsoft Excel .xlsx import/export alongside the
+// .gsheet/.json formats in open.go/save.go, built on
+// github.com/xuri/excelize/v2 the same way decimal.go's Decimal mode and
+// the validation engines (ui/validationEngines.go) pull in math/big and
+// google/cel-go: a plain source-level import, with no go.mod/vendoring
+// in this tree to pin it. excelize owns the .xlsx ZIP/OOXML layer
+// entirely - reading/writing cell values, formulas, styles, merges, data
+// validations, and comments - so this file's job is only translating
+// between its types and GoSheet's own [2]int/A1-keyed cell model.
+//
+// A worksheet's data validations round-trip to and from GoSheet's own
+// Valrule/Valrulemsg rule text via xlsxValidationToRule/
+// ruleToXLSXValidation, matching the exact rule shapes
+// GetValidationPresets() in dataValidationUI.go generates. Style
+// (bold/italic/underline/strikethrough/colors/alignment/number format)
+// is deduplicated into one excelize style ID per distinct combination
+// (xlsxCellStyle/styleIDFor), the same dedup-by-value approach
+// xlsxStyleTable used before this file went through excelize. Comment
+// text round-trips through excelize's own Comment type; cell.Notes'
+// Excel UI red-corner indicator is whatever excelize itself emits.
+package fileop
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+
+	"github.com/rivo/tview"
+	"github.com/xuri/excelize/v2"
+)
+
+// XLSXFormatHandler handles .xlsx import/export, delegating to
+// OpenXLSXWorkbook/SaveWorkbookAsXLSX below for the actual excelize-backed
+// codec so callers that go through the format registry and callers that
+// still call OpenWorkbook directly (open.go's .xlsx branch) share one
+// implementation.
+type XLSXFormatHandler struct{}
+
+func (h *XLSXFormatHandler) SupportsFormat(format FileFormat) bool {
+	return format == FormatXLSX
+}
+
+func (h *XLSXFormatHandler) Extensions() []string {
+	return []string{".xlsx"}
+}
+
+func (h *XLSXFormatHandler) Write(filename string, sheets []SheetInfo, activeSheet int) error {
+	return SaveWorkbookAsXLSX(sheets, activeSheet, filename)
+}
+
+// Read satisfies ReadableFormatHandler by adapting OpenXLSXWorkbook's
+// WorkbookResult shape to the []SheetInfo shape the registry shares across
+// every format.
+func (h *XLSXFormatHandler) Read(filename string) ([]SheetInfo, int, error) {
+	result, err := OpenXLSXWorkbook(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sheetInfosFromResult(result), result.ActiveSheet, nil
+}
+
+// OpenXLSXWorkbook loads a workbook from a .xlsx file, in the same shape
+// OpenWorkbook returns for .gsheet/.json so callers can treat every format
+// identically once loaded.
+func OpenXLSXWorkbook(filename string) (*WorkbookResult, error) {
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open xlsx: %v", err)
+	}
+	defer f.Close()
+
+	sheetNames := f.GetSheetList()
+	result := &WorkbookResult{
+		Sheets:      make([]SheetResult, 0, len(sheetNames)),
+		ActiveSheet: f.GetActiveSheetIndex(),
+		Version:     "xlsx",
+	}
+
+	for _, name := range sheetNames {
+		cells, rows, cols, merges, err := readSheetBody(f, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %q: %v", name, err)
+		}
+		result.Sheets = append(result.Sheets, SheetResult{
+			Name:   name,
+			Cells:  cells,
+			Rows:   rows,
+			Cols:   cols,
+			Merges: merges,
+		})
+	}
+
+	return result, nil
+}
+
+// readSheetBody reads sheet's populated cells, applies their styles and any
+// comments, then layers its data validations and merges on top.
+func readSheetBody(f *excelize.File, name string) ([]*cell.Cell, int32, int32, []utils.Range, error) {
+	dim, err := f.GetSheetDimension(name)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+	r1, c1, r2, c2, err := dimensionBounds(dim)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	comments, err := f.GetComments(name)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+	commentByRef := make(map[string]string, len(comments))
+	for _, cm := range comments {
+		commentByRef[cm.Cell] = cm.Text
+	}
+
+	var cells []*cell.Cell
+	var maxRow, maxCol int32
+	byKey := make(map[[2]int]*cell.Cell)
+
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			ref, err := excelize.CoordinatesToCellName(int(c), int(r))
+			if err != nil {
+				continue
+			}
+			rawValue, err := f.GetCellValue(name, ref)
+			if err != nil {
+				continue
+			}
+			formula, _ := f.GetCellFormula(name, ref)
+			styleID, _ := f.GetCellStyle(name, ref)
+			note, hasNote := commentByRef[ref]
+
+			if rawValue == "" && formula == "" && styleID == 0 && !hasNote {
+				continue
+			}
+			if formula != "" {
+				rawValue = "=" + formula
+			}
+
+			cellData := newCellFromXLSX(r, c, rawValue, note)
+			if styleID != 0 {
+				if style, err := f.GetStyle(styleID); err == nil {
+					applyXLSXStyle(cellData, style)
+				}
+			}
+
+			cells = append(cells, cellData)
+			byKey[[2]int{int(r), int(c)}] = cellData
+			if r > maxRow {
+				maxRow = r
+			}
+			if c > maxCol {
+				maxCol = c
+			}
+		}
+	}
+
+	if err := applyDataValidations(f, name, &cells, byKey, &maxRow, &maxCol); err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	merges, err := readMergeCells(f, name)
+	if err != nil {
+		return nil, 0, 0, nil, err
+	}
+
+	return cells, maxRow, maxCol, merges, nil
+}
+
+// dimensionBounds parses a GetSheetDimension result ("A1:D10", or a bare
+// "A1" for a one-cell sheet) into 1-indexed row/col bounds.
+func dimensionBounds(dim string) (r1, c1, r2, c2 int32, err error) {
+	parts := strings.SplitN(dim, ":", 2)
+	c1i, r1i, err := excelize.CellNameToCoordinates(parts[0])
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	c2i, r2i := c1i, r1i
+	if len(parts) == 2 {
+		c2i, r2i, err = excelize.CellNameToCoordinates(parts[1])
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+	}
+	return int32(r1i), int32(c1i), int32(r2i), int32(c2i), nil
+}
+
+// newCellFromXLSX builds a cell.Cell for a populated (row, col) read from a
+// .xlsx worksheet, the same field set blankCell gives a validation-only
+// cell with no value of its own.
+func newCellFromXLSX(row, col int32, rawValue, note string) *cell.Cell {
+	displayValue := rawValue
+	typeValue := "string"
+	emptyStr := ""
+	autotype := "auto"
+	noteText := note
+
+	return &cell.Cell{
+		Row:      row,
+		Column:   col,
+		MaxWidth: 20,
+		MinWidth: 8,
+		RawValue: &rawValue,
+		Display:  &displayValue,
+		Type:     &typeValue,
+
+		Notes:      &noteText,
+		Valrule:    &emptyStr,
+		Valrulemsg: &emptyStr,
+
+		Color:   utils.ColorOptions["White"],
+		BgColor: utils.ColorOptions["Black"],
+
+		DateTimeFormat: &autotype,
+
+		DependsOn:  []*string{},
+		Dependents: []*string{},
+	}
+}
+
+// readMergeCells converts excelize's merged-cell list into GoSheet's own
+// utils.Range shape.
+func readMergeCells(f *excelize.File, name string) ([]utils.Range, error) {
+	mcs, err := f.GetMergeCells(name)
+	if err != nil {
+		return nil, err
+	}
+	merges := make([]utils.Range, 0, len(mcs))
+	for _, mc := range mcs {
+		c1, r1, err := excelize.CellNameToCoordinates(mc.GetStartAxis())
+		if err != nil {
+			continue
+		}
+		c2, r2, err := excelize.CellNameToCoordinates(mc.GetEndAxis())
+		if err != nil {
+			continue
+		}
+		merges = append(merges, utils.Range{TopRow: int32(r1), LeftCol: int32(c1), BottomRow: int32(r2), RightCol: int32(c2)})
+	}
+	return merges, nil
+}
+
+// applyDataValidations layers sheet's data validations onto the cells
+// already read in cells/byKey, synthesizing a blank cell (via blankCell)
+// for a validation target that otherwise has no value, the same thing the
+// hand-rolled reader this file replaced did.
+func applyDataValidations(f *excelize.File, name string, cells *[]*cell.Cell, byKey map[[2]int]*cell.Cell, maxRow, maxCol *int32) error {
+	dvs, err := f.GetDataValidations(name)
+	if err != nil {
+		return err
+	}
+	for _, dv := range dvs {
+		for _, bound := range parseSqref(dv.Sqref) {
+			for r := bound.r1; r <= bound.r2; r++ {
+				for c := bound.c1; c <= bound.c2; c++ {
+					key := [2]int{int(r), int(c)}
+					target, exists := byKey[key]
+					if !exists {
+						target = blankCell(r, c)
+						byKey[key] = target
+						*cells = append(*cells, target)
+						if r > *maxRow {
+							*maxRow = r
+						}
+						if c > *maxCol {
+							*maxCol = c
+						}
+					}
+					rule, msg := xlsxValidationToRule(dv, columnIndexToLetter(c)+strconv.Itoa(int(r)))
+					target.Valrule = &rule
+					target.Valrulemsg = &msg
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// blankCell returns an otherwise-empty cell at (row, col), for a validation
+// whose sqref names a cell with no value of its own.
+func blankCell(row, col int32) *cell.Cell {
+	emptyStr := ""
+	rawValue := ""
+	displayValue := ""
+	typeValue := "string"
+	autotype := "auto"
+
+	return &cell.Cell{
+		Row:      row,
+		Column:   col,
+		MaxWidth: 20,
+		MinWidth: 8,
+		RawValue: &rawValue,
+		Display:  &displayValue,
+		Type:     &typeValue,
+
+		Notes:      &emptyStr,
+		Valrule:    &emptyStr,
+		Valrulemsg: &emptyStr,
+
+		Color:   utils.ColorOptions["White"],
+		BgColor: utils.ColorOptions["Black"],
+
+		DateTimeFormat: &autotype,
+
+		DependsOn:  []*string{},
+		Dependents: []*string{},
+	}
+}
+
+// columnLetterToIndex/columnIndexToLetter/parseA1Ref/cellBound/parseSqref
+// stay on plain A1-text math rather than excelize.CellNameToCoordinates/
+// CoordinatesToCellName: they're shared with the rule-translation helpers
+// below (cellRef text embedded in rule strings, sqref token splitting),
+// which operate on dv.Sqref/c.Column/c.Row values rather than excelize
+// cell handles.
+
+// parseA1Ref splits an XLSX cell reference ("B12") into 1-indexed column and
+// row numbers.
+func parseA1Ref(ref string) (col, row int32, err error) {
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		i++
+	}
+	if i == 0 || i == len(ref) {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	col = columnLetterToIndex(ref[:i])
+	r, err := strconv.Atoi(ref[i:])
+	if err != nil {
+		return 0, 0, err
+	}
+	return col, int32(r), nil
+}
+
+func columnLetterToIndex(letters string) int32 {
+	var col int32
+	for _, ch := range letters {
+		col = col*26 + int32(ch-'A'+1)
+	}
+	return col
+}
+
+func columnIndexToLetter(col int32) string {
+	var letters []byte
+	for col > 0 {
+		col--
+		letters = append([]byte{byte('A' + col%26)}, letters...)
+		col /= 26
+	}
+	return string(letters)
+}
+
+// cellBound is one inclusive rectangular A1 range, as named by a
+// dataValidation's space-separated sqref attribute.
+type cellBound struct {
+	r1, c1, r2, c2 int32
+}
+
+// parseSqref splits a dataValidation's sqref ("B2 D4:D10") into the
+// rectangles it names, ignoring any token that isn't a valid cell or range
+// reference.
+func parseSqref(sqref string) []cellBound {
+	var bounds []cellBound
+	for _, tok := range strings.Fields(sqref) {
+		parts := strings.SplitN(tok, ":", 2)
+		c1, r1, err := parseA1Ref(parts[0])
+		if err != nil {
+			continue
+		}
+		c2, r2 := c1, r1
+		if len(parts) == 2 {
+			c2, r2, err = parseA1Ref(parts[1])
+			if err != nil {
+				continue
+			}
+		}
+		if r1 > r2 {
+			r1, r2 = r2, r1
+		}
+		if c1 > c2 {
+			c1, c2 = c2, c1
+		}
+		bounds = append(bounds, cellBound{r1: r1, c1: c1, r2: r2, c2: c2})
+	}
+	return bounds
+}
+
+// xlsxListRangePattern/xlsxListValuePattern mirror the "List - From Cell
+// Range"/"List - Allowed Values" preset shapes in dataValidationUI.go.
+// fileop can't import ui (ui imports fileop, not the other way around), so
+// these are duplicated locally rather than exported from there - the same
+// tradeoff evaluatefuncs.checkArgs makes against utils.validateArgs.
+var (
+	xlsxListRangePattern = regexp.MustCompile(`^LIST_RANGE\(([A-Za-z0-9_!$:]+)\)$`)
+	xlsxListValuePattern = regexp.MustCompile(`THIS == "([^"]+)"`)
+
+	xlsxWholeBetweenPattern   = regexp.MustCompile(`^THIS >= (-?[\d.]+) && THIS <= (-?[\d.]+) && THIS == FLOOR\(THIS\)$`)
+	xlsxWholeGreaterPattern   = regexp.MustCompile(`^THIS > (-?[\d.]+) && THIS == FLOOR\(THIS\)$`)
+	xlsxWholeLessPattern      = regexp.MustCompile(`^THIS < (-?[\d.]+) && THIS == FLOOR\(THIS\)$`)
+	xlsxDecimalBetweenPattern = regexp.MustCompile(`^THIS >= (-?[\d.]+) && THIS <= (-?[\d.]+)$`)
+	xlsxDecimalGreaterPattern = regexp.MustCompile(`^THIS > (-?[\d.]+)$`)
+	xlsxDecimalLessPattern    = regexp.MustCompile(`^THIS < (-?[\d.]+)$`)
+	xlsxTextLenBetweenPattern = regexp.MustCompile(`^LEN\(THIS\) >= (\d+) && LEN\(THIS\) <= (\d+)$`)
+	xlsxTextLenMaxPattern     = regexp.MustCompile(`^LEN\(THIS\) <= (\d+)$`)
+
+	xlsxAndPattern = regexp.MustCompile(`(?i)^AND\((.*)\)$`)
+	xlsxOrPattern  = regexp.MustCompile(`(?i)^OR\((.*)\)$`)
+)
+
+// replaceCellRef rewrites every bare or $-anchored occurrence of cellRef
+// (e.g. "B2" matches "B2" and "$B$2") in expr with repl, used to translate
+// between an Excel custom formula's own-cell address and GoSheet's "THIS".
+func replaceCellRef(expr, cellRef, repl string) string {
+	i := 0
+	for i < len(cellRef) && ((cellRef[i] >= 'A' && cellRef[i] <= 'Z') || (cellRef[i] >= 'a' && cellRef[i] <= 'z')) {
+		i++
+	}
+	letters, digits := cellRef[:i], cellRef[i:]
+	re := regexp.MustCompile(`\$?(?i:` + regexp.QuoteMeta(letters) + `)\$?` + regexp.QuoteMeta(digits) + `\b`)
+	return re.ReplaceAllString(expr, repl)
+}
+
+// fromExcelFormula translates an Excel custom-validation formula into
+// GoSheet rule text: the cell's own address becomes THIS, <> becomes !=,
+// and a single top-level AND(...)/OR(...) wrapper becomes &&/||. Anything
+// more deeply nested is passed through unevaluated rather than guessed at.
+func fromExcelFormula(formula, cellRef string) string {
+	expr := strings.TrimSpace(strings.TrimPrefix(formula, "="))
+	expr = replaceCellRef(expr, cellRef, "THIS")
+	expr = strings.ReplaceAll(expr, "<>", "!=")
+	if m := xlsxAndPattern.FindStringSubmatch(expr); m != nil {
+		expr = strings.Join(strings.Split(m[1], ","), " && ")
+	} else if m := xlsxOrPattern.FindStringSubmatch(expr); m != nil {
+		expr = strings.Join(strings.Split(m[1], ","), " || ")
+	}
+	return strings.TrimSpace(expr)
+}
+
+// toExcelFormula is fromExcelFormula's inverse for export: THIS becomes the
+// cell's own address, != becomes <>, and a flat &&/|| chain becomes an
+// AND(...)/OR(...) call, matching the one-operator-deep translation
+// fromExcelFormula understands on the way back in.
+func toExcelFormula(rule, cellRef string) string {
+	expr := strings.ReplaceAll(rule, "THIS", cellRef)
+	expr = strings.ReplaceAll(expr, "!=", "<>")
+	switch {
+	case strings.Contains(expr, "&&"):
+		parts := strings.Split(expr, "&&")
+		for i, p := range parts {
+			parts[i] = strings.TrimSpace(p)
+		}
+		expr = "AND(" + strings.Join(parts, ",") + ")"
+	case strings.Contains(expr, "||"):
+		parts := strings.Split(expr, "||")
+		for i, p := range parts {
+			parts[i] = strings.TrimSpace(p)
+		}
+		expr = "OR(" + strings.Join(parts, ",") + ")"
+	}
+	return expr
+}
+
+// xlsxListFormulaToRule translates a "list" validation's formula1, either a
+// quoted literal list ("Red,Green,Blue") or a range reference, into the
+// matching "List - Allowed Values"/"List - From Cell Range" rule text.
+func xlsxListFormulaToRule(formula string) string {
+	trimmed := strings.TrimSpace(formula)
+	if strings.HasPrefix(trimmed, `"`) && strings.HasSuffix(trimmed, `"`) {
+		values := strings.Split(strings.Trim(trimmed, `"`), ",")
+		conditions := make([]string, len(values))
+		for i, v := range values {
+			conditions[i] = fmt.Sprintf("THIS == \"%s\"", strings.TrimSpace(v))
+		}
+		return strings.Join(conditions, " || ")
+	}
+	rangeRef := strings.ReplaceAll(strings.TrimPrefix(trimmed, "="), "$", "")
+	return fmt.Sprintf("LIST_RANGE(%s)", strings.ToUpper(rangeRef))
+}
+
+// xlsxValidationToRule translates one excelize.DataValidation entry into the
+// Valrule/Valrulemsg text CheckValidationRule expects, for the cell at
+// cellRef (its own address, used to resolve "custom" formulas).
+func xlsxValidationToRule(dv *excelize.DataValidation, cellRef string) (rule, msg string) {
+	f1, f2 := dv.Formula1, dv.Formula2
+
+	switch dv.Type {
+	case "whole", "decimal":
+		floorClause := ""
+		if dv.Type == "whole" {
+			floorClause = " && THIS == FLOOR(THIS)"
+		}
+		switch dv.Operator {
+		case "notBetween":
+			rule = fmt.Sprintf("(THIS < %s || THIS > %s)%s", f1, f2, floorClause)
+		case "equal":
+			rule = fmt.Sprintf("THIS == %s%s", f1, floorClause)
+		case "notEqual":
+			rule = fmt.Sprintf("THIS != %s%s", f1, floorClause)
+		case "greaterThan":
+			rule = fmt.Sprintf("THIS > %s%s", f1, floorClause)
+		case "lessThan":
+			rule = fmt.Sprintf("THIS < %s%s", f1, floorClause)
+		case "greaterThanOrEqual":
+			rule = fmt.Sprintf("THIS >= %s%s", f1, floorClause)
+		case "lessThanOrEqual":
+			rule = fmt.Sprintf("THIS <= %s%s", f1, floorClause)
+		default:
+			rule = fmt.Sprintf("THIS >= %s && THIS <= %s%s", f1, f2, floorClause)
+		}
+	case "textLength":
+		switch dv.Operator {
+		case "notBetween":
+			rule = fmt.Sprintf("(LEN(THIS) < %s || LEN(THIS) > %s)", f1, f2)
+		case "equal":
+			rule = fmt.Sprintf("LEN(THIS) == %s", f1)
+		case "notEqual":
+			rule = fmt.Sprintf("LEN(THIS) != %s", f1)
+		case "greaterThan":
+			rule = fmt.Sprintf("LEN(THIS) > %s", f1)
+		case "lessThan":
+			rule = fmt.Sprintf("LEN(THIS) < %s", f1)
+		case "greaterThanOrEqual":
+			rule = fmt.Sprintf("LEN(THIS) >= %s", f1)
+		case "lessThanOrEqual":
+			rule = fmt.Sprintf("LEN(THIS) <= %s", f1)
+		default:
+			rule = fmt.Sprintf("LEN(THIS) >= %s && LEN(THIS) <= %s", f1, f2)
+		}
+	case "list":
+		rule = xlsxListFormulaToRule(f1)
+	case "custom":
+		rule = fromExcelFormula(f1, cellRef)
+	}
+
+	if dv.Error != nil {
+		msg = *dv.Error
+	}
+	return rule, msg
+}
+
+// ruleToXLSXValidation is xlsxValidationToRule's inverse for export: it
+// recognizes the exact rule shapes GetValidationPresets() generates and
+// falls back to a "custom" formula for anything else, so round-tripping a
+// file GoSheet itself wrote reproduces the original preset's Excel type.
+func ruleToXLSXValidation(rule, msg, sqref, cellRef string) *excelize.DataValidation {
+	rule = strings.TrimSpace(rule)
+	dv := &excelize.DataValidation{
+		Sqref:            sqref,
+		AllowBlank:       true,
+		ShowErrorMessage: msg != "",
+	}
+	if msg != "" {
+		errMsg := msg
+		dv.Error = &errMsg
+	}
+
+	switch {
+	case xlsxListRangePattern.MatchString(rule):
+		m := xlsxListRangePattern.FindStringSubmatch(rule)
+		dv.Type = "list"
+		dv.Formula1 = "=" + m[1]
+	case strings.Contains(rule, "||") && xlsxListValuePattern.MatchString(rule):
+		matches := xlsxListValuePattern.FindAllStringSubmatch(rule, -1)
+		values := make([]string, len(matches))
+		for i, m := range matches {
+			values[i] = m[1]
+		}
+		dv.Type = "list"
+		dv.Formula1 = `"` + strings.Join(values, ",") + `"`
+	case xlsxWholeBetweenPattern.MatchString(rule):
+		m := xlsxWholeBetweenPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1, dv.Formula2 = "whole", "between", m[1], m[2]
+	case xlsxWholeGreaterPattern.MatchString(rule):
+		m := xlsxWholeGreaterPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1 = "whole", "greaterThan", m[1]
+	case xlsxWholeLessPattern.MatchString(rule):
+		m := xlsxWholeLessPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1 = "whole", "lessThan", m[1]
+	case xlsxDecimalBetweenPattern.MatchString(rule):
+		m := xlsxDecimalBetweenPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1, dv.Formula2 = "decimal", "between", m[1], m[2]
+	case xlsxDecimalGreaterPattern.MatchString(rule):
+		m := xlsxDecimalGreaterPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1 = "decimal", "greaterThan", m[1]
+	case xlsxDecimalLessPattern.MatchString(rule):
+		m := xlsxDecimalLessPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1 = "decimal", "lessThan", m[1]
+	case xlsxTextLenBetweenPattern.MatchString(rule):
+		m := xlsxTextLenBetweenPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1, dv.Formula2 = "textLength", "between", m[1], m[2]
+	case xlsxTextLenMaxPattern.MatchString(rule):
+		m := xlsxTextLenMaxPattern.FindStringSubmatch(rule)
+		dv.Type, dv.Operator, dv.Formula1 = "textLength", "lessThanOrEqual", m[1]
+	default:
+		dv.Type = "custom"
+		dv.Formula1 = toExcelFormula(rule, cellRef)
+	}
+
+	return dv
+}
+
+// --- Styles (bold/italic/underline + RGB colors) ----------------------------
+
+// xlsxCellStyle is the subset of an excelize cell style this package
+// understands, used as a map key to dedupe repeated style combinations down
+// to one excelize style ID each (styleIDFor). The zero value means "no
+// override", matching a cell.Cell with no flags and default Color/BgColor,
+// and is never interned - SetCellStyle is simply skipped for it.
+type xlsxCellStyle struct {
+	bold, italic, underline, strikethrough bool
+	textColor                              string // 6 hex digits, no "#"/alpha; "" means no override
+	bgColor                                string
+	align                                  int8   // tview.AlignLeft/Center/Right
+	numFmt                                 string // OOXML format code, "" means General
+}
+
+// hexDigits strips a leading "#" from a Color.Hex() string, uppercasing the
+// rest to the bare hex excelize.Font/Fill.Color expect.
+func hexDigits(hex string) string {
+	return strings.ToUpper(strings.TrimPrefix(hex, "#"))
+}
+
+// numFmtCode derives an OOXML number-format code from c's formatting fields
+// (the same fields cellui's financial/number/datetime dropdowns populate),
+// or "" for General - mirrors the "financial"/"number"/"datetime" cellType
+// switch in cellui/formatters.go.
+func numFmtCode(c *cell.Cell) string {
+	if c.Type == nil {
+		return ""
+	}
+	switch *c.Type {
+	case "number", "financial":
+		code := "0"
+		if c.ThousandsSeparator != 0 {
+			code = "#,##0"
+		}
+		if c.DecimalPoints > 0 {
+			code += "." + strings.Repeat("0", int(c.DecimalPoints))
+		}
+		if *c.Type == "financial" && c.FinancialSign != 0 {
+			code = string(c.FinancialSign) + code
+		}
+		return code
+	case "datetime":
+		return "yyyy-mm-dd hh:mm:ss"
+	default:
+		return ""
+	}
+}
+
+// styleIDFor returns c's excelize style ID, building and caching a new
+// excelize.Style the first time a given bold/italic/underline/color/
+// alignment/number-format combination is seen.
+func styleIDFor(f *excelize.File, cache map[xlsxCellStyle]int, c *cell.Cell) (int, error) {
+	style := xlsxCellStyle{
+		bold:          c.HasFlag(cell.FlagBold),
+		italic:        c.HasFlag(cell.FlagItalic),
+		underline:     c.HasFlag(cell.FlagUnderline),
+		strikethrough: c.HasFlag(cell.FlagStrikethrough),
+		align:         c.Align,
+		numFmt:        numFmtCode(c),
+	}
+	if !c.Color.IsDefaultWhite() {
+		style.textColor = hexDigits(c.Color.Hex())
+	}
+	if !c.BgColor.IsDefaultBlack() && !c.BgColor.IsDefaultWhite() {
+		style.bgColor = hexDigits(c.BgColor.Hex())
+	}
+	if style == (xlsxCellStyle{}) {
+		return 0, nil
+	}
+	if id, ok := cache[style]; ok {
+		return id, nil
+	}
+
+	font := &excelize.Font{Bold: style.bold, Italic: style.italic, Strike: style.strikethrough}
+	if style.underline {
+		font.Underline = "single"
+	}
+	if style.textColor != "" {
+		font.Color = style.textColor
+	}
+
+	excelizeStyle := &excelize.Style{Font: font}
+	if style.bgColor != "" {
+		excelizeStyle.Fill = excelize.Fill{Type: "pattern", Color: []string{style.bgColor}, Pattern: 1}
+	}
+	if style.align != 0 {
+		excelizeStyle.Alignment = &excelize.Alignment{Horizontal: getAlignmentStyle(style.align)}
+	}
+	if style.numFmt != "" {
+		numFmt := style.numFmt
+		excelizeStyle.CustomNumFmt = &numFmt
+	}
+
+	id, err := f.NewStyle(excelizeStyle)
+	if err != nil {
+		return 0, err
+	}
+	cache[style] = id
+	return id, nil
+}
+
+// alignFromHorizontal reverses getAlignmentStyle, mapping an excelize
+// Alignment.Horizontal value back to the tview.Align constant it came from.
+func alignFromHorizontal(horizontal string) int8 {
+	switch horizontal {
+	case "center":
+		return tview.AlignCenter
+	case "right":
+		return tview.AlignRight
+	default:
+		return tview.AlignLeft
+	}
+}
+
+// applyXLSXStyle applies style's bold/italic/underline/strikethrough flags,
+// alignment, and RGB colors to cellData. style.NumFmt/CustomNumFmt is
+// deliberately not applied back onto DecimalPoints/ThousandsSeparator/
+// FinancialSign/Type - going from a format code back to those fields isn't a
+// clean inverse of numFmtCode, so a round-tripped cell keeps whatever
+// formatting fields it already had.
+func applyXLSXStyle(cellData *cell.Cell, style *excelize.Style) {
+	if style.Font != nil {
+		if style.Font.Bold {
+			cellData.Flags |= cell.FlagBold
+		}
+		if style.Font.Italic {
+			cellData.Flags |= cell.FlagItalic
+		}
+		if style.Font.Underline != "" {
+			cellData.Flags |= cell.FlagUnderline
+		}
+		if style.Font.Strike {
+			cellData.Flags |= cell.FlagStrikethrough
+		}
+		if style.Font.Color != "" {
+			if rgb, ok := parseHexColor(style.Font.Color); ok {
+				cellData.Color = rgb
+			}
+		}
+	}
+	if len(style.Fill.Color) > 0 && style.Fill.Color[0] != "" {
+		if rgb, ok := parseHexColor(style.Fill.Color[0]); ok {
+			cellData.BgColor = rgb
+		}
+	}
+	if style.Alignment != nil && style.Alignment.Horizontal != "" {
+		cellData.Align = alignFromHorizontal(style.Alignment.Horizontal)
+	}
+}
+
+// parseHexColor parses 6 hex digits (an optional leading "#" is stripped
+// first, since excelize itself is inconsistent about including one) into a
+// cell.Color.
+func parseHexColor(hex string) (cell.Color, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return cell.Color{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return cell.Color{}, false
+	}
+	return cell.Color{byte(v >> 16), byte(v >> 8), byte(v)}, true
+}
+
+// --- Writing -----------------------------------------------------------
+
+// SaveWorkbookAsXLSX writes sheets out as a .xlsx workbook via excelize: one
+// sheet per SheetInfo, each cell's value/formula plus a deduplicated style
+// (styleIDFor), data validations, merges, comments, and column widths.
+// SaveWorkbookAsXLSX does not yet export a sheet's Charts: ChartSpec still
+// round-trips through .gsheet/.json, so a chart survives an xlsx export/
+// reimport cycle through either of those formats instead, but turning it
+// into an actual xl/charts/chartN.xml (excelize's own Chart/AddChart API)
+// is its own piece of work this pass doesn't cover.
+func SaveWorkbookAsXLSX(sheets []SheetInfo, activeSheet int, filename string) error {
+	if !strings.HasSuffix(filename, ".xlsx") {
+		if idx := strings.Index(filename, "."); idx != -1 {
+			filename = filename[:idx]
+		}
+		filename += ".xlsx"
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	styleCache := make(map[xlsxCellStyle]int)
+
+	for i, sheet := range sheets {
+		name := sheet.Name
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", name); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			return err
+		}
+
+		if err := writeWorksheetCells(f, name, sheet, styleCache); err != nil {
+			return fmt.Errorf("failed to write sheet %q: %v", name, err)
+		}
+		if err := writeMergeCells(f, name, sheet); err != nil {
+			return fmt.Errorf("failed to write merges for sheet %q: %v", name, err)
+		}
+		if err := writeDataValidations(f, name, sheet); err != nil {
+			return fmt.Errorf("failed to write data validations for sheet %q: %v", name, err)
+		}
+		if err := writeComments(f, name, sheet); err != nil {
+			return fmt.Errorf("failed to write comments for sheet %q: %v", name, err)
+		}
+		if err := writeColWidths(f, name, sheet); err != nil {
+			return fmt.Errorf("failed to write column widths for sheet %q: %v", name, err)
+		}
+	}
+
+	f.SetActiveSheet(activeSheet)
+	return f.SaveAs(filename)
+}
+
+// writeWorksheetCells writes every cell in sheet.GlobalData as either a
+// formula or a value (numeric where parseable, string otherwise), then
+// applies its deduplicated style.
+func writeWorksheetCells(f *excelize.File, name string, sheet SheetInfo, styleCache map[xlsxCellStyle]int) error {
+	for _, c := range sheet.GlobalData {
+		ref, err := excelize.CoordinatesToCellName(int(c.Column), int(c.Row))
+		if err != nil {
+			continue
+		}
+
+		raw := ""
+		if c.RawValue != nil {
+			raw = *c.RawValue
+		}
+
+		switch {
+		case strings.HasPrefix(raw, "="):
+			if err := f.SetCellFormula(name, ref, strings.TrimPrefix(raw, "=")); err != nil {
+				return err
+			}
+		default:
+			if value, err := strconv.ParseFloat(raw, 64); err == nil {
+				if err := f.SetCellFloat(name, ref, value, -1, 64); err != nil {
+					return err
+				}
+			} else if err := f.SetCellStr(name, ref, raw); err != nil {
+				return err
+			}
+		}
+
+		styleID, err := styleIDFor(f, styleCache, c)
+		if err != nil {
+			return err
+		}
+		if styleID != 0 {
+			if err := f.SetCellStyle(name, ref, ref, styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeMergeCells emits one excelize merge per sheet.Merges entry.
+func writeMergeCells(f *excelize.File, name string, sheet SheetInfo) error {
+	for _, m := range sheet.Merges {
+		topLeft := columnIndexToLetter(m.LeftCol) + strconv.Itoa(int(m.TopRow))
+		bottomRight := columnIndexToLetter(m.RightCol) + strconv.Itoa(int(m.BottomRow))
+		if err := f.MergeCell(name, topLeft, bottomRight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDataValidations emits one excelize data validation per cell in sheet
+// with a non-empty Valrule, translated by ruleToXLSXValidation.
+func writeDataValidations(f *excelize.File, name string, sheet SheetInfo) error {
+	for _, c := range sheet.GlobalData {
+		if c.Valrule == nil || strings.TrimSpace(*c.Valrule) == "" {
+			continue
+		}
+		msg := ""
+		if c.Valrulemsg != nil {
+			msg = *c.Valrulemsg
+		}
+		ref := columnIndexToLetter(c.Column) + strconv.Itoa(int(c.Row))
+		if err := f.AddDataValidation(name, ruleToXLSXValidation(*c.Valrule, msg, ref, ref)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeComments emits one excelize comment per cell in sheet with a
+// non-empty Notes.
+func writeComments(f *excelize.File, name string, sheet SheetInfo) error {
+	for _, c := range sheet.GlobalData {
+		if c.Notes == nil || strings.TrimSpace(*c.Notes) == "" {
+			continue
+		}
+		ref := columnIndexToLetter(c.Column) + strconv.Itoa(int(c.Row))
+		if err := f.AddComment(name, excelize.Comment{Cell: ref, Author: "GoSheet", Text: *c.Notes}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeColWidths gives each column its widest cell's MinWidth, skipping
+// entirely if it's still the cell-model default (8).
+func writeColWidths(f *excelize.File, name string, sheet SheetInfo) error {
+	colWidths := make(map[int32]int16)
+	for _, c := range sheet.GlobalData {
+		if c.MinWidth > colWidths[c.Column] {
+			colWidths[c.Column] = c.MinWidth
+		}
+	}
+	for col, width := range colWidths {
+		if width <= 0 || width == 8 {
+			continue
+		}
+		letter := columnIndexToLetter(col)
+		if err := f.SetColWidth(name, letter, letter, float64(width)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
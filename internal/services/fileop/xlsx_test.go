@@ -0,0 +1,148 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package fileop
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// strp returns a pointer to s, for the *string fields cell.Cell stores its
+// raw/display text in.
+func strp(s string) *string { return &s }
+
+func TestSaveWorkbookAsXLSXRoundTrip(t *testing.T) {
+	typeString := "string"
+	typeNumber := "number"
+	note := "reviewed by finance"
+
+	sheet := SheetInfo{
+		Name: "Budget",
+		Rows: 2,
+		Cols: 2,
+		GlobalData: map[[2]int]*cell.Cell{
+			{1, 1}: {
+				Row: 1, Column: 1,
+				RawValue: strp("Total"), Display: strp("Total"), Type: &typeString,
+				Flags: cell.FlagBold | cell.FlagUnderline,
+				Color: utils.ColorOptions["White"], BgColor: utils.ColorOptions["Black"],
+			},
+			{1, 2}: {
+				Row: 1, Column: 2,
+				RawValue: strp("42"), Display: strp("42"), Type: &typeNumber,
+				Color: utils.ColorOptions["White"], BgColor: utils.ColorOptions["Black"],
+			},
+			{2, 1}: {
+				Row: 2, Column: 1,
+				RawValue: strp("=A1&\"!\""), Display: strp(""), Type: &typeString,
+				Notes:   &note,
+				Color:   utils.ColorOptions["White"],
+				BgColor: utils.ColorOptions["Black"],
+			},
+		},
+		Merges: []utils.Range{{TopRow: 1, LeftCol: 1, BottomRow: 1, RightCol: 2}},
+	}
+
+	path := filepath.Join(t.TempDir(), "roundtrip.xlsx")
+	if err := SaveWorkbookAsXLSX([]SheetInfo{sheet}, 0, path); err != nil {
+		t.Fatalf("SaveWorkbookAsXLSX error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("excelize.OpenFile error = %v", err)
+	}
+	defer f.Close()
+
+	if got := f.GetSheetList(); len(got) != 1 || got[0] != "Budget" {
+		t.Fatalf("GetSheetList() = %v, want [Budget]", got)
+	}
+
+	if got, _ := f.GetCellValue("Budget", "A1"); got != "Total" {
+		t.Errorf("A1 value = %q, want %q", got, "Total")
+	}
+	if got, _ := f.GetCellValue("Budget", "B1"); got != "42" {
+		t.Errorf("B1 value = %q, want %q", got, "42")
+	}
+	if got, _ := f.GetCellFormula("Budget", "A2"); got != `A1&"!"` {
+		t.Errorf("A2 formula = %q, want %q", got, `A1&"!"`)
+	}
+
+	styleID, err := f.GetCellStyle("Budget", "A1")
+	if err != nil {
+		t.Fatalf("GetCellStyle error = %v", err)
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		t.Fatalf("GetStyle error = %v", err)
+	}
+	if style.Font == nil || !style.Font.Bold {
+		t.Error("A1 style should carry Font.Bold")
+	}
+	if style.Font == nil || style.Font.Underline != "single" {
+		t.Errorf("A1 style Font.Underline = %q, want %q", style.Font.Underline, "single")
+	}
+
+	merges, err := f.GetMergeCells("Budget")
+	if err != nil {
+		t.Fatalf("GetMergeCells error = %v", err)
+	}
+	if len(merges) != 1 || merges[0].GetStartAxis() != "A1" || merges[0].GetEndAxis() != "B1" {
+		t.Errorf("GetMergeCells() = %v, want a single A1:B1 merge", merges)
+	}
+
+	comments, err := f.GetComments("Budget")
+	if err != nil {
+		t.Fatalf("GetComments error = %v", err)
+	}
+	if len(comments) != 1 || comments[0].Text != note {
+		t.Errorf("GetComments() = %v, want one comment with text %q", comments, note)
+	}
+}
+
+func TestOpenXLSXWorkbookRoundTripsSaveWorkbookAsXLSX(t *testing.T) {
+	typeNumber := "number"
+	sheet := SheetInfo{
+		Name: "Sheet1",
+		Rows: 1,
+		Cols: 1,
+		GlobalData: map[[2]int]*cell.Cell{
+			{1, 1}: {
+				Row: 1, Column: 1,
+				RawValue: strp("7"), Display: strp("7"), Type: &typeNumber,
+				Color: utils.ColorOptions["White"], BgColor: utils.ColorOptions["Black"],
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "readback.xlsx")
+	if err := SaveWorkbookAsXLSX([]SheetInfo{sheet}, 0, path); err != nil {
+		t.Fatalf("SaveWorkbookAsXLSX error = %v", err)
+	}
+
+	result, err := OpenXLSXWorkbook(path)
+	if err != nil {
+		t.Fatalf("OpenXLSXWorkbook error = %v", err)
+	}
+	if len(result.Sheets) != 1 || result.Sheets[0].Name != "Sheet1" {
+		t.Fatalf("OpenXLSXWorkbook sheets = %v, want one sheet named Sheet1", result.Sheets)
+	}
+	var cellData *cell.Cell
+	for _, c := range result.Sheets[0].Cells {
+		if c.Row == 1 && c.Column == 1 {
+			cellData = c
+			break
+		}
+	}
+	if cellData == nil || cellData.RawValue == nil || *cellData.RawValue != "7" {
+		t.Errorf("OpenXLSXWorkbook round-tripped cell = %+v, want RawValue \"7\"", cellData)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// zipxml.go holds the handful of ZIP/XML primitives shared by this
+// package's hand-rolled package-format writers: ods.go's .ods codec (no
+// vendored ODF library exists to pull in) and streaming.go's reduced-
+// fidelity xlsxStreamWriter (deliberately bypassing xlsx.go's excelize-
+// backed codec, which builds its whole in-memory *excelize.File before
+// writing - not a fit for streaming a huge sheet row by row).
+package fileop
+
+import "archive/zip"
+
+// xmlHeader is the standalone XML declaration every OOXML/ODF part starts
+// with.
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// writeZipEntry creates name in zw and writes content to it in one call.
+func writeZipEntry(zw *zip.Writer, name, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(content))
+	return err
+}
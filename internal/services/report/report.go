@@ -0,0 +1,257 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// Package report materializes a .gsheet workbook as a template: cells
+// holding {{field.subfield}} placeholders are substituted via reflection
+// over a caller-supplied data value, and a {{range items}} cell paired with
+// a {{end}} cell below it marks a block of rows that's cloned once per
+// element of the bound slice, with every row below the block shifted down
+// to make room - the same row-shift downstream rows get from insertRow,
+// just driven by a slice's length instead of a single row insertion.
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/services/fileop"
+)
+
+// rangeMarkerPattern matches a cell that opens a repeating block, e.g. a
+// cell whose whole raw value is "{{range Items}}".
+var rangeMarkerPattern = regexp.MustCompile(`^\{\{\s*range\s+([\w.]+)\s*\}\}$`)
+
+// endMarkerPattern matches a cell that closes the block opened by the most
+// recent rangeMarkerPattern match.
+var endMarkerPattern = regexp.MustCompile(`^\{\{\s*end\s*\}\}$`)
+
+// fieldTokenPattern matches a substitution placeholder inside normal cell
+// text, e.g. "{{user.name}}" or "Total: {{invoice.total}}".
+var fieldTokenPattern = regexp.MustCompile(`\{\{\s*([\w.]+)\s*\}\}`)
+
+// Render loads the .gsheet/.json/.xlsx template at templatePath, expands
+// every {{range field}}...{{end}} block against data and substitutes every
+// {{field.subfield}} token, then writes the result to out (.xlsx if out
+// ends in .xlsx, .gsheet otherwise).
+func Render(templatePath string, data interface{}, out string) error {
+	wb, err := fileop.OpenWorkbook(templatePath)
+	if err != nil {
+		return fmt.Errorf("open template %q: %w", templatePath, err)
+	}
+
+	sheets := make([]fileop.SheetInfo, 0, len(wb.Sheets))
+	for _, sheet := range wb.Sheets {
+		rendered, rows, err := renderSheet(sheet, data)
+		if err != nil {
+			return fmt.Errorf("render sheet %q: %w", sheet.Name, err)
+		}
+		sheets = append(sheets, fileop.SheetInfo{
+			Name:       sheet.Name,
+			Rows:       rows,
+			Cols:       sheet.Cols,
+			Color:      sheet.Color,
+			Group:      sheet.Group,
+			GlobalData: rendered,
+		})
+	}
+
+	format := fileop.FormatGSheet
+	if strings.HasSuffix(out, ".xlsx") {
+		format = fileop.FormatXLSX
+	}
+	return fileop.SaveWorkbookAs(sheets, wb.ActiveSheet, out, format)
+}
+
+// renderSheet expands sheet's range blocks against data and returns the
+// resulting cells keyed by their final (row, col), plus the final row count.
+func renderSheet(sheet fileop.SheetResult, data interface{}) (map[[2]int]*cell.Cell, int32, error) {
+	cellsByRow := make(map[int32][]*cell.Cell)
+	for _, c := range sheet.Cells {
+		cellsByRow[c.Row] = append(cellsByRow[c.Row], c)
+	}
+
+	result := make(map[[2]int]*cell.Cell)
+	outRow := int32(1)
+
+	row := int32(1)
+	for row <= sheet.Rows {
+		field, isRangeStart := rangeField(cellsByRow[row])
+		if !isRangeStart {
+			for _, c := range cellsByRow[row] {
+				placed := cloneCellForRow(c, outRow, data)
+				result[[2]int{int(placed.Row), int(placed.Column)}] = placed
+			}
+			outRow++
+			row++
+			continue
+		}
+
+		bodyStart := row + 1
+		endRow, err := findEndRow(cellsByRow, bodyStart, sheet.Rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("row %d: %w", row, err)
+		}
+		bodyEnd := endRow - 1
+
+		items, err := resolveSlice(data, field)
+		if err != nil {
+			return nil, 0, fmt.Errorf("row %d: {{range %s}}: %w", row, field, err)
+		}
+
+		for _, item := range items {
+			for r := bodyStart; r <= bodyEnd; r++ {
+				for _, c := range cellsByRow[r] {
+					placed := cloneCellForRow(c, outRow+(r-bodyStart), item)
+					result[[2]int{int(placed.Row), int(placed.Column)}] = placed
+				}
+			}
+			outRow += bodyEnd - bodyStart + 1
+		}
+
+		row = endRow + 1
+	}
+
+	return result, outRow - 1, nil
+}
+
+// rangeField reports whether row contains a {{range field}} marker cell and,
+// if so, which field it names.
+func rangeField(cellsInRow []*cell.Cell) (field string, ok bool) {
+	for _, c := range cellsInRow {
+		if c.RawValue == nil {
+			continue
+		}
+		if m := rangeMarkerPattern.FindStringSubmatch(strings.TrimSpace(cell.StripTviewTags(*c.RawValue))); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
+
+// findEndRow scans rows [from, maxRow] for the {{end}} marker closing the
+// block that started just before from.
+func findEndRow(cellsByRow map[int32][]*cell.Cell, from, maxRow int32) (int32, error) {
+	for r := from; r <= maxRow; r++ {
+		for _, c := range cellsByRow[r] {
+			if c.RawValue == nil {
+				continue
+			}
+			if endMarkerPattern.MatchString(strings.TrimSpace(cell.StripTviewTags(*c.RawValue))) {
+				return r, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no matching {{end}} found")
+}
+
+// cloneCellForRow copies c onto outRow, substituting {{field}} tokens in its
+// RawValue/Display against ctx while preserving every formatting flag,
+// color, and formula the template row carried.
+func cloneCellForRow(c *cell.Cell, outRow int32, ctx interface{}) *cell.Cell {
+	cloned := *c
+	cloned.Row = outRow
+	cloned.DependsOn = []*string{}
+	cloned.Dependents = []*string{}
+
+	if c.RawValue != nil {
+		raw := substituteTokens(*c.RawValue, ctx)
+		cloned.RawValue = &raw
+	}
+	if c.Display != nil {
+		display := substituteTokens(*c.Display, ctx)
+		cloned.Display = &display
+	}
+
+	return &cloned
+}
+
+// substituteTokens replaces every {{field.subfield}} placeholder in s with
+// its value resolved from ctx, leaving a token in place if it can't resolve
+// (e.g. a field name typo) so the gap is visible rather than silently blank.
+func substituteTokens(s string, ctx interface{}) string {
+	return fieldTokenPattern.ReplaceAllStringFunc(s, func(token string) string {
+		m := fieldTokenPattern.FindStringSubmatch(token)
+		if m == nil {
+			return token
+		}
+		if rangeMarkerPattern.MatchString(token) || endMarkerPattern.MatchString(token) {
+			return token
+		}
+		value, ok := resolveField(ctx, m[1])
+		if !ok {
+			return token
+		}
+		return value
+	})
+}
+
+// resolveField walks a dotted path (e.g. "user.name") over ctx via
+// reflection, following struct fields and map keys, and returns its value
+// formatted the same way fmt's %v would.
+func resolveField(ctx interface{}, path string) (string, bool) {
+	v, ok := resolvePath(ctx, path)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%v", v.Interface()), true
+}
+
+// resolveSlice walks path over data the same way resolveField does, but
+// expects the result to be a slice or array and returns its elements.
+func resolveSlice(data interface{}, path string) ([]interface{}, error) {
+	v, ok := resolvePath(data, path)
+	if !ok {
+		return nil, fmt.Errorf("field %q not found", path)
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("field %q is a %s, not a slice", path, v.Kind())
+	}
+	out := make([]interface{}, v.Len())
+	for i := range out {
+		out[i] = v.Index(i).Interface()
+	}
+	return out, nil
+}
+
+// resolvePath walks root's struct fields / map keys following path's
+// dot-separated segments, unwrapping pointers and interfaces as it goes.
+func resolvePath(root interface{}, path string) (reflect.Value, bool) {
+	if root == nil {
+		return reflect.Value{}, false
+	}
+
+	v := reflect.ValueOf(root)
+	for _, part := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			v = v.FieldByName(part)
+		case reflect.Map:
+			v = v.MapIndex(reflect.ValueOf(part))
+		default:
+			return reflect.Value{}, false
+		}
+		if !v.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// fileopbridge.go registers this package's active Session as fileop's
+// WorkbookProvider. fileop can't import table back (table already imports
+// fileop for OpenWorkbook/SaveWorkbook), so the save-as dialog's "save
+// whatever is open" path has to flow the other way: table hands fileop a
+// closure at init time, the same registration idiom
+// internal/utils/udffuncs.go uses to let formulas call into a UDF registry
+// owned by another package.
+
+package table
+
+import "gosheet/internal/services/fileop"
+
+func init() {
+	fileop.WorkbookProvider = activeWorkbookForSave
+}
+
+// activeWorkbookForSave adapts defaultSession's active workbook to
+// fileop.GetWorkbookForSave's []SheetInfo shape. Like the legacy
+// SaveTable/SaveTableAsJSON/SaveTableAsExcel wrappers in fileop, it only
+// ever exports the active sheet - this package's Session has no multi-sheet
+// enumeration yet, so that's the most it can honestly offer.
+func activeWorkbookForSave() ([]fileop.SheetInfo, int, bool) {
+	wb := defaultSession.ActiveWorkbook()
+	if wb == nil {
+		return nil, 0, false
+	}
+
+	sheet := wb.GetActiveSheet()
+
+	var maxRow, maxCol int32
+	for key := range sheet.Data {
+		row, col := int32(key[0]), int32(key[1])
+		if row > maxRow {
+			maxRow = row
+		}
+		if col > maxCol {
+			maxCol = col
+		}
+	}
+
+	info := fileop.SheetInfo{
+		Name:       sheet.Name,
+		Rows:       maxRow,
+		Cols:       maxCol,
+		GlobalData: sheet.Data,
+		Merges:     sheet.Merges,
+	}
+	return []fileop.SheetInfo{info}, 0, true
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// formularefs.go rewrites cell references inside formula text when a row or
+// column is inserted or deleted, and re-evaluates every formula cell, also
+// walking the cell.Cell Dependents chain. It is invoked by
+// every handler in indel.go right before they call RenderVisible.
+
+package table
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+
+	"github.com/Knetic/govaluate"
+)
+
+// formulaRefPattern matches an A1-style reference inside formula text, same
+// shape as validationCellRefRegex in datavalidation but extended with the
+// absolute ($) markers and sheet qualifier crossSheetRefPattern (in
+// evaluatefuncs) already handles for cross-sheet lookups:
+//
+//	groups: 1=quoted sheet, 2=bare sheet, 3/5=$ markers on the first ref's
+//	        col/row, 4/6=first ref's col letters/row digits, 7/9=$ markers
+//	        on a range end, 8/10=range end's col letters/row digits.
+var formulaRefPattern = regexp.MustCompile(
+	`(?:(?:'([^']+)'|([A-Za-z_][A-Za-z0-9_]*))!)?` +
+		`(\$?)([A-Z]+)(\$?)(\d+)` +
+		`(?::(\$?)([A-Z]+)(\$?)(\d+))?`,
+)
+
+// formulaAxis is which coordinate an insert/delete shifts.
+type formulaAxis int
+
+const (
+	formulaAxisRow formulaAxis = iota
+	formulaAxisCol
+)
+
+// formulaShiftMode is whether the edit inserted or deleted a line.
+type formulaShiftMode int
+
+const (
+	formulaShiftInsert formulaShiftMode = iota
+	formulaShiftDelete
+)
+
+// shiftCoord applies one axis's insert/delete shift to a single 1-indexed
+// coordinate. deleted reports whether coord sat exactly on a deleted line,
+// in which case the caller substitutes #REF!.
+func shiftCoord(coord int32, mode formulaShiftMode, k int32) (shifted int32, deleted bool) {
+	switch mode {
+	case formulaShiftInsert:
+		if coord >= k {
+			return coord + 1, false
+		}
+		return coord, false
+	default: // formulaShiftDelete
+		switch {
+		case coord < k:
+			return coord, false
+		case coord == k:
+			return coord, true
+		default:
+			return coord - 1, false
+		}
+	}
+}
+
+// rewriteFormulaRefs rewrites every reference in expr affected by inserting
+// or deleting row/col k. A reference whose axis coordinate equals k becomes
+// #REF! (insert never deletes, so that case only arises for formulaShiftDelete).
+// A range spanning the deleted line shrinks to what's left of it; a range
+// collapsed to nothing becomes #REF! too - the repo's existing hand-rolled
+// parsers (xlsx.go, evaluator.go) take the same "good enough, not a full
+// formula AST" approach rather than pulling in a real parser.
+func rewriteFormulaRefs(expr string, axis formulaAxis, mode formulaShiftMode, k int32) string {
+	return formulaRefPattern.ReplaceAllStringFunc(expr, func(token string) string {
+		m := formulaRefPattern.FindStringSubmatch(token)
+		if m == nil {
+			return token
+		}
+
+		sheetPrefix := ""
+		if m[1] != "" {
+			sheetPrefix = "'" + m[1] + "'!"
+		} else if m[2] != "" {
+			sheetPrefix = m[2] + "!"
+		}
+
+		startAbsCol, startCol, startAbsRow, startRow := m[3], m[4], m[5], m[6]
+		startC, startR := parseA1Coords(startCol, startRow)
+
+		if m[7] == "" && m[8] == "" {
+			// Single reference, no range end.
+			newR, newC, ref := shiftRef(startR, startC, axis, mode, k)
+			if ref {
+				return sheetPrefix + "#REF!"
+			}
+			return sheetPrefix + reassembleRef(startAbsCol, newC, startAbsRow, newR)
+		}
+
+		endAbsCol, endCol, endAbsRow, endRow := m[7], m[8], m[9], m[10]
+		endC, endR := parseA1Coords(endCol, endRow)
+
+		newStartR, newStartC, startDeleted := shiftRef(startR, startC, axis, mode, k)
+		newEndR, newEndC, endDeleted := shiftRef(endR, endC, axis, mode, k)
+		if endDeleted {
+			if axis == formulaAxisRow {
+				newEndR--
+			} else {
+				newEndC--
+			}
+		}
+
+		if startDeleted && endDeleted {
+			return sheetPrefix + "#REF!"
+		}
+		if axis == formulaAxisRow {
+			if newStartR > newEndR {
+				return sheetPrefix + "#REF!"
+			}
+		} else if newStartC > newEndC {
+			return sheetPrefix + "#REF!"
+		}
+
+		return sheetPrefix +
+			reassembleRef(startAbsCol, newStartC, startAbsRow, newStartR) + ":" +
+			reassembleRef(endAbsCol, newEndC, endAbsRow, newEndR)
+	})
+}
+
+// shiftRef applies shiftCoord to whichever of (row, col) axis names.
+func shiftRef(row, col int32, axis formulaAxis, mode formulaShiftMode, k int32) (newRow, newCol int32, deleted bool) {
+	if axis == formulaAxisRow {
+		newRow, deleted = shiftCoord(row, mode, k)
+		return newRow, col, deleted
+	}
+	newCol, deleted = shiftCoord(col, mode, k)
+	return row, newCol, deleted
+}
+
+// parseA1Coords converts A1-style column letters and row digits into the
+// app's 1-indexed (col, row) pair. Errors can't occur here since both
+// pieces already matched formulaRefPattern's character classes.
+func parseA1Coords(colLetters, rowDigits string) (col, row int32) {
+	for _, ch := range colLetters {
+		col = col*26 + int32(ch-'A') + 1
+	}
+	r, _ := strconv.Atoi(rowDigits)
+	return col, int32(r)
+}
+
+// reassembleRef rebuilds an A1 reference from a shifted (row, col) pair,
+// preserving whichever $ absolute markers the original token had.
+func reassembleRef(absCol string, col int32, absRow string, row int32) string {
+	return absCol + utils.ColumnName(col) + absRow + strconv.Itoa(int(row))
+}
+
+// sheetRangeResolver resolves a same-sheet "A1:B3" range against data, the
+// same Values/Rows/Cols shape GovalFuncsWithRanges expects (see
+// utils/rangeargs.go) - used here to recalculate a formula after its
+// references have just been rewritten.
+func sheetRangeResolver(data map[[2]int]*cell.Cell) utils.RangeResolver {
+	return func(rangeRef string) (utils.CellRange, error) {
+		parts := strings.SplitN(rangeRef, ":", 2)
+		if len(parts) != 2 {
+			return utils.CellRange{}, fmt.Errorf("invalid range %q", rangeRef)
+		}
+		c1, r1, err := parseA1Ref(parts[0])
+		if err != nil {
+			return utils.CellRange{}, err
+		}
+		c2, r2, err := parseA1Ref(parts[1])
+		if err != nil {
+			return utils.CellRange{}, err
+		}
+		if r1 > r2 {
+			r1, r2 = r2, r1
+		}
+		if c1 > c2 {
+			c1, c2 = c2, c1
+		}
+
+		rows, cols := int(r2-r1)+1, int(c2-c1)+1
+		values := make([]any, 0, rows*cols)
+		for r := r1; r <= r2; r++ {
+			for c := c1; c <= c2; c++ {
+				key := [2]int{int(r), int(c)}
+				if cellData, ok := data[key]; ok && cellData.Display != nil {
+					values = append(values, *cellData.Display)
+				} else {
+					values = append(values, "")
+				}
+			}
+		}
+		return utils.CellRange{Values: values, Rows: rows, Cols: cols}, nil
+	}
+}
+
+// parseA1Ref parses a bare "B12" reference (no sheet qualifier; $ markers
+// are accepted and ignored) into its 1-indexed (col, row) pair.
+func parseA1Ref(ref string) (col, row int32, err error) {
+	m := validationRefLikePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(ref)))
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	col, row = parseA1Coords(m[1], m[2])
+	return col, row, nil
+}
+
+var validationRefLikePattern = regexp.MustCompile(`^\$?([A-Z]+)\$?(\d+)$`)
+
+// recalcFormulaCell re-evaluates cellData's RawValue against data and
+// updates its Display, the same ExpandRangeRefs + GovalFuncsWithRanges path
+// rangeargs.go documents for same-sheet formula evaluation. cellData.Color
+// and flags are left untouched - only Display changes.
+func recalcFormulaCell(cellData *cell.Cell, data map[[2]int]*cell.Cell) {
+	if cellData == nil || cellData.RawValue == nil || !cellData.IsFormula() {
+		return
+	}
+
+	expr := utils.ExpandRangeRefs(strings.TrimPrefix(*cellData.RawValue, "="))
+	functions := utils.GovalFuncsWithRanges(sheetRangeResolver(data))
+
+	evalExpr, err := govaluate.NewEvaluableExpressionWithFunctions(expr, functions)
+	if err != nil {
+		display := "#ERROR!"
+		cellData.Display = &display
+		return
+	}
+
+	params := make(map[string]interface{})
+	for _, name := range evalExpr.Vars() {
+		if col, row, err := parseA1Ref(name); err == nil {
+			params[name] = 0.0
+			if ref, ok := data[[2]int{int(row), int(col)}]; ok && ref.Display != nil && *ref.Display != "" {
+				params[name] = *ref.Display
+				if f, err := strconv.ParseFloat(*ref.Display, 64); err == nil {
+					params[name] = f
+				}
+			}
+		}
+	}
+	result, err := evalExpr.Evaluate(params)
+	if err != nil {
+		display := "#ERROR!"
+		cellData.Display = &display
+		return
+	}
+
+	display := fmt.Sprintf("%v", result)
+	cellData.Display = &display
+}
+
+// recalculateDependents walks seed's Dependents chain (cell.Cell's
+// DependsOn/Dependents graph, the same one evaluatefuncs/dependency.go
+// builds TopoOrder from) and recalculates every formula cell it reaches.
+// Nothing in this codebase populates Dependents for same-sheet formulas
+// yet - that wiring lives wherever a normal edit triggers recalculation,
+// which this pruned tree doesn't have - so today this is a no-op on most
+// sheets. It's written to walk the graph correctly regardless, so that once
+// Dependents is populated elsewhere a structural edit still fans out
+// recalculation instead of leaving stale Display values behind.
+func recalculateDependents(seed *cell.Cell, data map[[2]int]*cell.Cell) {
+	visited := make(map[string]bool)
+
+	var walk func(c *cell.Cell)
+	walk = func(c *cell.Cell) {
+		for _, depRef := range c.Dependents {
+			if depRef == nil || visited[*depRef] {
+				continue
+			}
+			visited[*depRef] = true
+
+			ref := *depRef
+			if idx := strings.Index(ref, "!"); idx != -1 {
+				ref = ref[idx+1:]
+			}
+			col, row, err := parseA1Ref(ref)
+			if err != nil {
+				continue
+			}
+
+			dep, ok := data[[2]int{int(row), int(col)}]
+			if !ok {
+				continue
+			}
+			recalcFormulaCell(dep, data)
+			walk(dep)
+		}
+	}
+	walk(seed)
+}
+
+// rewriteAndRecalcFormulas rewrites every formula cell in data affected by
+// inserting or deleting row/col k on axis, then re-evaluates every formula
+// cell and walks its Dependents chain. Called by eliminateCol/eliminateRow/insertCol/
+// insertRow right before RenderVisible.
+func rewriteAndRecalcFormulas(data map[[2]int]*cell.Cell, axis formulaAxis, mode formulaShiftMode, k int32) {
+	for _, cellData := range data {
+		if cellData.RawValue == nil || !cellData.IsFormula() {
+			continue
+		}
+
+		rewritten := "=" + rewriteFormulaRefs(strings.TrimPrefix(*cellData.RawValue, "="), axis, mode, k)
+		if rewritten != *cellData.RawValue {
+			cellData.RawValue = &rewritten
+		}
+
+		recalcFormulaCell(cellData, data)
+		recalculateDependents(cellData, data)
+	}
+}
@@ -9,8 +9,8 @@ package table
 
 import (
 	"fmt"
-	"gosheet/internal/services/ui"
 	"gosheet/internal/services/cell"
+	"gosheet/internal/services/ui"
 	"gosheet/internal/utils"
 
 	"github.com/rivo/tview"
@@ -21,7 +21,7 @@ var totalRows, totalCols int32
 // DELETE FUNCTIONS
 func eliminateRowCol(app *tview.Application, table *tview.Table) {
 	activeViewport := GetActiveViewport()
-	
+
 	if activeViewport == nil {
 		return
 	}
@@ -41,7 +41,7 @@ func eliminateRowCol(app *tview.Application, table *tview.Table) {
 func eliminateCol(app *tview.Application, table *tview.Table, col int32) {
 	activeData := GetActiveSheetData()
 	activeViewport := GetActiveViewport()
-	
+
 	if activeData == nil || activeViewport == nil {
 		return
 	}
@@ -53,12 +53,12 @@ func eliminateCol(app *tview.Application, table *tview.Table, col int32) {
 			if buttonLabel == "Yes" {
 				activeData := GetActiveSheetData()
 				activeViewport := GetActiveViewport()
-				
+
 				if activeData == nil || activeViewport == nil {
 					app.SetRoot(table, true).SetFocus(table)
 					return
 				}
-				
+
 				newData := make(map[[2]int]*cell.Cell)
 				for key, cellData := range activeData {
 					r, c := key[0], key[1]
@@ -70,21 +70,26 @@ func eliminateCol(app *tview.Application, table *tview.Table, col int32) {
 						newData[newKey] = cellData
 					}
 				}
-				
+
+				rewriteAndRecalcFormulas(newData, formulaAxisCol, formulaShiftDelete, col)
+
 				// CRITICAL: Update the actual sheet's data reference
-				sheet := globalWorkbook.GetActiveSheet()
+				sheet := defaultSession.ActiveWorkbook().GetActiveSheet()
+				var merges []utils.Range
 				if sheet != nil {
 					sheet.Data = newData
+					shiftSheetMerges(sheet, formulaAxisCol, formulaShiftDelete, col)
+					merges = sheet.Merges
 				}
-				
-				RenderVisible(table, activeViewport, newData)
+
+				RenderVisible(table, activeViewport, newData, merges)
 				app.SetRoot(table, true).SetFocus(table)
 			} else {
 				app.SetRoot(table, true).SetFocus(table)
 			}
 		})
 	modal.SetBorder(true).SetTitle(" Confirmation ").SetTitleAlign(tview.AlignCenter)
-	app.SetRoot(modal, true).SetFocus(modal)	
+	app.SetRoot(modal, true).SetFocus(modal)
 }
 
 func eliminateRow(app *tview.Application, table *tview.Table, row int32) {
@@ -95,12 +100,12 @@ func eliminateRow(app *tview.Application, table *tview.Table, row int32) {
 			if buttonLabel == "Yes" {
 				activeData := GetActiveSheetData()
 				activeViewport := GetActiveViewport()
-				
+
 				if activeData == nil || activeViewport == nil {
 					app.SetRoot(table, true).SetFocus(table)
 					return
 				}
-				
+
 				newData := make(map[[2]int]*cell.Cell)
 				for key, cellData := range activeData {
 					r, c := key[0], key[1]
@@ -112,28 +117,33 @@ func eliminateRow(app *tview.Application, table *tview.Table, row int32) {
 						newData[newKey] = cellData
 					}
 				}
-				
+
+				rewriteAndRecalcFormulas(newData, formulaAxisRow, formulaShiftDelete, row)
+
 				// CRITICAL: Update the actual sheet's data reference
-				sheet := globalWorkbook.GetActiveSheet()
+				sheet := defaultSession.ActiveWorkbook().GetActiveSheet()
+				var merges []utils.Range
 				if sheet != nil {
 					sheet.Data = newData
+					shiftSheetMerges(sheet, formulaAxisRow, formulaShiftDelete, row)
+					merges = sheet.Merges
 				}
-				
-				RenderVisible(table, activeViewport, newData)
+
+				RenderVisible(table, activeViewport, newData, merges)
 				app.SetRoot(table, true).SetFocus(table)
 			} else {
 				app.SetRoot(table, true).SetFocus(table)
 			}
 		})
 	modal.SetBorder(true).SetTitle(" Confirmation ").SetTitleAlign(tview.AlignCenter)
-	app.SetRoot(modal, true).SetFocus(modal)	
+	app.SetRoot(modal, true).SetFocus(modal)
 }
 
 // INSERT FUNCTIONS
 func insertRowCol(app *tview.Application, table *tview.Table) {
 	activeData := GetActiveSheetData()
 	activeViewport := GetActiveViewport()
-	
+
 	if activeData == nil || activeViewport == nil {
 		return
 	}
@@ -158,12 +168,12 @@ func insertCol(app *tview.Application, table *tview.Table, col int32) {
 			if buttonLabel == "Yes" {
 				activeData := GetActiveSheetData()
 				activeViewport := GetActiveViewport()
-				
+
 				if activeData == nil || activeViewport == nil {
 					app.SetRoot(table, true).SetFocus(table)
 					return
 				}
-				
+
 				newData := make(map[[2]int]*cell.Cell)
 				for key, cellData := range activeData {
 					r, c := key[0], key[1]
@@ -175,21 +185,26 @@ func insertCol(app *tview.Application, table *tview.Table, col int32) {
 						newData[newKey] = cellData
 					}
 				}
-				
+
+				rewriteAndRecalcFormulas(newData, formulaAxisCol, formulaShiftInsert, col)
+
 				// CRITICAL: Update the actual sheet's data reference
-				sheet := globalWorkbook.GetActiveSheet()
+				sheet := defaultSession.ActiveWorkbook().GetActiveSheet()
+				var merges []utils.Range
 				if sheet != nil {
 					sheet.Data = newData
+					shiftSheetMerges(sheet, formulaAxisCol, formulaShiftInsert, col)
+					merges = sheet.Merges
 				}
-				
-				RenderVisible(table, activeViewport, newData)
+
+				RenderVisible(table, activeViewport, newData, merges)
 				app.SetRoot(table, true).SetFocus(table)
 			} else {
 				app.SetRoot(table, true).SetFocus(table)
 			}
 		})
 	modal.SetBorder(true).SetTitle(" Insert Column ").SetTitleAlign(tview.AlignCenter)
-	app.SetRoot(modal, true).SetFocus(modal)	
+	app.SetRoot(modal, true).SetFocus(modal)
 }
 
 func insertRow(app *tview.Application, table *tview.Table, row int32) {
@@ -200,12 +215,12 @@ func insertRow(app *tview.Application, table *tview.Table, row int32) {
 			if buttonLabel == "Yes" {
 				activeData := GetActiveSheetData()
 				activeViewport := GetActiveViewport()
-				
+
 				if activeData == nil || activeViewport == nil {
 					app.SetRoot(table, true).SetFocus(table)
 					return
 				}
-				
+
 				newData := make(map[[2]int]*cell.Cell)
 				for key, cellData := range activeData {
 					r, c := key[0], key[1]
@@ -217,19 +232,24 @@ func insertRow(app *tview.Application, table *tview.Table, row int32) {
 						newData[newKey] = cellData
 					}
 				}
-				
+
+				rewriteAndRecalcFormulas(newData, formulaAxisRow, formulaShiftInsert, row)
+
 				// CRITICAL: Update the actual sheet's data reference
-				sheet := globalWorkbook.GetActiveSheet()
+				sheet := defaultSession.ActiveWorkbook().GetActiveSheet()
+				var merges []utils.Range
 				if sheet != nil {
 					sheet.Data = newData
+					shiftSheetMerges(sheet, formulaAxisRow, formulaShiftInsert, row)
+					merges = sheet.Merges
 				}
-				
-				RenderVisible(table, activeViewport, newData)
+
+				RenderVisible(table, activeViewport, newData, merges)
 				app.SetRoot(table, true).SetFocus(table)
 			} else {
 				app.SetRoot(table, true).SetFocus(table)
 			}
 		})
 	modal.SetBorder(true).SetTitle(" Insert Row ").SetTitleAlign(tview.AlignCenter)
-	app.SetRoot(modal, true).SetFocus(modal)	
+	app.SetRoot(modal, true).SetFocus(modal)
 }
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// merges.go adds merged-cell regions to the sheet model: Sheet.Merges holds
+// every merged range, Merge/Unmerge maintain it with overlap validation, and
+// shiftSheetMerges keeps it in sync with insertRow/insertCol/eliminateRow/
+// eliminateCol the same way rewriteAndRecalcFormulas keeps formula text in
+// sync - both are called from indel.go right before RenderVisible.
+
+package table
+
+import (
+	"fmt"
+
+	"gosheet/internal/utils"
+)
+
+// Merge adds r to sheet's merged regions. It fails if r overlaps an existing
+// merge; unlike spreadsheet apps that silently unmerge the conflicting
+// region first, this repo's validation-heavy style (see dataValidationUI.go)
+// prefers rejecting the ambiguous case outright.
+func (s *Sheet) Merge(r utils.Range) error {
+	for _, existing := range s.Merges {
+		if r.Overlaps(existing) {
+			return fmt.Errorf("range overlaps existing merge %v", existing)
+		}
+	}
+	s.Merges = append(s.Merges, r)
+	return nil
+}
+
+// Unmerge removes the merge exactly matching r, reporting whether one was
+// found.
+func (s *Sheet) Unmerge(r utils.Range) bool {
+	for i, existing := range s.Merges {
+		if existing == r {
+			s.Merges = append(s.Merges[:i], s.Merges[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// shiftSheetMerges applies the same row/col insert-or-delete shift that
+// rewriteFormulaRefs applies to formula text, but to sheet.Merges: a delete
+// landing inside a merge (its edge rows/columns included) shrinks it, a delete removing its entire
+// span drops it, and an insert or a delete outside the span just moves its
+// corners via shiftCoord.
+func shiftSheetMerges(sheet *Sheet, axis formulaAxis, mode formulaShiftMode, k int32) {
+	if sheet == nil {
+		return
+	}
+
+	shifted := make([]utils.Range, 0, len(sheet.Merges))
+	for _, m := range sheet.Merges {
+		newRange, ok := shiftMergeRange(m, axis, mode, k)
+		if ok {
+			shifted = append(shifted, newRange)
+		}
+	}
+	sheet.Merges = shifted
+}
+
+// shiftMergeRange shifts a single merge range, reporting ok=false if the
+// edit eliminated it entirely (a delete whose single deleted line was the
+// merge's only row or column).
+func shiftMergeRange(m utils.Range, axis formulaAxis, mode formulaShiftMode, k int32) (utils.Range, bool) {
+	top, left, bottom, right := m.TopRow, m.LeftCol, m.BottomRow, m.RightCol
+
+	switch axis {
+	case formulaAxisRow:
+		if mode == formulaShiftDelete && k >= top && k <= bottom {
+			if bottom == top {
+				return utils.Range{}, false
+			}
+			bottom--
+		} else {
+			top, _ = shiftCoord(top, mode, k)
+			bottom, _ = shiftCoord(bottom, mode, k)
+		}
+	case formulaAxisCol:
+		if mode == formulaShiftDelete && k >= left && k <= right {
+			if right == left {
+				return utils.Range{}, false
+			}
+			right--
+		} else {
+			left, _ = shiftCoord(left, mode, k)
+			right, _ = shiftCoord(right, mode, k)
+		}
+	}
+
+	return utils.Range{TopRow: top, LeftCol: left, BottomRow: bottom, RightCol: right}, true
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// session.go replaces the old single globalWorkbook with a Session that can
+// hold several open workbooks at once, modeled on the LSP-style session
+// pattern: a viewMu-guarded slice of *Workbook plus a viewMap keyed by
+// filename, with one of them marked active at a time. CreateTable/OpenTable/
+// NewTable build a workbook through a Session instead of reassigning a
+// package-level variable, and BuildTabBar/NextWorkbook/PrevWorkbook let the
+// UI cycle the active one (wired to Ctrl+Tab in main.go).
+
+package table
+
+import (
+	"fmt"
+	"sync"
+
+	"gosheet/internal/services/fileop"
+
+	"github.com/rivo/tview"
+)
+
+// Session owns every workbook currently open in the application and tracks
+// which one is focused. It is safe for concurrent use.
+type Session struct {
+	viewMu  sync.RWMutex
+	views   []*Workbook
+	viewMap map[string]*Workbook // keyed by Workbook.CurrentFile; unsaved workbooks aren't indexed here
+	active  *Workbook
+}
+
+// NewSession creates an empty Session with no open workbooks.
+func NewSession() *Session {
+	return &Session{
+		viewMap: make(map[string]*Workbook),
+	}
+}
+
+// defaultSession is the application-wide Session. It replaces the old
+// package-level globalWorkbook variable; GetSession exposes it to callers
+// (main.go) that used to read the global directly.
+var defaultSession = NewSession()
+
+// GetSession returns the application-wide Session.
+func GetSession() *Session {
+	return defaultSession
+}
+
+// OpenWorkbook opens filename into a new workbook and adds it to the
+// session, becoming the active one. Reopening a filename that's already
+// open switches to the existing workbook instead of loading it twice.
+func (s *Session) OpenWorkbook(filename string) (*Workbook, error) {
+	s.viewMu.Lock()
+	defer s.viewMu.Unlock()
+
+	if wb, ok := s.viewMap[filename]; ok {
+		s.active = wb
+		return wb, nil
+	}
+
+	cellSlice, err := fileop.OpenTable(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open workbook %q: %w", filename, err)
+	}
+
+	wb := NewWorkbook()
+	wb.CurrentFile = filename
+	wb.HasChanges = false
+
+	sheet := wb.GetActiveSheet()
+	for _, c := range cellSlice {
+		key := [2]int{int(c.Row), int(c.Column)}
+		sheet.Data[key] = c
+	}
+
+	s.views = append(s.views, wb)
+	s.viewMap[filename] = wb
+	s.active = wb
+	return wb, nil
+}
+
+// NewWorkbook creates a blank, untitled workbook, adds it to the session,
+// and makes it the active one.
+func (s *Session) NewWorkbook() *Workbook {
+	s.viewMu.Lock()
+	defer s.viewMu.Unlock()
+
+	wb := NewWorkbook()
+	wb.CurrentFile = ""
+	wb.HasChanges = false
+
+	s.views = append(s.views, wb)
+	s.active = wb
+	return wb
+}
+
+// CloseWorkbook removes wb from the session. If wb was the active workbook,
+// the workbook immediately before it becomes active (or, if wb was first,
+// the workbook that is now first, or none if the session is now empty).
+func (s *Session) CloseWorkbook(wb *Workbook) {
+	s.viewMu.Lock()
+	defer s.viewMu.Unlock()
+
+	idx := -1
+	for i, v := range s.views {
+		if v == wb {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return
+	}
+
+	s.views = append(s.views[:idx], s.views[idx+1:]...)
+	if wb.CurrentFile != "" {
+		delete(s.viewMap, wb.CurrentFile)
+	}
+
+	if s.active != wb {
+		return
+	}
+	switch {
+	case len(s.views) == 0:
+		s.active = nil
+	case idx > 0:
+		s.active = s.views[idx-1]
+	default:
+		s.active = s.views[0]
+	}
+}
+
+// ActiveWorkbook returns the session's currently focused workbook, or nil if
+// nothing is open.
+func (s *Session) ActiveWorkbook() *Workbook {
+	s.viewMu.RLock()
+	defer s.viewMu.RUnlock()
+	return s.active
+}
+
+// SetActive makes wb the focused workbook, if it belongs to this session.
+func (s *Session) SetActive(wb *Workbook) {
+	s.viewMu.Lock()
+	defer s.viewMu.Unlock()
+	for _, v := range s.views {
+		if v == wb {
+			s.active = wb
+			return
+		}
+	}
+}
+
+// Workbooks returns every workbook currently open, in tab order.
+func (s *Session) Workbooks() []*Workbook {
+	s.viewMu.RLock()
+	defer s.viewMu.RUnlock()
+	out := make([]*Workbook, len(s.views))
+	copy(out, s.views)
+	return out
+}
+
+// NextWorkbook switches the session to the workbook after the active one
+// (wrapping around), the action bound to Ctrl+Tab, and returns it.
+func (s *Session) NextWorkbook() *Workbook {
+	s.viewMu.Lock()
+	defer s.viewMu.Unlock()
+	return s.cycle(1)
+}
+
+// PrevWorkbook switches the session to the workbook before the active one
+// (wrapping around) and returns it.
+func (s *Session) PrevWorkbook() *Workbook {
+	s.viewMu.Lock()
+	defer s.viewMu.Unlock()
+	return s.cycle(-1)
+}
+
+// cycle moves the active workbook by delta positions, wrapping around.
+// Callers must hold viewMu.
+func (s *Session) cycle(delta int) *Workbook {
+	if len(s.views) == 0 {
+		return nil
+	}
+	if s.active == nil {
+		s.active = s.views[0]
+		return s.active
+	}
+
+	idx := 0
+	for i, v := range s.views {
+		if v == s.active {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + delta + len(s.views)) % len(s.views)
+	s.active = s.views[idx]
+	return s.active
+}
+
+// BuildTabBar renders a single-line strip naming every open workbook, with
+// the active one highlighted, so the user can see what Ctrl+Tab is cycling
+// through. It does not itself handle input; main.go's SetInputCapture drives
+// Session.NextWorkbook/PrevWorkbook and re-renders the bar on every switch.
+func BuildTabBar(s *Session) *tview.TextView {
+	tabBar := tview.NewTextView().
+		SetDynamicColors(true).
+		SetRegions(false)
+	RefreshTabBar(tabBar, s)
+	return tabBar
+}
+
+// RefreshTabBar redraws tabBar to reflect s's current workbooks and active
+// selection. Call it after OpenWorkbook/NewWorkbook/CloseWorkbook/
+// NextWorkbook/PrevWorkbook change which workbook is focused.
+func RefreshTabBar(tabBar *tview.TextView, s *Session) {
+	active := s.ActiveWorkbook()
+
+	var text string
+	for i, wb := range s.Workbooks() {
+		name := wb.CurrentFile
+		if name == "" {
+			name = "Untitled"
+		} else {
+			name = tabBarBaseName(name)
+		}
+		if wb.HasChanges {
+			name += "*"
+		}
+
+		if wb == active {
+			text += fmt.Sprintf(" [black:white] %s [-:-] ", name)
+		} else {
+			text += fmt.Sprintf(" %s ", name)
+		}
+		if i < len(s.Workbooks())-1 {
+			text += "|"
+		}
+	}
+	tabBar.SetText(text)
+}
+
+// tabBarBaseName trims a workbook's path down to its filename for the tab
+// bar, the same way updateTableTitle already shows filepath.Base(filename)
+// in the window title.
+func tabBarBaseName(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' || path[i] == '\\' {
+			return path[i+1:]
+		}
+	}
+	return path
+}
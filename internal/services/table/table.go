@@ -12,87 +12,70 @@ import (
 	"path/filepath"
 
 	"gosheet/internal/services/cell"
-	"gosheet/internal/services/fileop"
 	"gosheet/internal/utils"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-
-var globalWorkbook *Workbook
-
 // Creates an empty tview table
 func CreateTable(title string) *tview.Table {
-    table := tview.NewTable().
-        SetBorders(false).
-        SetFixed(1, 1).
-        SetSelectable(true, true)
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 1).
+		SetSelectable(true, true)
 	table.SetBorder(true)
 
-	SetCurrentFilename(table, title)	
+	SetCurrentFilename(table, title)
 	updateTableTitle(table)
 
-    return table
+	return table
 }
 
-// OpenTable loads a table from a file and returns a tview.Table
-func OpenTable(app *tview.Application, filename string) *tview.Table {
-	cellSlice, err := fileop.OpenTable(filename)
-
+// OpenTable loads a table from a file into session, making it the active
+// workbook, and returns a tview.Table rendering it.
+func OpenTable(session *Session, app *tview.Application, filename string) *tview.Table {
+	wb, err := session.OpenWorkbook(filename)
 	if err != nil {
 		return nil
 	}
 
-	// Initialize workbook
-	globalWorkbook = NewWorkbook()
-	globalWorkbook.CurrentFile = filename
-	globalWorkbook.HasChanges = false
-
-	// Get the first sheet
-	sheet := globalWorkbook.GetActiveSheet()
-	
-	// Populate sheet data
-	for _, c := range cellSlice {
-		key := [2]int{int(c.Row), int(c.Column)}
-		sheet.Data[key] = c
-	}
+	sheet := wb.GetActiveSheet()
 
 	table := CreateTable(filename)
 
-	RenderVisible(table, sheet.Viewport, sheet.Data)
+	RenderVisible(table, sheet.Viewport, sheet.Data, sheet.Merges)
 	table = SelectInTable(app, table, sheet.Viewport, sheet.Data)
 
 	return table
 }
 
-// Makes a new table/Workbook
-func NewTable(app *tview.Application) *tview.Table {
-	globalWorkbook = NewWorkbook()
-	globalWorkbook.CurrentFile = ""
-	globalWorkbook.HasChanges = false
-	
+// NewTable creates a blank workbook in session, making it the active one,
+// and returns a tview.Table rendering it.
+func NewTable(session *Session, app *tview.Application) *tview.Table {
+	wb := session.NewWorkbook()
+
 	table := CreateTable("Untitled")
-	
-	sheet := globalWorkbook.GetActiveSheet()
-	RenderVisible(table, sheet.Viewport, sheet.Data)
+
+	sheet := wb.GetActiveSheet()
+	RenderVisible(table, sheet.Viewport, sheet.Data, sheet.Merges)
 	table = SelectInTable(app, table, sheet.Viewport, sheet.Data)
-	
+
 	updateTableTitle(table)
-	
+
 	return table
 }
 
 // Cleanup unused cells from memory
 func CleanupDistantCells(data map[[2]int]*cell.Cell, vp *utils.Viewport, keepDistance int32) {
-	minRow := max(1, vp.TopRow - keepDistance)
-    maxRow := vp.TopRow + vp.ViewRows + keepDistance
-    minCol := max(1, vp.LeftCol - keepDistance)
-    maxCol := vp.LeftCol + vp.ViewCols + keepDistance
+	minRow := max(1, vp.TopRow-keepDistance)
+	maxRow := vp.TopRow + vp.ViewRows + keepDistance
+	minCol := max(1, vp.LeftCol-keepDistance)
+	maxCol := vp.LeftCol + vp.ViewCols + keepDistance
 
 	for key, cellData := range data {
 		row, col := int32(key[0]), int32(key[1])
-		
+
 		if row < minRow || row > maxRow || col < minCol || col > maxCol {
 			if isEmptyCell(cellData) {
 				delete(data, key)
@@ -119,17 +102,20 @@ func isEmptyCell(c *cell.Cell) bool {
 		return false
 	}
 	if c.Color[0] != 255 || c.Color[1] != 255 || c.Color[2] != 255 {
-        return false
-    }
-    if c.BgColor[0] != 0 || c.BgColor[1] != 0 || c.BgColor[2] != 0 {
-        return false
-    }	
-	
+		return false
+	}
+	if c.BgColor[0] != 0 || c.BgColor[1] != 0 || c.BgColor[2] != 0 {
+		return false
+	}
+
 	return true
 }
 
-// Render Table Viewport for optimised memory usage
-func RenderVisible(table *tview.Table, vp *utils.Viewport, data map[[2]int]*cell.Cell) {
+// Render Table Viewport for optimised memory usage. merges gives the
+// anchor cell (its top-left corner) a SetRowSpan/SetColSpan covering the
+// rest of the range, and the cells it covers are skipped entirely so tview
+// doesn't draw a second box on top of the span.
+func RenderVisible(table *tview.Table, vp *utils.Viewport, data map[[2]int]*cell.Cell, merges []utils.Range) {
 	table.Clear()
 
 	table.SetCell(0, 0, tview.NewTableCell("").SetAlign(tview.AlignCenter))
@@ -144,7 +130,7 @@ func RenderVisible(table *tview.Table, vp *utils.Viewport, data map[[2]int]*cell
 		label := fmt.Sprintf("%d", r)
 		rowCell := cell.NewCell(int32(r), 0, label)
 		rowCell.MinWidth = 2
-		rowCell.MaxWidth = int16(len(label))+2
+		rowCell.MaxWidth = int16(len(label)) + 2
 		table.SetCell(int(r-vp.TopRow+1), 0, rowCell.ToTViewCell())
 	}
 
@@ -153,7 +139,12 @@ func RenderVisible(table *tview.Table, vp *utils.Viewport, data map[[2]int]*cell
 			key := [2]int{int(r), int(c)}
 			visualRow := r - vp.TopRow + 1
 			visualCol := c - vp.LeftCol + 1
-			
+
+			merge, inMerge := mergeAt(merges, int32(r), int32(c))
+			if inMerge && !(merge.TopRow == int32(r) && merge.LeftCol == int32(c)) {
+				continue
+			}
+
 			var tvCell *tview.TableCell
 			if cellData, exists := data[key]; exists {
 				tvCell = cellData.ToTViewCell()
@@ -161,54 +152,70 @@ func RenderVisible(table *tview.Table, vp *utils.Viewport, data map[[2]int]*cell
 				tvCell = tview.NewTableCell("").
 					SetAlign(tview.AlignLeft).
 					SetTextColor(tcell.NewRGBColor(255, 255, 255)).
-					SetBackgroundColor(tcell.NewRGBColor(0, 0, 0))	
+					SetBackgroundColor(tcell.NewRGBColor(0, 0, 0))
+			}
+
+			if inMerge {
+				tvCell.SetRowSpan(int(merge.RowSpan()))
+				tvCell.SetColSpan(int(merge.ColSpan()))
 			}
-			
+
 			table.SetCell(int(visualRow), int(visualCol), tvCell)
 		}
 	}
-	
+
 	CleanupDistantCells(data, vp, 100)
 }
 
+// mergeAt returns the merge range covering (row, col), if any.
+func mergeAt(merges []utils.Range, row, col int32) (utils.Range, bool) {
+	for _, m := range merges {
+		if m.Contains(row, col) {
+			return m, true
+		}
+	}
+	return utils.Range{}, false
+}
+
 // MarkAsModified marks the file as modified
 func MarkAsModified(table *tview.Table) {
-	if globalWorkbook != nil {
-		globalWorkbook.HasChanges = true
+	if wb := defaultSession.ActiveWorkbook(); wb != nil {
+		wb.HasChanges = true
 		updateTableTitle(table)
 	}
 }
 
 // MarkAsSaved marks the workbook as saved
 func MarkAsSaved(table *tview.Table) {
-	if globalWorkbook != nil {
-		globalWorkbook.HasChanges = false
+	if wb := defaultSession.ActiveWorkbook(); wb != nil {
+		wb.HasChanges = false
 		updateTableTitle(table)
 	}
 }
 
 // SetCurrentFilename sets the current filename and updates title
 func SetCurrentFilename(table *tview.Table, filename string) {
-	if globalWorkbook != nil {
-		globalWorkbook.CurrentFile = filename
+	if wb := defaultSession.ActiveWorkbook(); wb != nil {
+		wb.CurrentFile = filename
 		updateTableTitle(table)
 	}
 }
 
 // Updates the table title to include a • that signals that the file has been modified
 func updateTableTitle(table *tview.Table) {
-	if globalWorkbook == nil {
+	wb := defaultSession.ActiveWorkbook()
+	if wb == nil {
 		table.SetTitle(" Untitled ")
 		return
 	}
 
-	sheet := globalWorkbook.GetActiveSheet()
+	sheet := wb.GetActiveSheet()
 	if sheet == nil {
 		table.SetTitle(" Untitled ")
 		return
 	}
 
-	filename := globalWorkbook.CurrentFile
+	filename := wb.CurrentFile
 
 	var title string
 	if filename == "" {
@@ -217,30 +224,33 @@ func updateTableTitle(table *tview.Table) {
 		title = fmt.Sprintf(" %s - %s ", filepath.Base(filename), sheet.Name)
 	}
 
-	if globalWorkbook.HasChanges {
+	if wb.HasChanges {
 		title += "● "
 	}
 
 	table.SetTitle(title)
 }
 
-// HasUnsavedChanges returns whether there are unsaved changes
+// HasUnsavedChanges returns whether the active workbook has unsaved changes
 func HasUnsavedChanges() bool {
-	if globalWorkbook == nil {
+	wb := defaultSession.ActiveWorkbook()
+	if wb == nil {
 		return false
 	}
-	return globalWorkbook.HasChanges
+	return wb.HasChanges
 }
 
-// GetCurrentFilename returns the current filename
+// GetCurrentFilename returns the active workbook's current filename
 func GetCurrentFilename() string {
-	if globalWorkbook == nil {
+	wb := defaultSession.ActiveWorkbook()
+	if wb == nil {
 		return ""
 	}
-	return globalWorkbook.CurrentFile
+	return wb.CurrentFile
 }
 
-// GetWorkbook returns the global workbook (for file operations)
+// GetWorkbook returns the active workbook (for file operations). Callers
+// juggling more than one open workbook should use GetSession instead.
 func GetWorkbook() *Workbook {
-	return globalWorkbook
+	return defaultSession.ActiveWorkbook()
 }
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// models.go provides the dialog-facing data for defining/editing a
+// fileop.ChartSpec: the option lists chartsUI.go's form populates its
+// dropdowns from, and a ValidateSpec check run before a chart is saved.
+package chartui
+
+import (
+	"fmt"
+
+	"gosheet/internal/services/fileop"
+)
+
+// TypeOption is one entry in the chart-type dropdown.
+type TypeOption struct {
+	Label string
+	Value fileop.ChartType
+}
+
+// TypeOptions lists every fileop.ChartType in the order the dropdown offers them.
+var TypeOptions = []TypeOption{
+	{Label: "Line", Value: fileop.ChartLine},
+	{Label: "Bar", Value: fileop.ChartBar},
+	{Label: "Pie", Value: fileop.ChartPie},
+	{Label: "Scatter", Value: fileop.ChartScatter},
+}
+
+// LegendOption is one entry in the legend-position dropdown.
+type LegendOption struct {
+	Label string
+	Value fileop.LegendPosition
+}
+
+// LegendOptions lists every fileop.LegendPosition in the order the dropdown offers them.
+var LegendOptions = []LegendOption{
+	{Label: "None", Value: fileop.LegendNone},
+	{Label: "Top", Value: fileop.LegendTop},
+	{Label: "Bottom", Value: fileop.LegendBottom},
+	{Label: "Left", Value: fileop.LegendLeft},
+	{Label: "Right", Value: fileop.LegendRight},
+}
+
+// ValidateSpec reports whether spec is complete enough to save: it needs a
+// recognized Type and a non-empty DataRange, the same two fields
+// ResolveChartSeries can't do anything without.
+func ValidateSpec(spec fileop.ChartSpec) error {
+	if spec.DataRange == "" {
+		return fmt.Errorf("data range is required")
+	}
+
+	for _, opt := range TypeOptions {
+		if opt.Value == spec.Type {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown chart type %q", spec.Type)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// chartsUI.go provides the "Charts" screen reachable from the sheet
+// manager: a list of the selected sheet's charts with add/edit/delete
+// dialogs, the chart-specific counterpart to namedRangesUI.go.
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"gosheet/internal/services/fileop"
+	chartui "gosheet/internal/services/ui/chart"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ShowChartsManager displays every chart on the sheet at sheetIndex and lets
+// the user add, edit, or delete one. returnTo is restored on Esc.
+func ShowChartsManager(app *tview.Application, returnTo tview.Primitive, callbacks SheetManagerCallbacks, sheetIndex int, sheetName string) {
+	list := tview.NewList().
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan).
+		SetSelectedTextColor(tcell.ColorWhite).
+		SetMainTextColor(tcell.ColorWhite).
+		SetSecondaryTextColor(tcell.ColorGray).
+		ShowSecondaryText(true)
+	list.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Charts - %s ", sheetName)).
+		SetBorderColor(tcell.ColorLightBlue).
+		SetTitleAlign(tview.AlignLeft)
+
+	updateChartsList(list, callbacks, sheetIndex)
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[gray]Alt+N[-] add   [gray]Alt+R[-] edit   [gray]Alt+D[-] delete   [gray]Esc[-] back")
+	help.SetBorder(true)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(help, 3, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			return nil
+		}
+
+		if event.Modifiers()&tcell.ModAlt != 0 {
+			switch event.Rune() {
+			case 'n', 'N':
+				showAddChartDialog(app, callbacks, layout, list, sheetIndex)
+				return nil
+			case 'r', 'R':
+				showEditChartDialog(app, callbacks, layout, list, sheetIndex)
+				return nil
+			case 'd', 'D':
+				confirmDeleteChart(app, callbacks, layout, list, sheetIndex)
+				return nil
+			}
+		}
+		return event
+	})
+
+	app.SetRoot(layout, true).SetFocus(list)
+}
+
+// updateChartsList refreshes list to reflect callbacks.GetCharts(sheetIndex).
+func updateChartsList(list *tview.List, callbacks SheetManagerCallbacks, sheetIndex int) {
+	list.Clear()
+	for _, spec := range callbacks.GetCharts(sheetIndex) {
+		title := spec.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		list.AddItem(
+			fmt.Sprintf(" %s [%s]", title, spec.Type),
+			fmt.Sprintf("   └─ %s", spec.DataRange),
+			0, nil,
+		)
+	}
+}
+
+// showAddChartDialog prompts for a new chart's fields.
+func showAddChartDialog(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, sheetIndex int) {
+	showChartForm(app, callbacks, returnTo, list, sheetIndex, " + Add Chart ", fileop.ChartSpec{}, func(spec fileop.ChartSpec) error {
+		return callbacks.AddChart(sheetIndex, spec)
+	})
+}
+
+// showEditChartDialog prefills the form with the selected chart.
+func showEditChartDialog(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, sheetIndex int) {
+	charts := callbacks.GetCharts(sheetIndex)
+	chartIndex := list.GetCurrentItem()
+	if chartIndex < 0 || chartIndex >= len(charts) {
+		return
+	}
+	current := charts[chartIndex]
+
+	showChartForm(app, callbacks, returnTo, list, sheetIndex, " Edit Chart ", current, func(spec fileop.ChartSpec) error {
+		return callbacks.UpdateChart(sheetIndex, chartIndex, spec)
+	})
+}
+
+// showChartForm is the shared field form behind add and edit, differing only
+// in its title, prefilled values, and submit action.
+func showChartForm(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, sheetIndex int, title string, prefill fileop.ChartSpec, onSubmit func(fileop.ChartSpec) error) {
+	form := tview.NewForm()
+	form.SetFieldBackgroundColor(tcell.ColorBlack)
+	form.SetButtonBackgroundColor(tcell.ColorDarkGreen)
+	form.SetButtonTextColor(tcell.ColorWhite)
+
+	typeLabels := make([]string, len(chartui.TypeOptions))
+	typeIndex := 0
+	for i, opt := range chartui.TypeOptions {
+		typeLabels[i] = opt.Label
+		if opt.Value == prefill.Type {
+			typeIndex = i
+		}
+	}
+
+	legendLabels := make([]string, len(chartui.LegendOptions))
+	legendIndex := 0
+	for i, opt := range chartui.LegendOptions {
+		legendLabels[i] = opt.Label
+		if opt.Value == prefill.LegendPosition {
+			legendIndex = i
+		}
+	}
+
+	titleInput := tview.NewInputField().
+		SetLabel("Title: ").
+		SetText(prefill.Title).
+		SetFieldWidth(24)
+	dataRangeInput := tview.NewInputField().
+		SetLabel("Data range (e.g. B2:B10): ").
+		SetText(prefill.DataRange).
+		SetFieldWidth(24)
+	categoryRangeInput := tview.NewInputField().
+		SetLabel("Category range (optional): ").
+		SetText(prefill.CategoryRange).
+		SetFieldWidth(24)
+	xAxisInput := tview.NewInputField().
+		SetLabel("X axis title: ").
+		SetText(prefill.XAxisTitle).
+		SetFieldWidth(24)
+	yAxisInput := tview.NewInputField().
+		SetLabel("Y axis title: ").
+		SetText(prefill.YAxisTitle).
+		SetFieldWidth(24)
+
+	var selectedType fileop.ChartType = chartui.TypeOptions[typeIndex].Value
+	var selectedLegend fileop.LegendPosition = chartui.LegendOptions[legendIndex].Value
+
+	typeDropdown := tview.NewDropDown().
+		SetLabel("Type: ").
+		SetOptions(typeLabels, func(text string, index int) { selectedType = chartui.TypeOptions[index].Value }).
+		SetCurrentOption(typeIndex)
+	legendDropdown := tview.NewDropDown().
+		SetLabel("Legend: ").
+		SetOptions(legendLabels, func(text string, index int) { selectedLegend = chartui.LegendOptions[index].Value }).
+		SetCurrentOption(legendIndex)
+
+	form.AddFormItem(titleInput).
+		AddFormItem(typeDropdown).
+		AddFormItem(dataRangeInput).
+		AddFormItem(categoryRangeInput).
+		AddFormItem(legendDropdown).
+		AddFormItem(xAxisInput).
+		AddFormItem(yAxisInput).
+		AddButton("Save", func() {
+			spec := fileop.ChartSpec{
+				Type:           selectedType,
+				Title:          strings.TrimSpace(titleInput.GetText()),
+				DataRange:      strings.ToUpper(strings.TrimSpace(dataRangeInput.GetText())),
+				CategoryRange:  strings.ToUpper(strings.TrimSpace(categoryRangeInput.GetText())),
+				LegendPosition: selectedLegend,
+				XAxisTitle:     strings.TrimSpace(xAxisInput.GetText()),
+				YAxisTitle:     strings.TrimSpace(yAxisInput.GetText()),
+			}
+
+			if err := chartui.ValidateSpec(spec); err != nil {
+				ShowWarningModal(app, form, err.Error())
+				return
+			}
+
+			if err := onSubmit(spec); err != nil {
+				ShowWarningModal(app, form, err.Error())
+				return
+			}
+
+			updateChartsList(list, callbacks, sheetIndex)
+			callbacks.MarkAsModified()
+			app.SetRoot(returnTo, true).SetFocus(list)
+		}).
+		AddButton("Cancel", func() {
+			app.SetRoot(returnTo, true).SetFocus(list)
+		})
+
+	form.SetBorder(true).
+		SetTitle(title).
+		SetBorderColor(tcell.ColorGreen).
+		SetTitleAlign(tview.AlignCenter)
+
+	app.SetRoot(form, true).SetFocus(form)
+}
+
+// confirmDeleteChart asks for confirmation before removing a chart.
+func confirmDeleteChart(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, sheetIndex int) {
+	charts := callbacks.GetCharts(sheetIndex)
+	chartIndex := list.GetCurrentItem()
+	if chartIndex < 0 || chartIndex >= len(charts) {
+		return
+	}
+	spec := charts[chartIndex]
+	title := spec.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete chart '%s'?", title)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonLabel == "Delete" {
+				if err := callbacks.DeleteChart(sheetIndex, chartIndex); err != nil {
+					ShowWarningModal(app, returnTo, err.Error())
+				} else {
+					updateChartsList(list, callbacks, sheetIndex)
+					callbacks.MarkAsModified()
+				}
+			}
+			app.SetRoot(returnTo, true).SetFocus(list)
+		})
+
+	app.SetRoot(modal, true).SetFocus(modal)
+}
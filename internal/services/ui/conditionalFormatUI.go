@@ -0,0 +1,809 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// conditionalFormatUI.go implements conditional formatting rules with
+// Excel-like presets, reusing dataValidationUI.go's preset architecture and
+// cell-reference/range plumbing. A rule is conceptually cell.Cell's
+// CondFormats field (`CondFormats []*CondFormatRule`, evaluated in priority
+// order with the winning Style overlaid on the base rendering); until the
+// cell package carries that field directly, rules are tracked here per cell
+// key the same way invalidCells stands in for a cell-side flag.
+
+package ui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+
+	"github.com/Knetic/govaluate"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CellStyle is the fg/bg color and bold flag a winning conditional format
+// rule overlays onto a cell's rendered TableCell. It mirrors cell.Cell's own
+// Color/BgColor [3]uint8 convention so a rule's style composes with the
+// cell's own formatting the same way.
+type CellStyle struct {
+	FgColor [3]uint8
+	BgColor [3]uint8
+	Bold    bool
+}
+
+// CondFormatKind distinguishes how a CondFormatRule turns a cell's value
+// into a Style: a plain govaluate boolean expression, a frequency check
+// over its Range, or one of the two continuous presets (data bar, color
+// scale) whose style depends on where the value falls between the range's
+// extremes.
+type CondFormatKind string
+
+const (
+	CondFormatKindRule         CondFormatKind = "rule"
+	CondFormatKindTopN         CondFormatKind = "topN"
+	CondFormatKindBottomN      CondFormatKind = "bottomN"
+	CondFormatKindAboveAverage CondFormatKind = "aboveAverage"
+	CondFormatKindBelowAverage CondFormatKind = "belowAverage"
+	CondFormatKindUnique       CondFormatKind = "unique"
+	CondFormatKindDuplicate    CondFormatKind = "duplicate"
+	CondFormatKindDataBar      CondFormatKind = "dataBar"
+	CondFormatKindColorScale2  CondFormatKind = "colorScale2"
+	CondFormatKindColorScale3  CondFormatKind = "colorScale3"
+)
+
+// CondFormatRule is one conditional formatting rule applied over Range,
+// checked in ascending Priority order with the first match winning.
+type CondFormatRule struct {
+	Name       string
+	Priority   int
+	Range      string // e.g. "B2:B20", the region the rule was applied over
+	Kind       CondFormatKind
+	Expression string // govaluate boolean expression, used when Kind == CondFormatKindRule
+	N          int    // used by CondFormatKindTopN/BottomN
+	Style      CellStyle
+	ScaleMin   CellStyle // low end of a data bar / color scale
+	ScaleMid   CellStyle // middle stop of a 3-color scale
+	ScaleMax   CellStyle // high end of a data bar / color scale
+}
+
+// condFormatRules holds every cell's conditional format rules, keyed by its
+// sheet-scoped position (see dataValidationUI.go's sheetCellKey/scopeKey),
+// standing in for cell.Cell.CondFormats.
+var condFormatRules = make(map[sheetCellKey][]*CondFormatRule)
+
+// nextCondFormatPriority auto-assigns a new rule the next priority slot so
+// rules stack in the order they were added unless the user overrides it.
+var nextCondFormatPriority = 1
+
+// condFormatAggKey caches a range-level aggregate (min/max/average/Nth
+// value) keyed by the range it was computed over and a hash of the formula
+// that produced it, so ranking presets don't recompute their aggregate once
+// per cell in the range on every redraw.
+type condFormatAggKey struct {
+	rangeRef    string
+	formulaHash uint64
+}
+
+var condFormatAggCache = make(map[condFormatAggKey]float64)
+
+// condFormatFreqCache caches a range's raw-value -> occurrence-count map for
+// the Unique/Duplicate presets, the frequency-check equivalent of
+// condFormatAggCache.
+var condFormatFreqCache = make(map[string]map[string]int)
+
+// clearCondFormatCaches drops every cached aggregate and frequency map,
+// forced whenever a rule is added or removed so the next redraw recomputes
+// against current data.
+func clearCondFormatCaches() {
+	condFormatAggCache = make(map[condFormatAggKey]float64)
+	condFormatFreqCache = make(map[string]map[string]int)
+}
+
+func hashFormula(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// condFormatRangeStat returns the cached result of reduce over rangeRef's
+// numeric values, computing and caching it on first use under (rangeRef,
+// formula).
+func condFormatRangeStat(rangeRef, formula string, globalData map[[2]int]*cell.Cell, reduce func([]float64) float64) float64 {
+	key := condFormatAggKey{rangeRef: rangeRef, formulaHash: hashFormula(formula)}
+	if v, ok := condFormatAggCache[key]; ok {
+		return v
+	}
+	result := reduce(condFormatRangeValues(rangeRef, globalData))
+	condFormatAggCache[key] = result
+	return result
+}
+
+// condFormatValueCounts returns the cached raw-value -> occurrence-count map
+// for rangeRef, computing it on first use.
+func condFormatValueCounts(rangeRef string, globalData map[[2]int]*cell.Cell) map[string]int {
+	if counts, ok := condFormatFreqCache[rangeRef]; ok {
+		return counts
+	}
+	r1, c1, r2, c2, ok := parseCondFormatRange(rangeRef)
+	counts := make(map[string]int)
+	if !ok {
+		condFormatFreqCache[rangeRef] = counts
+		return counts
+	}
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			cellData, exists := globalData[[2]int{int(r), int(c)}]
+			if !exists || cellData.RawValue == nil {
+				continue
+			}
+			counts[strings.TrimSpace(*cellData.RawValue)]++
+		}
+	}
+	condFormatFreqCache[rangeRef] = counts
+	return counts
+}
+
+// condFormatRangeValues reads every numeric cell in rangeRef out of
+// globalData, skipping blanks and non-numeric values.
+func condFormatRangeValues(rangeRef string, globalData map[[2]int]*cell.Cell) []float64 {
+	r1, c1, r2, c2, ok := parseCondFormatRange(rangeRef)
+	if !ok {
+		return nil
+	}
+	values := make([]float64, 0, int(r2-r1+1)*int(c2-c1+1))
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			cellData, exists := globalData[[2]int{int(r), int(c)}]
+			if !exists || cellData.RawValue == nil {
+				continue
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(*cellData.RawValue), 64); err == nil {
+				values = append(values, f)
+			}
+		}
+	}
+	return values
+}
+
+// parseCondFormatRange parses an "A1:B10" range into normalized (low, low,
+// high, high) absolute row/col bounds. It duplicates validationRangeResolver's
+// range-splitting rather than exporting a shared helper for it, the same
+// call evaluatefuncs.checkArgs makes about utils.validateArgs.
+func parseCondFormatRange(rangeRef string) (r1, c1, r2, c2 int32, ok bool) {
+	parts := strings.Split(rangeRef, ":")
+	if len(parts) != 2 {
+		return 0, 0, 0, 0, false
+	}
+	r1, c1, ok1 := parseValidationRef(parts[0])
+	r2, c2, ok2 := parseValidationRef(parts[1])
+	if !ok1 || !ok2 {
+		return 0, 0, 0, 0, false
+	}
+	if r1 > r2 {
+		r1, r2 = r2, r1
+	}
+	if c1 > c2 {
+		c1, c2 = c2, c1
+	}
+	return r1, c1, r2, c2, true
+}
+
+func rangeMin(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func rangeMax(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+func rangeAverage(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// rangeNth returns a reduce func picking the Nth value from the top (or
+// bottom, if ascending is true) of values, clamped to the slice's bounds.
+func rangeNth(n int, ascending bool) func([]float64) float64 {
+	return func(values []float64) float64 {
+		if len(values) == 0 {
+			return 0
+		}
+		sorted := append([]float64(nil), values...)
+		if ascending {
+			sort.Float64s(sorted)
+		} else {
+			sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+		}
+		if n < 1 {
+			n = 1
+		}
+		if n > len(sorted) {
+			n = len(sorted)
+		}
+		return sorted[n-1]
+	}
+}
+
+// interpolateStyle linearly blends two styles' background colors by frac
+// (clamped to [0,1]), for data bars and color scales.
+func interpolateStyle(from, to CellStyle, frac float64) CellStyle {
+	if frac < 0 {
+		frac = 0
+	}
+	if frac > 1 {
+		frac = 1
+	}
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + frac*(float64(b)-float64(a)))
+	}
+	return CellStyle{
+		BgColor: [3]uint8{
+			lerp(from.BgColor[0], to.BgColor[0]),
+			lerp(from.BgColor[1], to.BgColor[1]),
+			lerp(from.BgColor[2], to.BgColor[2]),
+		},
+		FgColor: to.FgColor,
+	}
+}
+
+// evaluateCondFormatStyle reports whether rule matches (row, col)'s current
+// value and, if so, the style it contributes.
+func evaluateCondFormatStyle(rule *CondFormatRule, row, col int32, globalData map[[2]int]*cell.Cell) (CellStyle, bool) {
+	key := [2]int{int(row), int(col)}
+	cellData, exists := globalData[key]
+	if !exists || cellData.RawValue == nil || strings.TrimSpace(*cellData.RawValue) == "" {
+		return CellStyle{}, false
+	}
+	raw := strings.TrimSpace(*cellData.RawValue)
+
+	switch rule.Kind {
+	case CondFormatKindUnique, CondFormatKindDuplicate:
+		isDuplicate := condFormatValueCounts(rule.Range, globalData)[raw] > 1
+		if (rule.Kind == CondFormatKindDuplicate) == isDuplicate {
+			return rule.Style, true
+		}
+		return CellStyle{}, false
+
+	case CondFormatKindTopN:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		threshold := condFormatRangeStat(rule.Range, fmt.Sprintf("top%d", rule.N), globalData, rangeNth(rule.N, false))
+		return rule.Style, value >= threshold
+
+	case CondFormatKindBottomN:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		threshold := condFormatRangeStat(rule.Range, fmt.Sprintf("bottom%d", rule.N), globalData, rangeNth(rule.N, true))
+		return rule.Style, value <= threshold
+
+	case CondFormatKindAboveAverage:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		avg := condFormatRangeStat(rule.Range, "avg", globalData, rangeAverage)
+		return rule.Style, value > avg
+
+	case CondFormatKindBelowAverage:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		avg := condFormatRangeStat(rule.Range, "avg", globalData, rangeAverage)
+		return rule.Style, value < avg
+
+	case CondFormatKindDataBar, CondFormatKindColorScale2, CondFormatKindColorScale3:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		lo := condFormatRangeStat(rule.Range, "min", globalData, rangeMin)
+		hi := condFormatRangeStat(rule.Range, "max", globalData, rangeMax)
+		if hi <= lo {
+			return rule.ScaleMax, true
+		}
+		frac := (value - lo) / (hi - lo)
+		if rule.Kind == CondFormatKindColorScale3 {
+			if frac <= 0.5 {
+				return interpolateStyle(rule.ScaleMin, rule.ScaleMid, frac*2), true
+			}
+			return interpolateStyle(rule.ScaleMid, rule.ScaleMax, (frac-0.5)*2), true
+		}
+		return interpolateStyle(rule.ScaleMin, rule.ScaleMax, frac), true
+
+	default:
+		var replacement string
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			replacement = fmt.Sprintf("%v", f)
+		} else {
+			replacement = fmt.Sprintf("%q", raw)
+		}
+		expr := strings.ReplaceAll(strings.ToUpper(rule.Expression), "THIS", replacement)
+		expr = substituteValidationRefs(expr, globalData)
+		expr = utils.ExpandRangeRefs(expr)
+
+		functions := utils.GovalFuncsWithRanges(validationRangeResolver(globalData))
+		parsed, err := govaluate.NewEvaluableExpressionWithFunctions(expr, functions)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		result, err := parsed.Evaluate(nil)
+		if err != nil {
+			return CellStyle{}, false
+		}
+		matched, ok := result.(bool)
+		if !ok || !matched {
+			return CellStyle{}, false
+		}
+		return rule.Style, true
+	}
+}
+
+// applyCondFormatStyleToCell overlays (row, col)'s highest-priority matching
+// rule's style onto tvCell, leaving it untouched if no rule there matches.
+func applyCondFormatStyleToCell(tvCell *tview.TableCell, row, col int32, globalData map[[2]int]*cell.Cell) *tview.TableCell {
+	rules := condFormatRules[scopeKey([2]int{int(row), int(col)})]
+	if len(rules) == 0 {
+		return tvCell
+	}
+
+	sorted := append([]*CondFormatRule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority < sorted[j].Priority })
+
+	for _, rule := range sorted {
+		style, matched := evaluateCondFormatStyle(rule, row, col, globalData)
+		if !matched {
+			continue
+		}
+		tvCell = tvCell.
+			SetTextColor(tcell.NewRGBColor(int32(style.FgColor[0]), int32(style.FgColor[1]), int32(style.FgColor[2]))).
+			SetBackgroundColor(tcell.NewRGBColor(int32(style.BgColor[0]), int32(style.BgColor[1]), int32(style.BgColor[2])))
+		if style.Bold {
+			tvCell.SetAttributes(tcell.AttrBold)
+		}
+		return tvCell
+	}
+	return tvCell
+}
+
+// ConditionalFormatPreset represents a predefined conditional formatting
+// rule, mirroring ValidationPreset's shape.
+type ConditionalFormatPreset struct {
+	Name        string
+	Description string
+	Fields      []ValidationField
+	Kind        CondFormatKind // CondFormatKindRule if unset
+	BuildRule   func(params map[string]string) string
+}
+
+// GetConditionalFormatPresets returns all available conditional formatting
+// presets.
+func GetConditionalFormatPresets() []ConditionalFormatPreset {
+	return []ConditionalFormatPreset{
+		{
+			Name:        "Cell Value - Between",
+			Description: "Highlight cells whose value is between two numbers",
+			Fields: []ValidationField{
+				{Name: "min", Label: "Minimum:", Type: "number", Placeholder: "0"},
+				{Name: "max", Label: "Maximum:", Type: "number", Placeholder: "100"},
+			},
+			BuildRule: func(params map[string]string) string {
+				return fmt.Sprintf("THIS >= %s && THIS <= %s", params["min"], params["max"])
+			},
+		},
+		{
+			Name:        "Cell Value - Greater Than",
+			Description: "Highlight cells greater than a value",
+			Fields: []ValidationField{
+				{Name: "value", Label: "Greater than:", Type: "number", Placeholder: "0"},
+			},
+			BuildRule: func(params map[string]string) string {
+				return fmt.Sprintf("THIS > %s", params["value"])
+			},
+		},
+		{
+			Name:        "Cell Value - Less Than",
+			Description: "Highlight cells less than a value",
+			Fields: []ValidationField{
+				{Name: "value", Label: "Less than:", Type: "number", Placeholder: "100"},
+			},
+			BuildRule: func(params map[string]string) string {
+				return fmt.Sprintf("THIS < %s", params["value"])
+			},
+		},
+		{
+			Name:        "Text Contains",
+			Description: "Highlight cells whose text contains a substring",
+			Fields: []ValidationField{
+				{Name: "text", Label: "Contains:", Type: "text", Placeholder: "keyword"},
+			},
+			BuildRule: func(params map[string]string) string {
+				return fmt.Sprintf("CONTAINS(THIS, %q)", params["text"])
+			},
+		},
+		{
+			Name:        "Top N Values",
+			Description: "Highlight the N highest values in the range",
+			Fields: []ValidationField{
+				{Name: "n", Label: "N:", Type: "number", Placeholder: "10"},
+			},
+			Kind: CondFormatKindTopN,
+		},
+		{
+			Name:        "Bottom N Values",
+			Description: "Highlight the N lowest values in the range",
+			Fields: []ValidationField{
+				{Name: "n", Label: "N:", Type: "number", Placeholder: "10"},
+			},
+			Kind: CondFormatKindBottomN,
+		},
+		{
+			Name:        "Above Average",
+			Description: "Highlight values above the range's average",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindAboveAverage,
+		},
+		{
+			Name:        "Below Average",
+			Description: "Highlight values below the range's average",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindBelowAverage,
+		},
+		{
+			Name:        "Unique Values",
+			Description: "Highlight values that appear exactly once in the range",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindUnique,
+		},
+		{
+			Name:        "Duplicate Values",
+			Description: "Highlight values that appear more than once in the range",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindDuplicate,
+		},
+		{
+			Name:        "Data Bar",
+			Description: "Shade each cell's background in proportion to its value within the range",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindDataBar,
+		},
+		{
+			Name:        "Color Scale (2-Color)",
+			Description: "Blend between two colors across the range's lowest and highest values",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindColorScale2,
+		},
+		{
+			Name:        "Color Scale (3-Color)",
+			Description: "Blend through three colors across the range's lowest, middle, and highest values",
+			Fields:      []ValidationField{},
+			Kind:        CondFormatKindColorScale3,
+		},
+	}
+}
+
+// condFormatColorOptions pairs a human label with the RGB triplet it maps to
+// in a CellStyle, the cell-style continuation of sheetManagerUI.go's
+// sheetColorOptions naming for sheet-tab color labels.
+var condFormatColorOptions = []struct {
+	Label string
+	RGB   [3]uint8
+}{
+	{"Red", [3]uint8{255, 199, 206}},
+	{"Orange", [3]uint8{255, 214, 165}},
+	{"Yellow", [3]uint8{255, 235, 156}},
+	{"Green", [3]uint8{198, 239, 206}},
+	{"Blue", [3]uint8{189, 215, 238}},
+	{"Purple", [3]uint8{216, 195, 236}},
+	{"Gray", [3]uint8{217, 217, 217}},
+	{"White", [3]uint8{255, 255, 255}},
+	{"Black", [3]uint8{0, 0, 0}},
+}
+
+func condFormatColorLabel(rgb [3]uint8) string {
+	for _, opt := range condFormatColorOptions {
+		if opt.RGB == rgb {
+			return opt.Label
+		}
+	}
+	return condFormatColorOptions[0].Label
+}
+
+func condFormatColorRGB(label string) [3]uint8 {
+	for _, opt := range condFormatColorOptions {
+		if strings.EqualFold(opt.Label, label) {
+			return opt.RGB
+		}
+	}
+	return condFormatColorOptions[0].RGB
+}
+
+// buildCondFormatRule validates params for preset's Kind and returns the
+// CondFormatRule to attach over rangeRef, or an error describing what's
+// missing.
+func buildCondFormatRule(preset ConditionalFormatPreset, params map[string]string, rangeRef string, fg, bg [3]uint8, mid [3]uint8, bold bool, priority int) (*CondFormatRule, error) {
+	if _, _, _, _, ok := parseCondFormatRange(rangeRef); !ok {
+		return nil, fmt.Errorf("invalid range %q, expected e.g. A1:B10", rangeRef)
+	}
+
+	rule := &CondFormatRule{
+		Name:     preset.Name,
+		Priority: priority,
+		Range:    rangeRef,
+		Kind:     preset.Kind,
+		Style:    CellStyle{FgColor: fg, BgColor: bg, Bold: bold},
+		ScaleMin: CellStyle{BgColor: bg},
+		ScaleMid: CellStyle{BgColor: mid},
+		ScaleMax: CellStyle{BgColor: fg},
+	}
+	if rule.Kind == "" {
+		rule.Kind = CondFormatKindRule
+	}
+
+	switch rule.Kind {
+	case CondFormatKindTopN, CondFormatKindBottomN:
+		n, err := strconv.Atoi(params["n"])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("N must be a positive whole number")
+		}
+		rule.N = n
+	case CondFormatKindRule:
+		for _, field := range preset.Fields {
+			if strings.TrimSpace(params[field.Name]) == "" {
+				return nil, fmt.Errorf("please fill in all required fields before applying")
+			}
+		}
+		rule.Expression = preset.BuildRule(params)
+	}
+
+	return rule, nil
+}
+
+// applyCondFormatRule attaches rule to every cell in its Range.
+func applyCondFormatRule(rule *CondFormatRule, globalData map[[2]int]*cell.Cell) {
+	r1, c1, r2, c2, _ := parseCondFormatRange(rule.Range)
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			key := scopeKey([2]int{int(r), int(c)})
+			condFormatRules[key] = append(condFormatRules[key], rule)
+		}
+	}
+	clearCondFormatCaches()
+}
+
+// clearCondFormatRulesAt removes every conditional format rule attached to
+// key, regardless of which range it was originally applied over.
+func clearCondFormatRulesAt(key [2]int) {
+	delete(condFormatRules, scopeKey(key))
+	clearCondFormatCaches()
+}
+
+// refreshCondFormatRange redraws every visible cell in rangeRef so a newly
+// applied or removed rule is reflected immediately.
+func refreshCondFormatRange(table *tview.Table, rangeRef string, globalData map[[2]int]*cell.Cell, globalViewport *utils.Viewport) {
+	r1, c1, r2, c2, ok := parseCondFormatRange(rangeRef)
+	if !ok {
+		return
+	}
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			if !globalViewport.IsVisible(r, c) {
+				continue
+			}
+			cellData, exists := globalData[[2]int{int(r), int(c)}]
+			if !exists {
+				continue
+			}
+			visualR, visualC := globalViewport.ToRelative(r, c)
+			tvCell := applyCondFormatStyleToCell(cellData.ToTViewCell(), r, c, globalData)
+			table.SetCell(int(visualR), int(visualC), tvCell)
+		}
+	}
+}
+
+// ShowConditionalFormatDialog displays the conditional formatting rule
+// editor, mirroring ShowValidationRuleDialog's preset dropdown, dynamic
+// form, and preview layout.
+func ShowConditionalFormatDialog(app *tview.Application, table *tview.Table, returnTo tview.Primitive, focus tview.Primitive, globalData map[[2]int]*cell.Cell, globalViewport *utils.Viewport) {
+	visualRow, visualCol := utils.ConvertToInt32(table.GetSelection())
+	row, col := globalViewport.ToAbsolute(visualRow, visualCol)
+
+	if row == 0 || col == 0 {
+		return
+	}
+
+	key := [2]int{int(row), int(col)}
+	currentRef := fmt.Sprintf("%s%d", utils.ColumnName(col), row)
+
+	presets := GetConditionalFormatPresets()
+	presetNames := make([]string, len(presets))
+	for i, preset := range presets {
+		presetNames[i] = preset.Name
+	}
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+
+	presetDropdown := tview.NewDropDown().
+		SetLabel("Format Type: ").
+		SetOptions(presetNames, nil).
+		SetCurrentOption(0)
+	presetDropdown.SetBorder(true).
+		SetTitle(" 1. Select Type ").
+		SetBorderColor(tcell.ColorLightBlue)
+
+	rangeInput := tview.NewInputField().
+		SetLabel("Applies to range: ").
+		SetText(currentRef).
+		SetFieldWidth(20)
+
+	dynamicForm := tview.NewForm()
+	dynamicForm.SetBorder(true).
+		SetTitle(" 2. Configure ").
+		SetBorderColor(tcell.ColorLightBlue)
+
+	colorLabels := make([]string, len(condFormatColorOptions))
+	for i, opt := range condFormatColorOptions {
+		colorLabels[i] = opt.Label
+	}
+
+	fgDropdown := tview.NewDropDown().
+		SetLabel("Text color: ").
+		SetOptions(colorLabels, nil).
+		SetCurrentOption(8) // "Black"
+	bgDropdown := tview.NewDropDown().
+		SetLabel("Fill color: ").
+		SetOptions(colorLabels, nil).
+		SetCurrentOption(2) // "Yellow"
+	midDropdown := tview.NewDropDown().
+		SetLabel("Mid color (3-color scale only): ").
+		SetOptions(colorLabels, nil).
+		SetCurrentOption(2) // "Yellow"... reused index acceptable as a default
+	boldCheckbox := tview.NewCheckbox().
+		SetLabel("Bold: ")
+
+	styleForm := tview.NewForm().
+		AddFormItem(fgDropdown).
+		AddFormItem(bgDropdown).
+		AddFormItem(midDropdown).
+		AddFormItem(boldCheckbox)
+	styleForm.SetBorder(true).
+		SetTitle(" 3. Style ").
+		SetBorderColor(tcell.ColorPurple)
+
+	existing := condFormatRules[scopeKey(key)]
+	var existingDesc strings.Builder
+	if len(existing) == 0 {
+		existingDesc.WriteString("No conditional format rules on this cell yet.")
+	} else {
+		existingDesc.WriteString("Rules currently covering this cell:\n")
+		for _, r := range existing {
+			fmt.Fprintf(&existingDesc, "  [gray]#%d[-] %s over %s\n", r.Priority, r.Name, r.Range)
+		}
+	}
+
+	previewText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	previewText.SetBorder(true).
+		SetTitle(" Preview ").
+		SetBorderColor(tcell.ColorGreen)
+
+	buttonForm := tview.NewForm()
+	buttonForm.AddButton("Apply", func() {
+		currentPresetIdx, _ := presetDropdown.GetCurrentOption()
+		preset := presets[currentPresetIdx]
+
+		params := make(map[string]string)
+		for i := 0; i < dynamicForm.GetFormItemCount(); i++ {
+			if inputField, ok := dynamicForm.GetFormItem(i).(*tview.InputField); ok && i < len(preset.Fields) {
+				params[preset.Fields[i].Name] = inputField.GetText()
+			}
+		}
+
+		_, fgLabel := fgDropdown.GetCurrentOption()
+		_, bgLabel := bgDropdown.GetCurrentOption()
+		_, midLabel := midDropdown.GetCurrentOption()
+
+		rule, err := buildCondFormatRule(preset, params, strings.ToUpper(strings.TrimSpace(rangeInput.GetText())),
+			condFormatColorRGB(fgLabel), condFormatColorRGB(bgLabel), condFormatColorRGB(midLabel),
+			boldCheckbox.IsChecked(), nextCondFormatPriority)
+		if err != nil {
+			showValidationErrorModal(app, container, container, err.Error())
+			return
+		}
+
+		nextCondFormatPriority++
+		applyCondFormatRule(rule, globalData)
+		refreshCondFormatRange(table, rule.Range, globalData, globalViewport)
+
+		app.SetRoot(returnTo, true).SetFocus(focus)
+	})
+
+	buttonForm.AddButton("Remove Rules Here", func() {
+		clearCondFormatRulesAt(key)
+		refreshCondFormatRange(table, currentRef+":"+currentRef, globalData, globalViewport)
+		app.SetRoot(returnTo, true).SetFocus(focus)
+	})
+
+	buttonForm.AddButton("Cancel", func() {
+		app.SetRoot(returnTo, true).SetFocus(focus)
+	})
+
+	updateDynamicForm := func(presetIdx int) {
+		dynamicForm.Clear(true)
+		preset := presets[presetIdx]
+
+		previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n%s",
+			preset.Name, preset.Description, existingDesc.String()))
+
+		for _, field := range preset.Fields {
+			dynamicForm.AddFormItem(tview.NewInputField().
+				SetLabel(field.Label).
+				SetPlaceholder(field.Placeholder).
+				SetFieldWidth(30))
+		}
+	}
+
+	presetDropdown.SetSelectedFunc(func(text string, index int) {
+		updateDynamicForm(index)
+	})
+
+	container.
+		AddItem(presetDropdown, 0, 1, false).
+		AddItem(rangeInput, 0, 1, false).
+		AddItem(previewText, 0, 2, false).
+		AddItem(dynamicForm, 0, 2, false).
+		AddItem(styleForm, 0, 2, false).
+		AddItem(buttonForm, 0, 1, false)
+
+	updateDynamicForm(0)
+
+	container.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Conditional Formatting - %s  •  Esc to cancel ", currentRef)).
+		SetBorderColor(tcell.ColorYellow)
+
+	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(focus)
+			return nil
+		}
+		return event
+	})
+
+	app.SetRoot(container, true).SetFocus(presetDropdown)
+}
@@ -10,7 +10,9 @@ package ui
 import (
 	"fmt"
 	"gosheet/internal/services/cell"
+	"gosheet/internal/services/ui/navigation"
 	"gosheet/internal/utils"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,12 +24,469 @@ import (
 
 var validationCellRefRegex = regexp.MustCompile(`\b([A-Z]+)(\d+)\b`)
 
+// validationRefPattern parses a single (optionally $-anchored) cell
+// reference, e.g. "B12" or "$B$12", reusing the same absolute row/col
+// addressing the rest of the app uses.
+var validationRefPattern = regexp.MustCompile(`^\$?([A-Z]+)\$?(\d+)$`)
+
+// activeSheetScope identifies the sheet whose cell-keyed state (validation
+// dependents/invalid cells below, plus conditionalFormatUI.go's
+// condFormatRules) is currently in scope. A bare [2]int cell coordinate
+// collides between sheet 1's A1 and sheet 2's A1 - or two different open
+// workbooks' A1 - so every map keyed on cell position also carries this
+// scope. SetActiveSheetScope must be called whenever the rendered sheet
+// changes (see sheetManagerUI.go's switchToSelectedSheet).
+var activeSheetScope string
+
+// SetActiveSheetScope updates the sheet identity cell-keyed validation and
+// conditional-format state is scoped to. id should uniquely identify a
+// sheet within the running session, e.g. "<workbook file>#<sheet name>".
+func SetActiveSheetScope(id string) {
+	activeSheetScope = id
+}
+
+// sheetCellKey scopes a cell coordinate to the sheet it belongs to, so
+// validationDependents/invalidCells/condFormatRules never confuse same-
+// numbered cells on different sheets.
+type sheetCellKey struct {
+	sheet string
+	coord [2]int
+}
+
+// scopeKey wraps coord with the currently active sheet scope.
+func scopeKey(coord [2]int) sheetCellKey {
+	return sheetCellKey{sheet: activeSheetScope, coord: coord}
+}
+
+// validationDependents maps the scoped key of a cell a validation rule
+// reads to the set of cell keys whose rule reads it, so changing one cell
+// can find every rule that needs re-checking without rescanning the whole
+// sheet. Edge direction is "key changes -> dependent re-validates", the
+// inverse of the more familiar "dependent depends on key" phrasing.
+var validationDependents = make(map[sheetCellKey]map[sheetCellKey]bool)
+
+// invalidCells holds the scoped keys of cells whose stored value currently
+// fails their own validation rule because something they reference changed.
+// markInvalidCell consults this to flag a cell's rendered TableCell.
+var invalidCells = make(map[sheetCellKey]bool)
+
+// listRangeRulePattern matches the rule text the "List - From Cell Range"
+// preset stores: a marker wrapping the source range rather than a real
+// govaluate call, so CheckValidationRule/ValidateValidationRule can special-
+// case it before anything reaches govaluate.
+var listRangeRulePattern = regexp.MustCompile(`^LIST_RANGE\(([A-Za-z0-9_!$:]+)\)$`)
+
+// listValuePattern matches one "THIS == "value"" condition generated by the
+// "List - Allowed Values" preset, used to recover a static list's values for
+// the in-cell dropdown without re-detecting the whole preset.
+var listValuePattern = regexp.MustCompile(`THIS == "([^"]+)"`)
+
+// parseListRangeRule reports whether rule is a "List - From Cell Range" rule
+// and, if so, the source range it names (e.g. "A1:A50" or "Sheet1!A1:A50").
+func parseListRangeRule(rule string) (rangeRef string, ok bool) {
+	m := listRangeRulePattern.FindStringSubmatch(strings.TrimSpace(rule))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// listRangeAllowedValues scans globalData for every non-blank cell in
+// rangeRef and returns their raw values in reading order. A "Sheet!" prefix
+// is accepted but ignored, since globalData only ever holds the active
+// sheet's cells.
+func listRangeAllowedValues(rangeRef string, globalData map[[2]int]*cell.Cell) []string {
+	if idx := strings.LastIndex(rangeRef, "!"); idx != -1 {
+		rangeRef = rangeRef[idx+1:]
+	}
+
+	r1, c1, r2, c2, ok := parseCondFormatRange(strings.ToUpper(rangeRef))
+	if !ok {
+		return nil
+	}
+
+	values := make([]string, 0, int(r2-r1+1)*int(c2-c1+1))
+	for r := r1; r <= r2; r++ {
+		for c := c1; c <= c2; c++ {
+			refCell, exists := globalData[[2]int{int(r), int(c)}]
+			if !exists || refCell.RawValue == nil || strings.TrimSpace(*refCell.RawValue) == "" {
+				continue
+			}
+			values = append(values, strings.TrimSpace(*refCell.RawValue))
+		}
+	}
+	return values
+}
+
+// buildListRangeRule turns rangeRef's current values into the same
+// "THIS == \"v1\" || THIS == \"v2\"" rule text the "List - Allowed Values"
+// preset generates, so CheckValidationRule only needs one evaluation path
+// whether the list was typed in or sourced from a range. An empty range
+// rejects every value rather than accepting everything.
+func buildListRangeRule(rangeRef string, globalData map[[2]int]*cell.Cell) string {
+	values := listRangeAllowedValues(rangeRef, globalData)
+	if len(values) == 0 {
+		return "1 == 2"
+	}
+	conditions := make([]string, len(values))
+	for i, v := range values {
+		conditions[i] = fmt.Sprintf("THIS == \"%s\"", v)
+	}
+	return strings.Join(conditions, " || ")
+}
+
+// isListRule reports whether rule is shaped like either list preset, static
+// or range-backed, so the cell editor can offer a dropdown instead of a
+// plain text field.
+func isListRule(rule string) bool {
+	if _, ok := parseListRangeRule(rule); ok {
+		return true
+	}
+	return listValuePattern.MatchString(rule)
+}
+
+// listValuesFromRule returns rule's current allowed values, resolving a
+// "List - From Cell Range" rule against globalData on every call so the
+// dropdown always reflects the source range's live contents.
+func listValuesFromRule(rule string, globalData map[[2]int]*cell.Cell) ([]string, bool) {
+	if rangeRef, ok := parseListRangeRule(rule); ok {
+		return listRangeAllowedValues(rangeRef, globalData), true
+	}
+	matches := listValuePattern.FindAllStringSubmatch(rule, -1)
+	if len(matches) == 0 {
+		return nil, false
+	}
+	values := make([]string, len(matches))
+	for i, m := range matches {
+		values[i] = m[1]
+	}
+	return values, true
+}
+
+// parseValidationRef parses a cell reference like "B12" or "$B$12" into its
+// absolute 1-indexed row and column.
+func parseValidationRef(ref string) (row, col int32, ok bool) {
+	m := validationRefPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(ref)))
+	if m == nil {
+		return 0, 0, false
+	}
+	c := 0
+	for _, ch := range m[1] {
+		c = c*26 + int(ch-'A') + 1
+	}
+	r, err := strconv.Atoi(m[2])
+	if err != nil {
+		return 0, 0, false
+	}
+	return int32(r), int32(c), true
+}
+
+// extractValidationRefs returns every cell reference a rule mentions (both
+// bare refs and the two endpoints of a range), excluding THIS.
+func extractValidationRefs(rule string) []string {
+	matches := validationCellRefRegex.FindAllString(strings.ToUpper(rule), -1)
+	refs := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if m != "THIS" {
+			refs = append(refs, m)
+		}
+	}
+	return refs
+}
+
+// isRangeEndpoint reports whether the match at rule[start:end] is one side
+// of an "A1:B3"-shaped range, which is left for ExpandRangeRefs/RANGE to
+// resolve instead of the single-ref substitution below.
+func isRangeEndpoint(rule string, start, end int) bool {
+	return (start > 0 && rule[start-1] == ':') || (end < len(rule) && rule[end] == ':')
+}
+
+// resolveValidationRef reads ref's current value out of globalData and
+// returns it as a govaluate-ready literal: a bare number for a numeric cell,
+// a quoted string otherwise, and "" for a cell that doesn't exist yet.
+func resolveValidationRef(ref string, globalData map[[2]int]*cell.Cell) (string, bool) {
+	row, col, ok := parseValidationRef(ref)
+	if !ok {
+		return "", false
+	}
+	refCell, exists := globalData[[2]int{int(row), int(col)}]
+	if !exists || refCell.RawValue == nil || strings.TrimSpace(*refCell.RawValue) == "" {
+		return `""`, true
+	}
+	raw := strings.TrimSpace(*refCell.RawValue)
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return fmt.Sprintf("%v", f), true
+	}
+	return fmt.Sprintf("%q", raw), true
+}
+
+// substituteValidationRefs resolves every standalone cell reference in rule
+// against globalData, leaving "A1:B3"-shaped ranges untouched for
+// ExpandRangeRefs to turn into a RANGE() call afterward.
+func substituteValidationRefs(rule string, globalData map[[2]int]*cell.Cell) string {
+	matches := validationCellRefRegex.FindAllStringIndex(rule, -1)
+	if matches == nil {
+		return rule
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if rule[start:end] == "THIS" || isRangeEndpoint(rule, start, end) {
+			continue
+		}
+		value, ok := resolveValidationRef(rule[start:end], globalData)
+		if !ok {
+			continue
+		}
+		b.WriteString(rule[last:start])
+		b.WriteString(value)
+		last = end
+	}
+	b.WriteString(rule[last:])
+	return b.String()
+}
+
+// validationRangeResolver builds a utils.RangeResolver reading every cell
+// of the range out of globalData, for SUM(B1:B10)-style rules.
+func validationRangeResolver(globalData map[[2]int]*cell.Cell) utils.RangeResolver {
+	return func(rangeRef string) (utils.CellRange, error) {
+		parts := strings.Split(rangeRef, ":")
+		if len(parts) != 2 {
+			return utils.CellRange{}, fmt.Errorf("invalid range %q", rangeRef)
+		}
+		r1, c1, ok1 := parseValidationRef(parts[0])
+		r2, c2, ok2 := parseValidationRef(parts[1])
+		if !ok1 || !ok2 {
+			return utils.CellRange{}, fmt.Errorf("invalid range %q", rangeRef)
+		}
+		if r1 > r2 {
+			r1, r2 = r2, r1
+		}
+		if c1 > c2 {
+			c1, c2 = c2, c1
+		}
+
+		rows, cols := int(r2-r1+1), int(c2-c1+1)
+		values := make([]any, 0, rows*cols)
+		for r := r1; r <= r2; r++ {
+			for c := c1; c <= c2; c++ {
+				refCell, exists := globalData[[2]int{int(r), int(c)}]
+				if !exists || refCell.RawValue == nil {
+					values = append(values, 0.0)
+					continue
+				}
+				raw := strings.TrimSpace(*refCell.RawValue)
+				if f, err := strconv.ParseFloat(raw, 64); err == nil {
+					values = append(values, f)
+				} else {
+					values = append(values, raw)
+				}
+			}
+		}
+		return utils.CellRange{Values: values, Rows: rows, Cols: cols}, nil
+	}
+}
+
+// registerValidationDependencies records that the rule at (row, col) reads
+// every cell ref it mentions, replacing whatever it previously depended on.
+// It refuses (without mutating the graph) when doing so would close a
+// dependency cycle, the same guarantee the cross-sheet formula evaluator's
+// DependencyGraph gives recalculation.
+func registerValidationDependencies(row, col int32, rule string) error {
+	owner := [2]int{int(row), int(col)}
+	ownerKey := scopeKey(owner)
+
+	newDeps := make(map[[2]int]bool)
+	for _, ref := range extractValidationRefs(rule) {
+		if r, c, ok := parseValidationRef(ref); ok {
+			newDeps[[2]int{int(r), int(c)}] = true
+		}
+	}
+
+	reachable := validationReachableFrom(owner)
+	for dep := range newDeps {
+		if dep == owner || reachable[dep] {
+			return fmt.Errorf("circular reference: %s%d's rule would eventually depend on itself", utils.ColumnName(col), row)
+		}
+	}
+
+	clearValidationDependencies(owner)
+	for dep := range newDeps {
+		depKey := scopeKey(dep)
+		if validationDependents[depKey] == nil {
+			validationDependents[depKey] = make(map[sheetCellKey]bool)
+		}
+		validationDependents[depKey][ownerKey] = true
+	}
+	return nil
+}
+
+// clearValidationDependencies removes owner from every cell's dependent set,
+// e.g. before re-registering its rule's references or when its rule is
+// deleted outright.
+func clearValidationDependencies(owner [2]int) {
+	ownerKey := scopeKey(owner)
+	for dep, owners := range validationDependents {
+		delete(owners, ownerKey)
+		if len(owners) == 0 {
+			delete(validationDependents, dep)
+		}
+	}
+}
+
+// validationReachableFrom returns every cell key reachable from start (on
+// the currently active sheet) by following validationDependents edges
+// forward ("start changes -> ... -> this cell re-validates"), used to
+// detect a would-be cycle before it's created.
+func validationReachableFrom(start [2]int) map[[2]int]bool {
+	visited := make(map[[2]int]bool)
+	queue := [][2]int{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for dependentKey := range validationDependents[scopeKey(node)] {
+			dependent := dependentKey.coord
+			if !visited[dependent] {
+				visited[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	return visited
+}
+
+// RevalidateDependents re-checks every cell whose rule reads (row, col) on
+// the currently active sheet after its value changes, updating invalidCells
+// so a later render can mark cells that now fail their own rule. It returns
+// the keys that were re-checked so the caller can refresh just those cells.
+func RevalidateDependents(row, col int32, globalData map[[2]int]*cell.Cell) [][2]int {
+	key := scopeKey([2]int{int(row), int(col)})
+	checked := make([][2]int, 0, len(validationDependents[key]))
+
+	for depKey := range validationDependents[key] {
+		dep := depKey.coord
+		checked = append(checked, dep)
+
+		depCell, exists := globalData[dep]
+		if !exists || depCell.RawValue == nil {
+			delete(invalidCells, depKey)
+			continue
+		}
+		if ok, _ := CheckValidationRule(depCell, *depCell.RawValue, globalData); ok {
+			delete(invalidCells, depKey)
+		} else {
+			invalidCells[depKey] = true
+		}
+	}
+	return checked
+}
+
+// ValidateImportedRules checks every cell in globalData that carries a
+// Valrule against its own current value, for rules that arrived from an
+// import (e.g. fileop's XLSX dataValidation reader) rather than through
+// ShowValidationRuleDialog, so a conversion fileop couldn't fully resolve
+// doesn't fail silently. It logs each failure to stderr and returns the A1
+// references of every cell whose imported rule didn't validate.
+func ValidateImportedRules(globalData map[[2]int]*cell.Cell) []string {
+	var failed []string
+	for key, cellData := range globalData {
+		if cellData.Valrule == nil || strings.TrimSpace(*cellData.Valrule) == "" {
+			continue
+		}
+		if cellData.RawValue == nil || strings.TrimSpace(*cellData.RawValue) == "" {
+			continue
+		}
+		if ok, reason := CheckValidationRule(cellData, *cellData.RawValue, globalData); !ok {
+			ref := fmt.Sprintf("%s%d", columnIndexToLetterForValidation(int32(key[1])), key[0])
+			fmt.Fprintf(os.Stderr, "imported validation rule failed for %s: %s\n", ref, reason)
+			failed = append(failed, ref)
+		}
+	}
+	return failed
+}
+
+// columnIndexToLetterForValidation is a local 1-indexed column-to-letter
+// helper; fileop.columnIndexToLetter isn't reachable from here since fileop
+// doesn't export it and ui only uses fileop's exported types.
+func columnIndexToLetterForValidation(col int32) string {
+	var letters []byte
+	for col > 0 {
+		col--
+		letters = append([]byte{byte('A' + col%26)}, letters...)
+		col /= 26
+	}
+	return string(letters)
+}
+
+// markInvalidCell flags tvCell with a visible marker when key is currently
+// in invalidCells, i.e. its value no longer satisfies its own rule because a
+// cell it references changed.
+func markInvalidCell(tvCell *tview.TableCell, key [2]int) *tview.TableCell {
+	if !invalidCells[scopeKey(key)] {
+		return tvCell
+	}
+	return tvCell.SetBackgroundColor(tcell.ColorDarkRed)
+}
+
+// markListValidationIndicator appends a small dropdown-arrow affordance to
+// tvCell's text when cellData's rule is list-shaped (static or range-backed),
+// the same cosmetic cue Excel gives a cell with an in-cell dropdown.
+func markListValidationIndicator(tvCell *tview.TableCell, cellData *cell.Cell) *tview.TableCell {
+	if cellData.Valrule == nil || !isListRule(strings.TrimSpace(*cellData.Valrule)) {
+		return tvCell
+	}
+	return tvCell.SetText(tvCell.Text + " ▾")
+}
+
+// ShowListValidationDropdown replaces the cell editor with a tview.DropDown
+// populated from cellData's list rule (resolving a source range's live
+// values on open), for use wherever the app currently opens a plain text
+// editor over a cell. onPick is called with the chosen value when the user
+// confirms a selection.
+func ShowListValidationDropdown(app *tview.Application, returnTo tview.Primitive, cellData *cell.Cell, globalData map[[2]int]*cell.Cell, onPick func(value string)) bool {
+	if cellData.Valrule == nil {
+		return false
+	}
+	values, ok := listValuesFromRule(strings.TrimSpace(*cellData.Valrule), globalData)
+	if !ok {
+		return false
+	}
+
+	dropdown := tview.NewDropDown().
+		SetLabel("Select value: ").
+		SetOptions(values, func(text string, index int) {
+			onPick(text)
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+		})
+	dropdown.SetBorder(true).
+		SetTitle(" List Validation ").
+		SetBorderColor(tcell.ColorLightBlue)
+
+	dropdown.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			return nil
+		}
+		return event
+	})
+
+	app.SetRoot(dropdown, true).SetFocus(dropdown)
+	return true
+}
+
 // ValidationPreset represents a predefined validation type
 type ValidationPreset struct {
 	Name        string
 	Description string
 	BuildRule   func(params map[string]string) string
 	Fields      []ValidationField
+	// Engine selects which backend CheckValidationRule/ValidateValidationRule
+	// run the built rule through - one of the ValidationEngine* constants.
+	// The zero value, ValidationEngineGovaluate, is every existing preset's
+	// backend and needs no explicit assignment.
+	Engine string
 }
 
 type ValidationField struct {
@@ -54,7 +513,7 @@ func GetValidationPresets() []ValidationPreset {
 				{Name: "max", Label: "Maximum:", Type: "number", Placeholder: "100"},
 			},
 			BuildRule: func(params map[string]string) string {
-				return fmt.Sprintf("THIS >= %s && THIS <= %s && THIS == FLOOR(THIS)", 
+				return fmt.Sprintf("THIS >= %s && THIS <= %s && THIS == FLOOR(THIS)",
 					params["min"], params["max"])
 			},
 		},
@@ -117,7 +576,7 @@ func GetValidationPresets() []ValidationPreset {
 				{Name: "max", Label: "Maximum length:", Type: "number", Placeholder: "50"},
 			},
 			BuildRule: func(params map[string]string) string {
-				return fmt.Sprintf("LEN(THIS) >= %s && LEN(THIS) <= %s", 
+				return fmt.Sprintf("LEN(THIS) >= %s && LEN(THIS) <= %s",
 					params["min"], params["max"])
 			},
 		},
@@ -143,7 +602,7 @@ func GetValidationPresets() []ValidationPreset {
 			Name:        "List - Allowed Values",
 			Description: "Value must be one of the specified options",
 			Fields: []ValidationField{
-				{Name: "list", Label: "Allowed values (comma-separated):", Type: "text", 
+				{Name: "list", Label: "Allowed values (comma-separated):", Type: "text",
 					Placeholder: "Yes,No,Maybe"},
 			},
 			BuildRule: func(params map[string]string) string {
@@ -156,6 +615,16 @@ func GetValidationPresets() []ValidationPreset {
 				return strings.Join(conditions, " || ")
 			},
 		},
+		{
+			Name:        "List - From Cell Range",
+			Description: "Value must match one of the values currently in a cell range (e.g. A1:A50)",
+			Fields: []ValidationField{
+				{Name: "range", Label: "Source range:", Type: "text", Placeholder: "A1:A50"},
+			},
+			BuildRule: func(params map[string]string) string {
+				return fmt.Sprintf("LIST_RANGE(%s)", strings.ToUpper(strings.TrimSpace(params["range"])))
+			},
+		},
 		{
 			Name:        "Email Format",
 			Description: "Value must be a valid email format",
@@ -180,41 +649,78 @@ func GetValidationPresets() []ValidationPreset {
 				return "THIS >= 0 && THIS <= 100"
 			},
 		},
+		{
+			Name:        "Regex Match",
+			Description: "Value must match a regular expression - practical for postal codes, SKUs, phone numbers",
+			Engine:      ValidationEngineRegex,
+			Fields: []ValidationField{
+				{Name: "pattern", Label: "Pattern:", Type: "text", Placeholder: "^[A-Z]{2}\\d{4}$"},
+			},
+			BuildRule: func(params map[string]string) string {
+				escaped := strings.ReplaceAll(params["pattern"], `"`, `\"`)
+				return fmt.Sprintf(`RegexMatch("%s")`, escaped)
+			},
+		},
+		{
+			Name:        "CEL Expression",
+			Description: "Write a typed CEL expression using 'THIS' to refer to the cell value",
+			Engine:      ValidationEngineCEL,
+			Fields: []ValidationField{
+				{Name: "expression", Label: "CEL expression:", Type: "text", Placeholder: "THIS.size() > 0"},
+			},
+			BuildRule: func(params map[string]string) string {
+				return params["expression"]
+			},
+		},
 	}
 }
 
-// ValidateValidationRule checks if a validation rule is syntactically correct
-func ValidateValidationRule(ruleText string, cellData *cell.Cell) bool {
+// ValidateValidationRule checks if a validation rule is syntactically
+// correct, dispatching to cellData's engine (ValEngine) the same way
+// CheckValidationRule does. For the default govaluate engine, that means
+// every identifier is THIS, a cell reference or range resolvable against
+// globalData, or a function GovalFuncs understands.
+func ValidateValidationRule(ruleText string, cellData *cell.Cell, globalData map[[2]int]*cell.Cell) bool {
 	if strings.TrimSpace(ruleText) == "" {
 		return true
 	}
 
-	upperRule := strings.ToUpper(ruleText)
-	matches := validationCellRefRegex.FindAllString(upperRule, -1)
+	if _, ok := parseListRangeRule(strings.TrimSpace(ruleText)); ok {
+		return true
+	}
 
-	for _, match := range matches {
-		if match != "THIS" {
-			return false
-		}
+	switch validationEngineOf(cellData) {
+	case ValidationEngineRegex:
+		return validateRegexRule(ruleText) == nil
+	case ValidationEngineCEL:
+		return validateCELRule(ruleText) == nil
 	}
 
-	testRule := strings.ReplaceAll(upperRule, "THIS", "5")
+	testRule := strings.ReplaceAll(strings.ToUpper(ruleText), "THIS", "5")
+	testRule = substituteValidationRefs(testRule, globalData)
+	testRule = utils.ExpandRangeRefs(testRule)
 
-	_, err := govaluate.NewEvaluableExpressionWithFunctions(testRule, utils.GovalFuncs())
-	if err != nil {
-		return false
-	}
+	functions := utils.GovalFuncsWithRanges(validationRangeResolver(globalData))
+	_, err := govaluate.NewEvaluableExpressionWithFunctions(testRule, functions)
+	return err == nil
+}
 
-	return true
+// validationEngineOf reads cellData's stored engine choice, defaulting to
+// govaluate for cells that predate ValEngine or never set it.
+func validationEngineOf(cellData *cell.Cell) string {
+	if cellData == nil || cellData.ValEngine == nil {
+		return ValidationEngineGovaluate
+	}
+	return *cellData.ValEngine
 }
 
 // EnforceValidationOnEdit checks validation before saving a cell edit
-func EnforceValidationOnEdit(app *tview.Application, returnTo tview.Primitive, cellData *cell.Cell, newValue string) bool {
+func EnforceValidationOnEdit(app *tview.Application, returnTo tview.Primitive, cellData *cell.Cell, newValue string, globalData map[[2]int]*cell.Cell) bool {
 	if strings.TrimSpace(newValue) == "" {
 		return true
 	}
 
-	isValid, errMsg := CheckValidationRule(cellData, newValue)
+	isValid, errMsg := CheckValidationRule(cellData, newValue, globalData)
 
 	if !isValid {
 		displayMsg := errMsg
@@ -226,7 +732,7 @@ func EnforceValidationOnEdit(app *tview.Application, returnTo tview.Primitive, c
 			SetText(fmt.Sprintf("Validation Failed!\n\n%s\n\nValidation Rule:\n%s", displayMsg, *cellData.Valrule)).
 			AddButtons([]string{"OK"}).
 			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				app.SetRoot(returnTo, true).SetFocus(returnTo)
+				navigation.PopModal(app)
 			})
 
 		modal.SetBackgroundColor(tcell.ColorDarkRed).
@@ -234,7 +740,7 @@ func EnforceValidationOnEdit(app *tview.Application, returnTo tview.Primitive, c
 		modal.SetButtonBackgroundColor(tcell.ColorDarkRed).
 			SetButtonTextColor(tcell.ColorWhite)
 
-		app.SetRoot(modal, true).SetFocus(modal)
+		navigation.PushModal(app, modal, returnTo, returnTo)
 		return false
 	}
 
@@ -246,7 +752,7 @@ func showValidationErrorModal(app *tview.Application, container *tview.Flex, ret
 		SetText(message).
 		AddButtons([]string{"OK"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.SetRoot(container, true).SetFocus(returnTo)
+			navigation.PopModal(app)
 		})
 
 	modal.SetBackgroundColor(tcell.ColorDarkRed).
@@ -254,10 +760,13 @@ func showValidationErrorModal(app *tview.Application, container *tview.Flex, ret
 	modal.SetButtonBackgroundColor(tcell.ColorDarkRed).
 		SetButtonTextColor(tcell.ColorWhite)
 
-	app.SetRoot(modal, true).SetFocus(modal)
+	navigation.PushModal(app, modal, container, returnTo)
 }
 
-func CheckValidationRule(cellData *cell.Cell, newValue string) (bool, string) {
+// CheckValidationRule checks newValue against cellData's rule, resolving any
+// cell reference or range the rule mentions (e.g. "THIS > A1 && THIS <
+// SUM(B1:B10)") from globalData.
+func CheckValidationRule(cellData *cell.Cell, newValue string, globalData map[[2]int]*cell.Cell) (bool, string) {
 	if cellData.Valrule == nil || strings.TrimSpace(*cellData.Valrule) == "" {
 		return true, ""
 	}
@@ -266,6 +775,12 @@ func CheckValidationRule(cellData *cell.Cell, newValue string) (bool, string) {
 		return true, ""
 	}
 
+	if engine := validationEngineOf(cellData); engine == ValidationEngineRegex {
+		return checkRegexRule(*cellData.Valrule, newValue, cellData)
+	} else if engine == ValidationEngineCEL {
+		return checkCELRule(*cellData.Valrule, newValue, cellData)
+	}
+
 	var testValue any
 
 	cellDataTypeAux := *cellData.Type
@@ -288,6 +803,9 @@ func CheckValidationRule(cellData *cell.Cell, newValue string) (bool, string) {
 	}
 
 	rule := strings.TrimSpace(*cellData.Valrule)
+	if rangeRef, ok := parseListRangeRule(rule); ok {
+		rule = buildListRangeRule(rangeRef, globalData)
+	}
 	upperRule := strings.ToUpper(rule)
 
 	var replacementValue string
@@ -300,8 +818,11 @@ func CheckValidationRule(cellData *cell.Cell, newValue string) (bool, string) {
 	}
 
 	evaluableRule := strings.ReplaceAll(upperRule, "THIS", replacementValue)
+	evaluableRule = substituteValidationRefs(evaluableRule, globalData)
+	evaluableRule = utils.ExpandRangeRefs(evaluableRule)
 
-	expr, err := govaluate.NewEvaluableExpressionWithFunctions(evaluableRule, utils.GovalFuncs())
+	functions := utils.GovalFuncsWithRanges(validationRangeResolver(globalData))
+	expr, err := govaluate.NewEvaluableExpressionWithFunctions(evaluableRule, functions)
 	if err != nil {
 		return false, fmt.Sprintf("Invalid validation rule: %s", err.Error())
 	}
@@ -333,13 +854,17 @@ func detectPresetFromRule(rule string) (int, map[string]string) {
 	}
 
 	presets := GetValidationPresets()
-	
+
 	for i, preset := range presets {
 		if preset.Name == "Custom" {
 			continue
 		}
 
 		switch preset.Name {
+		case "Regex Match":
+			if pattern, ok := parseRegexMatchRule(rule); ok {
+				return i, map[string]string{"pattern": pattern}
+			}
 		case "Whole Number - Between":
 			re := regexp.MustCompile(`THIS >= ([\d.]+) && THIS <= ([\d.]+) && THIS == FLOOR\(THIS\)`)
 			if matches := re.FindStringSubmatch(rule); matches != nil {
@@ -398,6 +923,10 @@ func detectPresetFromRule(rule string) (int, map[string]string) {
 					return i, map[string]string{"list": strings.Join(values, ",")}
 				}
 			}
+		case "List - From Cell Range":
+			if rangeRef, ok := parseListRangeRule(rule); ok {
+				return i, map[string]string{"range": rangeRef}
+			}
 		case "Positive Numbers Only":
 			if rule == "THIS > 0" {
 				return i, nil
@@ -409,7 +938,7 @@ func detectPresetFromRule(rule string) (int, map[string]string) {
 		}
 	}
 
-	return 0, nil 
+	return 0, nil
 }
 
 // ShowValidationRuleDialog displays the enhanced validation rule editor with presets
@@ -502,7 +1031,7 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 					}
 				}
 			}
-			
+
 			allFilled := true
 			for _, field := range preset.Fields {
 				if params[field.Name] == "" {
@@ -510,18 +1039,24 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 					break
 				}
 			}
-			
+
 			if !allFilled {
 				showValidationErrorModal(app, container, container, "Please fill in all required fields before applying.")
 				return
 			}
-			
+
 			finalRule = preset.BuildRule(params)
 		}
 
 		customMsg := strings.TrimSpace(customMessageInput.GetText())
 		cellData.Valrulemsg = &customMsg
 
+		engine := preset.Engine
+		if preset.Name == "Custom" {
+			engine = ValidationEngineGovaluate
+		}
+		cellData.ValEngine = &engine
+
 		saveRule(app, table, cellData, finalRule, row, col, buttonForm, returnTo, focus, container, globalData, globalViewport)
 	})
 
@@ -541,7 +1076,7 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 		currentFormItemsInDynamic = nil
 		preset := presets[presetIdx]
 
-		previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[gray]Empty cells are always allowed, validation only applies when entering a value.[-]", 
+		previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[gray]Empty cells are always allowed, validation only applies when entering a value.[-]",
 			preset.Name, preset.Description))
 
 		if preset.Name == "Custom" {
@@ -558,7 +1093,7 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 			//		items = append(items, item)
 			//	}
 			container.Clear()
-				container.AddItem(presetDropdown, 0, 1, false).
+			container.AddItem(presetDropdown, 0, 1, false).
 				AddItem(previewText, 0, 2, false).
 				AddItem(customRuleArea, 0, 5, true).
 				AddItem(customMessageInput, 0, 1, false).
@@ -579,16 +1114,16 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 					SetLabel(field.Label).
 					SetPlaceholder(field.Placeholder).
 					SetFieldWidth(30)
-				
+
 				if detectedParams != nil && detectedPresetIdx == presetIdx {
 					if val, ok := detectedParams[field.Name]; ok {
 						inputField.SetText(val)
 					}
 				}
-				
+
 				dynamicForm.AddFormItem(inputField)
 				currentFormItemsInDynamic = append(currentFormItemsInDynamic, inputField)
-				
+
 				idx := i
 				inputField.SetChangedFunc(func(text string) {
 					params := make(map[string]string)
@@ -598,7 +1133,7 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 							params[preset.Fields[j].Name] = fi.GetText()
 						}
 					}
-					
+
 					allFilled := true
 					for _, f := range preset.Fields {
 						if params[f.Name] == "" {
@@ -606,23 +1141,23 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 							break
 						}
 					}
-					
+
 					if allFilled {
 						rule := preset.BuildRule(params)
-						previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[green]Generated Rule:[white]\n%s\n\n[gray]Empty cells are always allowed.[-]", 
-							preset.Name, preset.Description, rule))
+						previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[green]Generated Rule:[white]\n%s%s\n\n[gray]Empty cells are always allowed.[-]",
+							preset.Name, preset.Description, rule, validationEngineIssues(preset, rule)))
 					} else {
-						previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[gray]Fill in all fields to see the generated rule.\nEmpty cells are always allowed.[-]", 
+						previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[gray]Fill in all fields to see the generated rule.\nEmpty cells are always allowed.[-]",
 							preset.Name, preset.Description))
 					}
-					_ = idx 
+					_ = idx
 				})
 			}
-			
+
 			if detectedParams != nil && detectedPresetIdx == presetIdx && len(preset.Fields) > 0 {
 				rule := preset.BuildRule(detectedParams)
-				previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[green]Current Rule:[white]\n%s\n\n[gray]Empty cells are always allowed.[-]", 
-					preset.Name, preset.Description, rule))
+				previewText.SetText(fmt.Sprintf("[yellow]%s[white]\n\n%s\n\n[green]Current Rule:[white]\n%s%s\n\n[gray]Empty cells are always allowed.[-]",
+					preset.Name, preset.Description, rule, validationEngineIssues(preset, rule)))
 			}
 		}
 	}
@@ -640,16 +1175,15 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 
 	updateDynamicForm(detectedPresetIdx)
 
-
 	container.SetBorder(true).
 		SetTitle(fmt.Sprintf(" Data Validation - %s%d  •  Ctrl+←/→ to navigate  •  Esc to cancel ", utils.ColumnName(col), row)).
 		SetBorderColor(tcell.ColorYellow)
 
 	getFocusablePrimitives := func() []tview.Primitive {
 		focusable := []tview.Primitive{}
-		
+
 		focusable = append(focusable, presetDropdown)
-		
+
 		for i := 0; i < container.GetItemCount(); i++ {
 			item := container.GetItem(i)
 			if item == customRuleArea {
@@ -660,7 +1194,7 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 				break
 			}
 		}
-		
+
 		focusable = append(focusable, customMessageInput)
 		focusable = append(focusable, buttonForm)
 
@@ -676,7 +1210,7 @@ func ShowValidationRuleDialog(app *tview.Application, table *tview.Table, return
 		}
 		if event.Modifiers()&tcell.ModCtrl != 0 {
 			focusables := getFocusablePrimitives()
-			
+
 			if event.Key() == tcell.KeyRight {
 				currentPrim++
 				currentPrim %= len(focusables)
@@ -704,29 +1238,46 @@ func deleteRule(cellData *cell.Cell, table *tview.Table, row, col int32, globalD
 
 	key := [2]int{int(row), int(col)}
 	globalData[key] = cellData
+	clearValidationDependencies(key)
+	delete(invalidCells, scopeKey(key))
 
 	if globalViewport.IsVisible(row, col) {
 		visualR, visualC := globalViewport.ToRelative(row, col)
-		table.SetCell(int(visualR), int(visualC), cellData.ToTViewCell())
+		table.SetCell(int(visualR), int(visualC), markListValidationIndicator(cellData.ToTViewCell(), cellData))
 	}
 }
 
 func saveRule(app *tview.Application, table *tview.Table, cellData *cell.Cell, ruleText string, row, col int32, form tview.Primitive, returnTo tview.Primitive, focus tview.Primitive, container *tview.Flex, globalData map[[2]int]*cell.Cell, globalViewport *utils.Viewport) {
 	ruleText = strings.TrimSpace(ruleText)
 
-	if ruleText == "" || ValidateValidationRule(ruleText, cellData) {
-		cellData.Valrule = &ruleText
+	if ruleText != "" && !ValidateValidationRule(ruleText, cellData, globalData) {
+		showValidationErrorModal(app, container, form, "Invalid validation rule!\n\nMake sure:\n- Cell references (e.g. A1, B2:B10) resolve correctly\n- The syntax is correct\n- The rule returns true/false")
+		return
+	}
 
-		key := [2]int{int(row), int(col)}
-		globalData[key] = cellData
+	key := [2]int{int(row), int(col)}
 
-		if globalViewport.IsVisible(row, col) {
-			visualR, visualC := globalViewport.ToRelative(row, col)
-			table.SetCell(int(visualR), int(visualC), cellData.ToTViewCell())
+	if err := registerValidationDependencies(row, col, ruleText); err != nil {
+		showValidationErrorModal(app, container, form, err.Error())
+		return
+	}
+
+	cellData.Valrule = &ruleText
+	globalData[key] = cellData
+
+	if cellData.RawValue != nil {
+		if ok, _ := CheckValidationRule(cellData, *cellData.RawValue, globalData); ok {
+			delete(invalidCells, scopeKey(key))
+		} else {
+			invalidCells[scopeKey(key)] = true
 		}
+	}
 
-		app.SetRoot(returnTo, true).SetFocus(focus)
-	} else {
-		showValidationErrorModal(app, container, form, "Invalid validation rule!\n\nMake sure:\n- You use 'THIS' instead of cell references (e.g., A1)\n- The syntax is correct\n- The rule returns true/false")
+	if globalViewport.IsVisible(row, col) {
+		visualR, visualC := globalViewport.ToRelative(row, col)
+		tvCell := markListValidationIndicator(markInvalidCell(cellData.ToTViewCell(), key), cellData)
+		table.SetCell(int(visualR), int(visualC), tvCell)
 	}
+
+	app.SetRoot(returnTo, true).SetFocus(focus)
 }
@@ -1,6 +1,8 @@
 package datavalidation
 
 import (
+	"gosheet/internal/services/ui/navigation"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -10,7 +12,7 @@ func showValidationErrorModal(app *tview.Application, container *tview.Flex, ret
 		SetText(message).
 		AddButtons([]string{"OK"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.SetRoot(container, true).SetFocus(returnTo)
+			navigation.PopModal(app)
 		})
 
 	modal.SetBackgroundColor(tcell.ColorDarkRed).
@@ -18,5 +20,5 @@ func showValidationErrorModal(app *tview.Application, container *tview.Flex, ret
 	modal.SetButtonBackgroundColor(tcell.ColorDarkRed).
 		SetButtonTextColor(tcell.ColorWhite)
 
-	app.SetRoot(modal, true).SetFocus(modal)
+	navigation.PushModal(app, modal, container, returnTo)
 }
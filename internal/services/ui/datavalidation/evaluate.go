@@ -0,0 +1,111 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// evaluate.go evaluates a Validator's rules on commit and drives the
+// Stop/Warning/Info presentation for the outcome.
+
+package datavalidation
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var (
+	regexCacheMu sync.Mutex
+	regexCache   = map[string]*regexp.Regexp{}
+)
+
+// regexpCompileCache compiles and memoizes patterns used by Regex rules so
+// repeated commits to the same cell don't recompile on every keystroke.
+func regexpCompileCache(pattern string) (*regexp.Regexp, error) {
+	regexCacheMu.Lock()
+	defer regexCacheMu.Unlock()
+
+	if re, ok := regexCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache[pattern] = re
+	return re, nil
+}
+
+// Outcome is the result of running a Validator's rules against a value.
+type Outcome struct {
+	Passed  bool
+	Style   Style
+	Message string
+}
+
+// EvaluateOnCommit runs every rule attached to v against newValue, stopping
+// at the first failing rule. An empty value always passes, matching the
+// existing "empty cells are always allowed" behavior.
+func EvaluateOnCommit(v *Validator, newValue string, ctx *EvalContext) Outcome {
+	if v == nil {
+		return Outcome{Passed: true}
+	}
+	if strings.TrimSpace(newValue) == "" {
+		return Outcome{Passed: true}
+	}
+
+	for _, rule := range v.Rules {
+		ok, err := rule.Check(newValue, ctx)
+		if err != nil {
+			return Outcome{Passed: false, Style: StyleStop, Message: err.Error()}
+		}
+		if !ok {
+			return Outcome{Passed: false, Style: rule.RuleStyle(), Message: rule.Message()}
+		}
+	}
+
+	return Outcome{Passed: true}
+}
+
+// HandleOutcome reacts to a failed Outcome according to its Style: Stop
+// reuses the existing rejection modal, Warning asks the user whether to
+// accept the value anyway, and Info simply notifies without blocking the
+// commit. commit is invoked when the value should be accepted.
+func HandleOutcome(app *tview.Application, container *tview.Flex, returnTo tview.Primitive, outcome Outcome, commit func()) {
+	if outcome.Passed {
+		commit()
+		return
+	}
+
+	switch outcome.Style {
+	case StyleWarning:
+		modal := tview.NewModal().
+			SetText(outcome.Message + "\n\nAccept this value anyway?").
+			AddButtons([]string{"Yes", "No"}).
+			SetDoneFunc(func(_ int, buttonLabel string) {
+				if buttonLabel == "Yes" {
+					commit()
+				}
+				app.SetRoot(returnTo, true).SetFocus(returnTo)
+			})
+		modal.SetBackgroundColor(tcell.ColorDarkGoldenrod).
+			SetBorderColor(tcell.ColorYellow)
+		app.SetRoot(modal, true).SetFocus(modal)
+
+	case StyleInfo:
+		modal := tview.NewModal().
+			SetText(outcome.Message).
+			AddButtons([]string{"OK"}).
+			SetDoneFunc(func(_ int, _ string) {
+				commit()
+				app.SetRoot(returnTo, true).SetFocus(returnTo)
+			})
+		app.SetRoot(modal, true).SetFocus(modal)
+
+	default: // StyleStop
+		showValidationErrorModal(app, container, returnTo, "Validation Failed!\n\n"+outcome.Message)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// listdropdown.go renders an in-cell dropdown for List rules when the
+// validated cell is edited.
+
+package datavalidation
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ListRuleFor returns the List rule attached to v, if any, so the cell
+// editor can decide whether to render a dropdown instead of a plain input.
+func ListRuleFor(v *Validator) (*List, bool) {
+	if v == nil {
+		return nil, false
+	}
+	for _, rule := range v.Rules {
+		if list, ok := rule.(*List); ok {
+			return list, true
+		}
+	}
+	return nil, false
+}
+
+// ShowListDropdown replaces the cell editor with a tview.DropDown populated
+// from the List rule's allowed values (static or range-resolved). onPick is
+// called with the chosen value when the user confirms a selection.
+func ShowListDropdown(app *tview.Application, returnTo tview.Primitive, rule *List, ctx *EvalContext, onPick func(value string)) {
+	values := rule.Values
+	if rule.SourceRange != "" && ctx != nil && ctx.ResolveRef != nil {
+		if resolved, ok := ctx.ResolveRef(rule.SourceRange); ok {
+			values = splitListValues(resolved)
+		}
+	}
+
+	dropdown := tview.NewDropDown().
+		SetLabel("Select value: ").
+		SetOptions(values, func(text string, index int) {
+			onPick(text)
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+		})
+	dropdown.SetBorder(true).
+		SetTitle(" List Validation ").
+		SetBorderColor(tcell.ColorLightBlue)
+
+	dropdown.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			return nil
+		}
+		return event
+	})
+
+	app.SetRoot(dropdown, true).SetFocus(dropdown)
+}
+
+func splitListValues(raw string) []string {
+	var values []string
+	current := ""
+	for _, r := range raw {
+		if r == ',' {
+			values = append(values, current)
+			current = ""
+			continue
+		}
+		current += string(r)
+	}
+	if current != "" {
+		values = append(values, current)
+	}
+	return values
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// rules.go defines the data validation rule engine: rule types, their
+// evaluation contract, and the per-cell/range Validator that stores them.
+
+package datavalidation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gosheet/internal/utils"
+
+	"github.com/Knetic/govaluate"
+)
+
+// Style controls how a failed Rule is surfaced to the user.
+type Style string
+
+const (
+	// StyleStop rejects the edit outright via the existing error modal.
+	StyleStop Style = "stop"
+	// StyleWarning asks the user to accept the value anyway via a Yes/No dialog.
+	StyleWarning Style = "warning"
+	// StyleInfo only informs the user; the edit is always accepted.
+	StyleInfo Style = "info"
+)
+
+// Rule is implemented by every concrete validation rule. Check receives the
+// raw text the user typed and reports whether it satisfies the rule.
+type Rule interface {
+	// Check evaluates newValue (and, for CustomFormula, the wider sheet via
+	// ctx) and returns whether the value passes.
+	Check(newValue string, ctx *EvalContext) (bool, error)
+	// Message returns the text shown when the rule rejects a value.
+	Message() string
+	// RuleStyle reports the Stop/Warning/Info behavior for this rule.
+	RuleStyle() Style
+}
+
+// EvalContext carries the state a rule may need beyond the raw input, such
+// as resolving a range for List rules or evaluating a CustomFormula.
+type EvalContext struct {
+	Row, Col   int32
+	ResolveRef func(cellRef string) (string, bool)
+	Funcs      map[string]govaluate.ExpressionFunction
+}
+
+// baseRule holds the fields shared by every concrete rule type.
+type baseRule struct {
+	Msg   string `json:"message"`
+	Style Style  `json:"style"`
+}
+
+func (b baseRule) Message() string  { return b.Msg }
+func (b baseRule) RuleStyle() Style { return b.Style }
+
+// WholeNumber requires the value to be an integer within [Min, Max].
+type WholeNumber struct {
+	baseRule
+	Min, Max float64
+}
+
+func (r *WholeNumber) Check(newValue string, _ *EvalContext) (bool, error) {
+	if strings.TrimSpace(newValue) == "" {
+		return true, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(newValue), 64)
+	if err != nil {
+		return false, nil
+	}
+	return f == float64(int64(f)) && f >= r.Min && f <= r.Max, nil
+}
+
+// Decimal requires the value to be a number within [Min, Max].
+type Decimal struct {
+	baseRule
+	Min, Max float64
+}
+
+func (r *Decimal) Check(newValue string, _ *EvalContext) (bool, error) {
+	if strings.TrimSpace(newValue) == "" {
+		return true, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(newValue), 64)
+	if err != nil {
+		return false, nil
+	}
+	return f >= r.Min && f <= r.Max, nil
+}
+
+// TextLength requires the text length to be within [Min, Max] runes.
+type TextLength struct {
+	baseRule
+	Min, Max int
+}
+
+func (r *TextLength) Check(newValue string, _ *EvalContext) (bool, error) {
+	n := len([]rune(newValue))
+	return n >= r.Min && n <= r.Max, nil
+}
+
+// List requires the value to be one of a fixed set, or one of the values
+// found in a sheet range (e.g. "Sheet1!A1:A10") when SourceRange is set.
+type List struct {
+	baseRule
+	Values      []string
+	SourceRange string
+}
+
+func (r *List) Check(newValue string, ctx *EvalContext) (bool, error) {
+	values := r.Values
+	if r.SourceRange != "" && ctx != nil && ctx.ResolveRef != nil {
+		if resolved, ok := ctx.ResolveRef(r.SourceRange); ok {
+			values = strings.Split(resolved, ",")
+		}
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(newValue)) {
+			return true, nil
+		}
+	}
+	return len(values) == 0, nil
+}
+
+// Date requires the value to parse as a date within [From, To].
+type Date struct {
+	baseRule
+	From, To time.Time
+	Layout   string
+}
+
+func (r *Date) Check(newValue string, _ *EvalContext) (bool, error) {
+	if strings.TrimSpace(newValue) == "" {
+		return true, nil
+	}
+	layout := r.Layout
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+	t, err := time.Parse(layout, strings.TrimSpace(newValue))
+	if err != nil {
+		return false, nil
+	}
+	return !t.Before(r.From) && !t.After(r.To), nil
+}
+
+// Time requires the value to parse as a time of day within [From, To].
+type Time struct {
+	baseRule
+	From, To time.Duration
+}
+
+func (r *Time) Check(newValue string, _ *EvalContext) (bool, error) {
+	if strings.TrimSpace(newValue) == "" {
+		return true, nil
+	}
+	t, err := time.Parse("15:04", strings.TrimSpace(newValue))
+	if err != nil {
+		return false, nil
+	}
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	return d >= r.From && d <= r.To, nil
+}
+
+// Regex requires the value to match a regular expression.
+type Regex struct {
+	baseRule
+	Pattern string
+}
+
+func (r *Regex) Check(newValue string, _ *EvalContext) (bool, error) {
+	re, err := regexpCompileCache(r.Pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regex rule: %w", err)
+	}
+	return re.MatchString(newValue), nil
+}
+
+// CustomFormula evaluates Expression against the shared formula engine,
+// substituting THIS for newValue, and treats a true boolean result as a
+// pass; a non-boolean result is an error.
+type CustomFormula struct {
+	baseRule
+	Expression string
+}
+
+func (r *CustomFormula) Check(newValue string, ctx *EvalContext) (bool, error) {
+	funcs := utils.GovalFuncs()
+	if ctx != nil && ctx.Funcs != nil {
+		funcs = ctx.Funcs
+	}
+
+	expr := strings.ToUpper(r.Expression)
+	var replacement string
+	if f, err := strconv.ParseFloat(strings.TrimSpace(newValue), 64); err == nil {
+		replacement = fmt.Sprintf("%v", f)
+	} else {
+		replacement = fmt.Sprintf("%q", newValue)
+	}
+	expr = strings.ReplaceAll(expr, "THIS", replacement)
+
+	evaluable, err := govaluate.NewEvaluableExpressionWithFunctions(expr, funcs)
+	if err != nil {
+		return false, err
+	}
+	result, err := evaluable.Evaluate(nil)
+	if err != nil {
+		return false, err
+	}
+	ok, isBool := result.(bool)
+	if !isBool {
+		return false, fmt.Errorf("custom formula must evaluate to true/false")
+	}
+	return ok, nil
+}
+
+// Validator attaches one or more Rules to a single cell or a range. No sheet
+// model or file format in this tree holds a Validator, so it is not saved
+// with the workbook.
+type Validator struct {
+	StartRow, StartCol int32
+	EndRow, EndCol     int32
+	Rules              []Rule
+}
+
+// Covers reports whether the validator applies to the given absolute cell.
+func (v *Validator) Covers(row, col int32) bool {
+	return row >= v.StartRow && row <= v.EndRow && col >= v.StartCol && col <= v.EndCol
+}
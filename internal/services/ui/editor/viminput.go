@@ -0,0 +1,373 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// viminput.go implements VimInputField, a vim-modal wrapper around
+// tview.InputField for the cell editor, following the same
+// "primitive wraps a primitive" pattern advanced input widgets use.
+
+package editor
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Mode is one of the three vim editing modes VimInputField supports.
+type Mode int
+
+const (
+	ModeNormal Mode = iota
+	ModeInsert
+	ModeVisual
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeInsert:
+		return "INSERT"
+	case ModeVisual:
+		return "VISUAL"
+	default:
+		return "NORMAL"
+	}
+}
+
+// repeatable captures the last change so '.' can replay it.
+type repeatable struct {
+	operator rune
+	motion   string
+	count    int
+}
+
+// VimInputField wraps a tview.InputField with vim-style normal/insert/visual
+// modes, motions, operators, counts, and a '.' repeat register. The mode
+// indicator is pushed to onModeChange so callers can wire it into a shared
+// status bar.
+type VimInputField struct {
+	*tview.InputField
+
+	mode      Mode
+	cursor    int
+	visualCol int // anchor column when entering visual mode
+
+	pendingCount    string
+	pendingOperator rune
+	lastChange      *repeatable
+
+	onModeChange func(Mode)
+	onReject     func(cursor int) // called to reposition the cursor after a validation rejection
+}
+
+// NewVimInputField creates a VimInputField starting in normal mode.
+func NewVimInputField() *VimInputField {
+	v := &VimInputField{
+		InputField: tview.NewInputField(),
+		mode:       ModeNormal,
+	}
+	v.InputField.SetInputCapture(v.handleKey)
+	return v
+}
+
+// SetOnModeChange registers a callback invoked every time the mode changes,
+// e.g. to update a status bar mode indicator.
+func (v *VimInputField) SetOnModeChange(fn func(Mode)) *VimInputField {
+	v.onModeChange = fn
+	return v
+}
+
+// Mode reports the current editing mode.
+func (v *VimInputField) Mode() Mode {
+	return v.mode
+}
+
+// RejectAt keeps the field open in normal mode with the cursor positioned at
+// the offending token (e.g. after a data validation rejection), instead of
+// dismissing to a modal.
+func (v *VimInputField) RejectAt(cursor int) {
+	v.setMode(ModeNormal)
+	v.cursor = clamp(cursor, 0, len([]rune(v.GetText())))
+	if v.onReject != nil {
+		v.onReject(v.cursor)
+	}
+}
+
+func (v *VimInputField) setMode(m Mode) {
+	v.mode = m
+	if v.onModeChange != nil {
+		v.onModeChange(m)
+	}
+}
+
+func (v *VimInputField) text() []rune {
+	return []rune(v.GetText())
+}
+
+func (v *VimInputField) setText(runes []rune) {
+	v.SetText(string(runes))
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func (v *VimInputField) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch v.mode {
+	case ModeInsert:
+		return v.handleInsert(event)
+	default:
+		return v.handleNormalOrVisual(event)
+	}
+}
+
+func (v *VimInputField) handleInsert(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEscape {
+		v.cursor = clamp(v.cursor-1, 0, len(v.text()))
+		v.setMode(ModeNormal)
+		return nil
+	}
+	return event
+}
+
+func (v *VimInputField) handleNormalOrVisual(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEscape {
+		v.setMode(ModeNormal)
+		return nil
+	}
+
+	r := event.Rune()
+
+	if r >= '1' && r <= '9' || (r == '0' && v.pendingCount != "") {
+		v.pendingCount += string(r)
+		return nil
+	}
+
+	count := 1
+	if v.pendingCount != "" {
+		if n, err := strconv.Atoi(v.pendingCount); err == nil {
+			count = n
+		}
+		v.pendingCount = ""
+	}
+
+	if v.pendingOperator != 0 && r != v.pendingOperator {
+		v.applyPendingOperator(r, count)
+		return nil
+	}
+
+	switch r {
+	case 'i':
+		v.setMode(ModeInsert)
+	case 'a':
+		v.cursor = clamp(v.cursor+1, 0, len(v.text()))
+		v.setMode(ModeInsert)
+	case 'v':
+		v.visualCol = v.cursor
+		v.setMode(ModeVisual)
+	case 'h':
+		v.cursor = v.moveBy(-count)
+	case 'l':
+		v.cursor = v.moveBy(count)
+	case 'w':
+		v.cursor = v.motionWord(count, true)
+	case 'b':
+		v.cursor = v.motionWord(count, false)
+	case 'e':
+		v.cursor = v.motionWordEnd(count)
+	case '0':
+		v.cursor = 0
+	case '$':
+		v.cursor = clamp(len(v.text())-1, 0, len(v.text()))
+	case 'g':
+		v.cursor = 0
+	case 'G':
+		v.cursor = clamp(len(v.text())-1, 0, len(v.text()))
+	case 'x':
+		v.deleteRange(v.cursor, v.cursor+count)
+	case 'd', 'c', 'y':
+		v.pendingOperator = r
+	case 'p':
+		v.paste()
+	case '.':
+		v.repeatLast()
+	}
+
+	return nil
+}
+
+func (v *VimInputField) moveBy(delta int) int {
+	return clamp(v.cursor+delta, 0, maxInt(0, len(v.text())-1))
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// motionWord implements 'w'/'b': jump to the start of the next/previous
+// whitespace-delimited word, `count` times.
+func (v *VimInputField) motionWord(count int, forward bool) int {
+	text := v.text()
+	pos := v.cursor
+	for i := 0; i < count; i++ {
+		if forward {
+			pos = nextWordStart(text, pos)
+		} else {
+			pos = prevWordStart(text, pos)
+		}
+	}
+	return pos
+}
+
+// motionWordEnd implements 'e': jump to the end of the current/next word.
+func (v *VimInputField) motionWordEnd(count int) int {
+	text := v.text()
+	pos := v.cursor
+	for i := 0; i < count; i++ {
+		pos = nextWordEnd(text, pos)
+	}
+	return pos
+}
+
+func isSpace(r rune) bool { return r == ' ' || r == '\t' }
+
+func nextWordStart(text []rune, pos int) int {
+	n := len(text)
+	i := pos
+	for i < n && !isSpace(text[i]) {
+		i++
+	}
+	for i < n && isSpace(text[i]) {
+		i++
+	}
+	if i >= n {
+		return maxInt(0, n-1)
+	}
+	return i
+}
+
+func prevWordStart(text []rune, pos int) int {
+	i := pos - 1
+	for i > 0 && isSpace(text[i]) {
+		i--
+	}
+	for i > 0 && !isSpace(text[i-1]) {
+		i--
+	}
+	return maxInt(0, i)
+}
+
+func nextWordEnd(text []rune, pos int) int {
+	n := len(text)
+	i := pos + 1
+	for i < n && isSpace(text[i]) {
+		i++
+	}
+	for i < n-1 && !isSpace(text[i+1]) {
+		i++
+	}
+	return clamp(i, 0, maxInt(0, n-1))
+}
+
+// applyPendingOperator resolves an operator (d/c/y) followed by a motion
+// key, and records it as the last change so '.' can replay it.
+func (v *VimInputField) applyPendingOperator(motionKey rune, count int) {
+	op := v.pendingOperator
+	v.pendingOperator = 0
+
+	start := v.cursor
+	end := start
+	switch motionKey {
+	case 'w':
+		end = v.motionWord(count, true)
+	case 'b':
+		end = v.motionWord(count, false)
+		start, end = end, start
+	case 'e':
+		end = v.motionWordEnd(count) + 1
+	case '$':
+		end = len(v.text())
+	case '0':
+		end = start
+		start = 0
+	default:
+		return
+	}
+
+	switch op {
+	case 'd':
+		v.deleteRange(start, end)
+	case 'y':
+		v.yank(start, end)
+	case 'c':
+		v.deleteRange(start, end)
+		v.setMode(ModeInsert)
+	}
+
+	v.lastChange = &repeatable{operator: op, motion: string(motionKey), count: count}
+}
+
+var yankRegister []rune
+
+func (v *VimInputField) deleteRange(start, end int) {
+	text := v.text()
+	start = clamp(start, 0, len(text))
+	end = clamp(end, start, len(text))
+	yankRegister = append([]rune{}, text[start:end]...)
+	remaining := append(append([]rune{}, text[:start]...), text[end:]...)
+	v.setText(remaining)
+	v.cursor = clamp(start, 0, maxInt(0, len(remaining)-1))
+}
+
+func (v *VimInputField) yank(start, end int) {
+	text := v.text()
+	start = clamp(start, 0, len(text))
+	end = clamp(end, start, len(text))
+	yankRegister = append([]rune{}, text[start:end]...)
+}
+
+func (v *VimInputField) paste() {
+	if len(yankRegister) == 0 {
+		return
+	}
+	text := v.text()
+	pos := clamp(v.cursor+1, 0, len(text))
+	merged := append(append([]rune{}, text[:pos]...), append(append([]rune{}, yankRegister...), text[pos:]...)...)
+	v.setText(merged)
+	v.cursor = pos + len(yankRegister) - 1
+}
+
+// repeatLast replays the last operator+motion change ('.').
+func (v *VimInputField) repeatLast() {
+	if v.lastChange == nil {
+		return
+	}
+	change := v.lastChange
+	v.pendingOperator = change.operator
+	v.applyPendingOperator([]rune(change.motion)[0], change.count)
+}
+
+// ModeIndicator renders the current mode as a short, colored label
+// (e.g. "[green]-- INSERT --[-]") suitable for a status bar.
+func (v *VimInputField) ModeIndicator() string {
+	color := "blue"
+	switch v.mode {
+	case ModeInsert:
+		color = "green"
+	case ModeVisual:
+		color = "yellow"
+	}
+	return "[" + color + "::b]-- " + strings.ToUpper(v.mode.String()) + " --[-::-]"
+}
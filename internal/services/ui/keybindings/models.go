@@ -0,0 +1,130 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// models.go defines the Action enum and the Chord it can be bound to, plus
+// the built-in defaults every Registry falls back to.
+
+package keybindings
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Action identifies a user-triggerable command that can be rebound. New
+// actions should be appended, not inserted, so saved config files bound to
+// an older build keep referring to the same command by name.
+type Action string
+
+const (
+	SheetAdd       Action = "SheetAdd"
+	SheetRename    Action = "SheetRename"
+	SheetDelete    Action = "SheetDelete"
+	SheetMove      Action = "SheetMove"
+	SheetDuplicate Action = "SheetDuplicate"
+	SheetSwitch    Action = "SheetSwitch"
+	SheetOrganize  Action = "SheetOrganize"
+	Quit           Action = "Quit"
+	Save           Action = "Save"
+	Undo           Action = "Undo"
+	Redo           Action = "Redo"
+	WorkbookSwitch Action = "WorkbookSwitch"
+)
+
+// actionOrder lists every Action in the order it should appear on the
+// Keybindings screen.
+var actionOrder = []Action{
+	SheetAdd, SheetRename, SheetDelete, SheetMove, SheetDuplicate, SheetSwitch, SheetOrganize,
+	Save, Undo, Redo, Quit, WorkbookSwitch,
+}
+
+// actionLabels gives each Action a human-readable name for the UI.
+var actionLabels = map[Action]string{
+	SheetAdd:       "New Sheet",
+	SheetRename:    "Rename Sheet",
+	SheetDelete:    "Delete Sheet",
+	SheetMove:      "Move/Reorder Sheet",
+	SheetDuplicate: "Duplicate Sheet",
+	SheetSwitch:    "Switch To Sheet",
+	SheetOrganize:  "Organize Sheet (Color/Group)",
+	Quit:           "Quit",
+	Save:           "Save",
+	Undo:           "Undo",
+	Redo:           "Redo",
+	WorkbookSwitch: "Switch Open Workbook",
+}
+
+// Label returns the human-readable name shown for a on the Keybindings
+// screen, falling back to the raw Action name if it is unrecognized.
+func (a Action) Label() string {
+	if l, ok := actionLabels[a]; ok {
+		return l
+	}
+	return string(a)
+}
+
+// Chord is a single key combination: a printable rune (for letter/digit
+// shortcuts) or a named tcell.Key (for Enter, Esc, function keys, ...),
+// plus whatever modifiers were held down.
+type Chord struct {
+	Key  tcell.Key     `json:"key"`
+	Rune rune          `json:"rune"`
+	Mod  tcell.ModMask `json:"mod"`
+}
+
+// ChordFromEvent builds a Chord out of the key event tview handed the
+// caller, normalizing tcell.KeyRune so two chords for the same letter
+// compare equal regardless of how they were captured.
+func ChordFromEvent(event *tcell.EventKey) Chord {
+	c := Chord{Key: event.Key(), Mod: event.Modifiers()}
+	if c.Key == tcell.KeyRune {
+		c.Rune = event.Rune()
+	}
+	return c
+}
+
+// String renders a Chord the way it should look on a button or in the
+// Keybindings screen: modifier prefixes, then the rune or tcell's KeyNames
+// name for the key, e.g. "Alt+n" or "Ctrl-S".
+func (c Chord) String() string {
+	var s string
+	if c.Mod&tcell.ModAlt != 0 {
+		s += "Alt+"
+	}
+	if c.Mod&tcell.ModCtrl != 0 {
+		s += "Ctrl+"
+	}
+	if c.Mod&tcell.ModShift != 0 {
+		s += "Shift+"
+	}
+
+	if c.Key == tcell.KeyRune {
+		return fmt.Sprintf("%s%c", s, c.Rune)
+	}
+	if name, ok := tcell.KeyNames[c.Key]; ok {
+		return s + name
+	}
+	return fmt.Sprintf("%sKey(%d)", s, c.Key)
+}
+
+// defaultBindings mirrors the shortcuts that used to be hardcoded in
+// ShowSheetManager's SetInputCapture and main.go's global input capture.
+func defaultBindings() map[Action]Chord {
+	return map[Action]Chord{
+		SheetAdd:       {Key: tcell.KeyRune, Rune: 'n', Mod: tcell.ModAlt},
+		SheetRename:    {Key: tcell.KeyRune, Rune: 'r', Mod: tcell.ModAlt},
+		SheetDelete:    {Key: tcell.KeyRune, Rune: 'd', Mod: tcell.ModAlt},
+		SheetMove:      {Key: tcell.KeyRune, Rune: 'm', Mod: tcell.ModAlt},
+		SheetDuplicate: {Key: tcell.KeyRune, Rune: 'c', Mod: tcell.ModAlt},
+		SheetSwitch:    {Key: tcell.KeyRune, Rune: 's', Mod: tcell.ModAlt},
+		SheetOrganize:  {Key: tcell.KeyRune, Rune: 'o', Mod: tcell.ModAlt},
+		Quit:           {Key: tcell.KeyCtrlC},
+		Save:           {Key: tcell.KeyCtrlS},
+		Undo:           {Key: tcell.KeyCtrlZ},
+		Redo:           {Key: tcell.KeyCtrlY},
+		WorkbookSwitch: {Key: tcell.KeyTAB, Mod: tcell.ModCtrl},
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// registry.go loads and saves a Registry of Action->Chord bindings from the
+// user config dir, falling back to defaults when no file exists yet.
+
+package keybindings
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// configFileName is the name of the keybindings file inside the app's
+// directory in the user's config dir (e.g. ~/.config/gosheet/keybindings.json).
+const configFileName = "keybindings.json"
+
+// Registry holds the active Action->Chord bindings and the reverse lookup
+// used to route incoming key events.
+type Registry struct {
+	bindings map[Action]Chord
+	byChord  map[Chord]Action
+}
+
+// NewDefaultRegistry returns a Registry seeded with the built-in shortcuts.
+func NewDefaultRegistry() *Registry {
+	return newRegistryFrom(defaultBindings())
+}
+
+func newRegistryFrom(bindings map[Action]Chord) *Registry {
+	r := &Registry{
+		bindings: make(map[Action]Chord, len(bindings)),
+		byChord:  make(map[Chord]Action, len(bindings)),
+	}
+	for action, chord := range bindings {
+		r.bindings[action] = chord
+		r.byChord[chord] = action
+	}
+	return r
+}
+
+// ConfigPath returns where the keybindings file lives, creating its parent
+// directory if necessary.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "gosheet")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
+
+// Load reads the keybindings file from the user config dir, falling back to
+// NewDefaultRegistry if it does not exist yet or fails to parse.
+func Load() *Registry {
+	path, err := ConfigPath()
+	if err != nil {
+		return NewDefaultRegistry()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewDefaultRegistry()
+	}
+
+	var saved map[Action]Chord
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return NewDefaultRegistry()
+	}
+
+	merged := defaultBindings()
+	for action, chord := range saved {
+		merged[action] = chord
+	}
+	return newRegistryFrom(merged)
+}
+
+// Save writes the current bindings to the user config dir as JSON.
+func (r *Registry) Save() error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r.bindings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Chord returns the chord currently bound to action.
+func (r *Registry) Chord(action Action) Chord {
+	return r.bindings[action]
+}
+
+// Lookup resolves a key event to the Action bound to it, if any.
+func (r *Registry) Lookup(event *tcell.EventKey) (Action, bool) {
+	action, ok := r.byChord[ChordFromEvent(event)]
+	return action, ok
+}
+
+// Actions returns every known Action in the order they should be listed.
+func (r *Registry) Actions() []Action {
+	return append([]Action(nil), actionOrder...)
+}
+
+// Bind rebinds action to chord, returning the Action that previously held
+// that chord (if any) so the caller can warn about the conflict before it
+// silently steals the shortcut.
+func (r *Registry) Bind(action Action, chord Chord) (conflict Action, hadConflict bool) {
+	if prev, ok := r.byChord[chord]; ok && prev != action {
+		conflict, hadConflict = prev, true
+		delete(r.bindings, prev)
+	}
+
+	if old, ok := r.bindings[action]; ok {
+		delete(r.byChord, old)
+	}
+
+	r.bindings[action] = chord
+	r.byChord[chord] = action
+	return conflict, hadConflict
+}
+
+// String renders the chord bound to action, or "(unbound)" if none is set.
+func (r *Registry) String(action Action) string {
+	chord, ok := r.bindings[action]
+	if !ok {
+		return "(unbound)"
+	}
+	return chord.String()
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// keybindingsUI.go provides the "Keybindings" screen: a list of every
+// rebindable Action with its current chord, letting the user record a new
+// chord and persist it to disk. No menu or key in this tree opens it.
+
+package ui
+
+import (
+	"fmt"
+
+	"gosheet/internal/services/ui/keybindings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ShowKeybindingsScreen displays every Action bound in registry and lets the
+// user rebind one. returnTo is restored on Esc.
+func ShowKeybindingsScreen(app *tview.Application, returnTo tview.Primitive, registry *keybindings.Registry) {
+	list := tview.NewList().
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan).
+		SetSelectedTextColor(tcell.ColorWhite).
+		SetMainTextColor(tcell.ColorWhite).
+		SetSecondaryTextColor(tcell.ColorGray).
+		ShowSecondaryText(false)
+	list.SetBorder(true).
+		SetTitle(" Keybindings ").
+		SetBorderColor(tcell.ColorLightBlue).
+		SetTitleAlign(tview.AlignLeft)
+
+	updateKeybindingsList(list, registry)
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[gray]Enter[-] record a new chord for the selected action   [gray]Esc[-] back")
+	help.SetBorder(true)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(help, 3, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			return nil
+		case tcell.KeyEnter:
+			actions := registry.Actions()
+			idx := list.GetCurrentItem()
+			if idx < 0 || idx >= len(actions) {
+				return nil
+			}
+			showRecordChordDialog(app, layout, list, registry, actions[idx])
+			return nil
+		}
+		return event
+	})
+
+	app.SetRoot(layout, true).SetFocus(list)
+}
+
+// updateKeybindingsList refreshes list to reflect registry's current state.
+func updateKeybindingsList(list *tview.List, registry *keybindings.Registry) {
+	list.Clear()
+	for _, action := range registry.Actions() {
+		list.AddItem(fmt.Sprintf(" %-20s %s", action.Label(), registry.String(action)), "", 0, nil)
+	}
+}
+
+// showRecordChordDialog binds the next key press to action and saves the
+// registry. If that chord was bound to a different action, that action is
+// left unbound and ShowWarningModal reports it.
+func showRecordChordDialog(app *tview.Application, returnTo tview.Primitive, list *tview.List, registry *keybindings.Registry, action keybindings.Action) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Press the new chord for '%s'...\n(Esc to cancel)", action.Label()))
+	modal.SetBorder(true).SetTitle(" Record Chord ").SetTitleAlign(tview.AlignCenter)
+
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(list)
+			return nil
+		}
+
+		chord := keybindings.ChordFromEvent(event)
+		conflict, hadConflict := registry.Bind(action, chord)
+		if err := registry.Save(); err != nil {
+			ShowWarningModal(app, returnTo, "Failed to save keybindings: "+err.Error())
+		}
+
+		updateKeybindingsList(list, registry)
+		app.SetRoot(returnTo, true).SetFocus(list)
+
+		if hadConflict {
+			ShowWarningModal(app, returnTo, fmt.Sprintf("'%s' was bound to '%s'; it is now unbound.", chord.String(), conflict.Label()))
+		}
+		return nil
+	})
+
+	app.SetRoot(modal, true).SetFocus(modal)
+}
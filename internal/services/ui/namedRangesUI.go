@@ -0,0 +1,186 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// namedRangesUI.go provides the "Names" screen reachable from the sheet
+// manager: a list of the workbook's named ranges with add/edit/delete
+// dialogs, so a formula can say SUM(Revenue) instead of Sheet2!B2:B10.
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"gosheet/internal/services/fileop"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ShowNamedRangesManager displays every named range in the workbook and
+// lets the user add, edit, or delete one. returnTo is restored on Esc.
+func ShowNamedRangesManager(app *tview.Application, returnTo tview.Primitive, callbacks SheetManagerCallbacks) {
+	list := tview.NewList().
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan).
+		SetSelectedTextColor(tcell.ColorWhite).
+		SetMainTextColor(tcell.ColorWhite).
+		SetSecondaryTextColor(tcell.ColorGray).
+		ShowSecondaryText(true)
+	list.SetBorder(true).
+		SetTitle(" Names ").
+		SetBorderColor(tcell.ColorLightBlue).
+		SetTitleAlign(tview.AlignLeft)
+
+	updateNamedRangesList(list, callbacks)
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[gray]Alt+N[-] add   [gray]Alt+R[-] rename/edit   [gray]Alt+D[-] delete   [gray]Esc[-] back")
+	help.SetBorder(true)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(help, 3, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			return nil
+		}
+
+		if event.Modifiers()&tcell.ModAlt != 0 {
+			switch event.Rune() {
+			case 'n', 'N':
+				showAddNamedRangeDialog(app, callbacks, layout, list)
+				return nil
+			case 'r', 'R':
+				showEditNamedRangeDialog(app, callbacks, layout, list)
+				return nil
+			case 'd', 'D':
+				confirmDeleteNamedRange(app, callbacks, layout, list)
+				return nil
+			}
+		}
+		return event
+	})
+
+	app.SetRoot(layout, true).SetFocus(list)
+}
+
+// updateNamedRangesList refreshes list to reflect callbacks.GetNames().
+func updateNamedRangesList(list *tview.List, callbacks SheetManagerCallbacks) {
+	list.Clear()
+	for _, name := range callbacks.GetNames() {
+		list.AddItem(
+			fmt.Sprintf(" %s", name.Name),
+			fmt.Sprintf("   └─ %s!%s", name.Sheet, name.Region),
+			0, nil,
+		)
+	}
+}
+
+// showAddNamedRangeDialog prompts for a new name, sheet, and region.
+func showAddNamedRangeDialog(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List) {
+	showNamedRangeForm(app, callbacks, returnTo, list, "+ Add Name", fileop.NamedRange{}, func(name fileop.NamedRange) error {
+		return callbacks.AddName(name.Name, name.Sheet, name.Region)
+	})
+}
+
+// showEditNamedRangeDialog prefills the form with the selected named range.
+func showEditNamedRangeDialog(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List) {
+	names := callbacks.GetNames()
+	selectedIndex := list.GetCurrentItem()
+	if selectedIndex < 0 || selectedIndex >= len(names) {
+		return
+	}
+	current := names[selectedIndex]
+
+	showNamedRangeForm(app, callbacks, returnTo, list, " Edit Name", current, func(name fileop.NamedRange) error {
+		return callbacks.UpdateName(current.Name, name.Name, name.Sheet, name.Region)
+	})
+}
+
+// showNamedRangeForm is the shared Name/Sheet/Region form behind add and
+// edit, differing only in its title, prefilled values, and submit action.
+func showNamedRangeForm(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, title string, prefill fileop.NamedRange, onSubmit func(fileop.NamedRange) error) {
+	form := tview.NewForm()
+	form.SetFieldBackgroundColor(tcell.ColorBlack)
+	form.SetButtonBackgroundColor(tcell.ColorDarkGreen)
+	form.SetButtonTextColor(tcell.ColorWhite)
+
+	nameInput := tview.NewInputField().
+		SetLabel("Name: ").
+		SetText(prefill.Name).
+		SetFieldWidth(24)
+	sheetInput := tview.NewInputField().
+		SetLabel("Sheet: ").
+		SetText(prefill.Sheet).
+		SetFieldWidth(24)
+	regionInput := tview.NewInputField().
+		SetLabel("Region (e.g. B2:B10): ").
+		SetText(prefill.Region).
+		SetFieldWidth(24)
+
+	form.AddFormItem(nameInput).
+		AddFormItem(sheetInput).
+		AddFormItem(regionInput).
+		AddButton("Save", func() {
+			name := fileop.NamedRange{
+				Name:   strings.TrimSpace(nameInput.GetText()),
+				Sheet:  strings.TrimSpace(sheetInput.GetText()),
+				Region: strings.ToUpper(strings.TrimSpace(regionInput.GetText())),
+			}
+			if name.Name == "" || name.Sheet == "" || name.Region == "" {
+				ShowWarningModal(app, form, "Name, sheet, and region are all required.")
+				return
+			}
+
+			if err := onSubmit(name); err != nil {
+				ShowWarningModal(app, form, err.Error())
+				return
+			}
+
+			updateNamedRangesList(list, callbacks)
+			callbacks.MarkAsModified()
+			app.SetRoot(returnTo, true).SetFocus(list)
+		}).
+		AddButton("Cancel", func() {
+			app.SetRoot(returnTo, true).SetFocus(list)
+		})
+
+	form.SetBorder(true).
+		SetTitle(" " + title + " ").
+		SetBorderColor(tcell.ColorGreen).
+		SetTitleAlign(tview.AlignCenter)
+
+	app.SetRoot(form, true).SetFocus(form)
+}
+
+// confirmDeleteNamedRange asks for confirmation before removing a name.
+func confirmDeleteNamedRange(app *tview.Application, callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List) {
+	names := callbacks.GetNames()
+	selectedIndex := list.GetCurrentItem()
+	if selectedIndex < 0 || selectedIndex >= len(names) {
+		return
+	}
+	name := names[selectedIndex]
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete named range '%s'?\n\nFormulas referencing it will show #NAME?.", name.Name)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonLabel == "Delete" {
+				if err := callbacks.DeleteName(name.Name); err != nil {
+					ShowWarningModal(app, returnTo, err.Error())
+				} else {
+					updateNamedRangesList(list, callbacks)
+					callbacks.MarkAsModified()
+				}
+			}
+			app.SetRoot(returnTo, true).SetFocus(list)
+		})
+
+	app.SetRoot(modal, true).SetFocus(modal)
+}
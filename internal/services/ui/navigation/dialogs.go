@@ -0,0 +1,102 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// dialogs.go provides Confirm/Prompt/Choice modal helpers so callers no
+// longer have to hand-roll a tview.NewModal and manually restore
+// SetRoot/SetFocus themselves.
+
+package navigation
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ShowConfirm asks a Yes/No question, invoking onYes or onNo before popping
+// back to the frame that was active when the confirm was shown.
+func ShowConfirm(app *tview.Application, returnTo, focus tview.Primitive, msg string, onYes, onNo func()) {
+	modal := tview.NewModal().
+		SetText(msg).
+		AddButtons([]string{"Yes", "No"}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			if buttonLabel == "Yes" && onYes != nil {
+				onYes()
+			} else if buttonLabel == "No" && onNo != nil {
+				onNo()
+			}
+			PopModal(app)
+		})
+	modal.SetBorder(true).SetTitle(" Confirm ").SetTitleAlign(tview.AlignCenter)
+
+	PushModal(app, modal, returnTo, focus)
+}
+
+// ShowPrompt asks for a single line of text. tview.Modal cannot host an
+// input field, so the prompt is a Flex-wrapped InputField instead. validate
+// (optional) is run before onSubmit; a non-nil error is shown in place and
+// the prompt stays open.
+func ShowPrompt(app *tview.Application, returnTo, focus tview.Primitive, label, defaultVal string, validate func(string) error, onSubmit func(string)) {
+	input := tview.NewInputField().
+		SetLabel(label).
+		SetText(defaultVal).
+		SetFieldWidth(40)
+
+	errText := tview.NewTextView().
+		SetDynamicColors(true)
+
+	form := tview.NewForm()
+	form.AddFormItem(input)
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(errText, 1, 0, false)
+	container.SetBorder(true).
+		SetTitle(" Prompt ").
+		SetTitleAlign(tview.AlignCenter)
+
+	submit := func() {
+		text := input.GetText()
+		if validate != nil {
+			if err := validate(text); err != nil {
+				errText.SetText("[red]" + err.Error() + "[-]")
+				return
+			}
+		}
+		PopModal(app)
+		if onSubmit != nil {
+			onSubmit(text)
+		}
+	}
+
+	form.AddButton("OK", submit)
+	form.AddButton("Cancel", func() { PopModal(app) })
+
+	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			PopModal(app)
+			return nil
+		}
+		return event
+	})
+
+	PushModal(app, container, returnTo, focus)
+}
+
+// ShowChoice offers a fixed list of options as buttons, invoking onPick with
+// the index of the option the user selected.
+func ShowChoice(app *tview.Application, returnTo, focus tview.Primitive, msg string, options []string, onPick func(index int)) {
+	modal := tview.NewModal().
+		SetText(msg).
+		AddButtons(options).
+		SetDoneFunc(func(index int, _ string) {
+			PopModal(app)
+			if onPick != nil {
+				onPick(index)
+			}
+		})
+	modal.SetBorder(true).SetTitle(" Choose ").SetTitleAlign(tview.AlignCenter)
+
+	PushModal(app, modal, returnTo, focus)
+}
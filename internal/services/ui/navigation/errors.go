@@ -8,8 +8,8 @@ func ShowWarningModal(app *tview.Application, returnTo tview.Primitive, message
 		SetText(message).
 		AddButtons([]string{"OK"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			PopModal(app)
 		})
 	modal.SetBorder(true).SetTitle(" Info ").SetTitleAlign(tview.AlignCenter)
-	app.SetRoot(modal, true).SetFocus(modal)
+	PushModal(app, modal, returnTo, returnTo)
 }
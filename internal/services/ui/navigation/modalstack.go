@@ -0,0 +1,39 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// modalstack.go backs the modal helpers with a stack of (root, focus)
+// frames so a dialog raised from inside another dialog (e.g. a validation
+// error from inside a confirm) pops back to its actual parent instead of
+// whatever returnTo the outermost caller happened to pass.
+
+package navigation
+
+import "github.com/rivo/tview"
+
+type modalFrame struct {
+	root  tview.Primitive
+	focus tview.Primitive
+}
+
+var modalStack []modalFrame
+
+// PushModal shows modal on top of the stack, remembering (returnTo, focus)
+// as the frame to restore when this modal (or anything shown on top of it)
+// is dismissed.
+func PushModal(app *tview.Application, modal tview.Primitive, returnTo, focus tview.Primitive) {
+	modalStack = append(modalStack, modalFrame{root: returnTo, focus: focus})
+	app.SetRoot(modal, true).SetFocus(modal)
+}
+
+// PopModal dismisses the top modal and restores the frame beneath it. It is
+// a no-op if the stack is empty (e.g. a modal shown outside PushModal).
+func PopModal(app *tview.Application) {
+	if len(modalStack) == 0 {
+		return
+	}
+	top := modalStack[len(modalStack)-1]
+	modalStack = modalStack[:len(modalStack)-1]
+	app.SetRoot(top.root, true).SetFocus(top.focus)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// notify.go implements non-blocking toast notifications backed by a
+// status bar, as an alternative to modal dialogs for transient events.
+
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Level controls the color a Toast is rendered with.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelSuccess
+	LevelWarning
+	LevelError
+)
+
+// colorTag returns the tview color tag name for a Level, used in
+// "[tag]text[-]" markup.
+func (l Level) colorTag() string {
+	switch l {
+	case LevelSuccess:
+		return "green"
+	case LevelWarning:
+		return "yellow"
+	case LevelError:
+		return "red"
+	default:
+		return "lightblue"
+	}
+}
+
+// Entry is a single notification, kept for the history pane after it
+// expires from the status bar.
+type Entry struct {
+	Level Level
+	Text  string
+	At    time.Time
+}
+
+// Bar is a status bar docked at the bottom of the main layout that shows the
+// most recent Toast and keeps a scrollable history of past notifications.
+type Bar struct {
+	view *tview.TextView
+
+	mu      sync.Mutex
+	queue   []Entry
+	history []Entry
+	timer   *time.Timer
+}
+
+// NewBar creates a status bar ready to be docked into a Flex layout.
+func NewBar() *Bar {
+	b := &Bar{
+		view: tview.NewTextView().
+			SetDynamicColors(true).
+			SetTextAlign(tview.AlignLeft),
+	}
+	return b
+}
+
+// View returns the underlying primitive to place in the app's layout.
+func (b *Bar) View() *tview.TextView {
+	return b.view
+}
+
+// Toast queues a notification, rendering it immediately if the bar is idle,
+// and auto-expires it (advancing to the next queued entry, if any) after ttl.
+func Toast(app *tview.Application, bar *Bar, level Level, msg string, ttl time.Duration) {
+	bar.mu.Lock()
+	entry := Entry{Level: level, Text: msg, At: timeNow()}
+	bar.history = append(bar.history, entry)
+	bar.queue = append(bar.queue, entry)
+	showNow := len(bar.queue) == 1
+	bar.mu.Unlock()
+
+	if showNow {
+		bar.render(app, entry, ttl)
+	}
+}
+
+func (b *Bar) render(app *tview.Application, entry Entry, ttl time.Duration) {
+	app.QueueUpdateDraw(func() {
+		b.view.SetText(fmt.Sprintf("[%s::b]● %s[-::-]", entry.Level.colorTag(), entry.Text))
+	})
+
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.timer = time.AfterFunc(ttl, func() {
+		b.mu.Lock()
+		if len(b.queue) > 0 {
+			b.queue = b.queue[1:]
+		}
+		next := (*Entry)(nil)
+		if len(b.queue) > 0 {
+			e := b.queue[0]
+			next = &e
+		}
+		b.mu.Unlock()
+
+		if next != nil {
+			b.render(app, *next, ttl)
+			return
+		}
+		app.QueueUpdateDraw(func() {
+			b.view.SetText("")
+		})
+	})
+}
+
+// History returns a copy of every notification shown so far, oldest first.
+func (b *Bar) History() []Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]Entry, len(b.history))
+	copy(out, b.history)
+	return out
+}
+
+// ShowHistory opens a scrollable pane listing past notifications, bound to
+// the keybinding the caller wires to it (e.g. Ctrl-N).
+func ShowHistory(app *tview.Application, returnTo tview.Primitive, bar *Bar) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.SetBorder(true).
+		SetTitle(" Notification History ").
+		SetBorderColor(tcell.ColorLightBlue)
+
+	for _, e := range bar.History() {
+		fmt.Fprintf(view, "[gray]%s[-]  [%s]%s[-]\n", e.At.Format("15:04:05"), e.Level.colorTag(), e.Text)
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetRoot(returnTo, true).SetFocus(returnTo)
+			return nil
+		}
+		return event
+	})
+
+	app.SetRoot(view, true).SetFocus(view)
+}
+
+// timeNow exists so tests can stub notification timestamps if needed.
+var timeNow = time.Now
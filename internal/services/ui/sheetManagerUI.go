@@ -9,34 +9,168 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"gosheet/internal/services/fileop"
+	"gosheet/internal/services/ui/keybindings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+// keybindingsRegistry holds the sheet manager's shortcut bindings, loaded
+// once at startup; main.go loads its own Registry, and nothing passes this
+// one to ShowKeybindingsScreen.
+var keybindingsRegistry = keybindings.Load()
+
+// sheetSelection tracks which sheets (by real index into callbacks.GetSheets())
+// are multi-selected, plus the name/group filter narrowing which of them are
+// currently visible in the list. anchor is the real index a Shift+Up/Down
+// range extension started from.
+//
+// Selected sheets and the anchor are always stored by real index so they
+// survive the filter changing; list rows are only "real indices" when no
+// filter is applied. visible is rebuilt by updateSheetList on every render
+// and is what Resolve/RowOf translate list rows against.
+type sheetSelection struct {
+	rows    map[int]bool
+	anchor  int
+	filter  string
+	visible []int
+}
+
+// newSheetSelection returns an empty selection with no filter.
+func newSheetSelection() *sheetSelection {
+	return &sheetSelection{rows: make(map[int]bool), anchor: -1}
+}
+
+// Toggle flips row's membership and makes it the new range anchor. row is a
+// real sheet index, already resolved from the list row.
+func (s *sheetSelection) Toggle(row int) {
+	if s.rows[row] {
+		delete(s.rows, row)
+	} else {
+		s.rows[row] = true
+	}
+	s.anchor = row
+}
+
+// ExtendTo selects every real sheet index between the anchor and row
+// (inclusive), starting a fresh anchor at row if none was set yet.
+func (s *sheetSelection) ExtendTo(row int) {
+	if s.anchor < 0 {
+		s.anchor = row
+	}
+	lo, hi := s.anchor, row
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	for i := lo; i <= hi; i++ {
+		s.rows[i] = true
+	}
+}
+
+// SelectRows marks every given real sheet index as selected, e.g. Ctrl+A
+// selecting everything currently visible under the active filter.
+func (s *sheetSelection) SelectRows(rows []int) {
+	for _, row := range rows {
+		s.rows[row] = true
+	}
+}
+
+// Clear empties the selection.
+func (s *sheetSelection) Clear() {
+	s.rows = make(map[int]bool)
+	s.anchor = -1
+}
+
+// Has reports whether the real sheet index row is selected.
+func (s *sheetSelection) Has(row int) bool {
+	return s.rows[row]
+}
+
+// Count returns the number of selected sheets.
+func (s *sheetSelection) Count() int {
+	return len(s.rows)
+}
+
+// Sorted returns the selected real sheet indices in ascending order.
+func (s *sheetSelection) Sorted() []int {
+	rows := make([]int, 0, len(s.rows))
+	for row := range s.rows {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// SetFilter sets the substring (matched case-insensitively against a
+// sheet's name or group) that narrows the list on the next render.
+func (s *sheetSelection) SetFilter(text string) {
+	s.filter = text
+}
+
+// Resolve translates a list row into the real sheet index it currently
+// displays, as of the last updateSheetList render.
+func (s *sheetSelection) Resolve(row int) (int, bool) {
+	if row < 0 || row >= len(s.visible) {
+		return 0, false
+	}
+	return s.visible[row], true
+}
+
+// RowOf returns the list row real currently renders at, or -1 if real is
+// filtered out of view.
+func (s *sheetSelection) RowOf(real int) int {
+	for row, idx := range s.visible {
+		if idx == real {
+			return row
+		}
+	}
+	return -1
+}
+
 // SheetManagerCallbacks defines callbacks for sheet operations
 type SheetManagerCallbacks struct {
-	GetSheets          func() []SheetInfo
-	GetActiveIndex     func() int
-	GetWorkbookInfo    func() WorkbookInfo
-	AddSheet           func(name string) error
-	RenameSheet        func(index int, name string) error
-	DeleteSheet        func(index int) error
-	DuplicateSheet     func(index int) error
-	MoveSheet          func(fromIndex, toIndex int) error
-	SwitchToSheet      func(index int) error
-	UpdateTabBar       func()
-	UpdateTableTitle   func()
-	MarkAsModified     func()
-	RenderActiveSheet  func()
+	GetSheets         func() []SheetInfo
+	GetActiveIndex    func() int
+	GetWorkbookInfo   func() WorkbookInfo
+	AddSheet          func(name string) error
+	RenameSheet       func(index int, name string) error
+	DeleteSheet       func(index int) error
+	DuplicateSheet    func(index int) error
+	MoveSheet         func(fromIndex, toIndex int) error
+	DeleteSheets      func(indices []int) error
+	DuplicateSheets   func(indices []int) error
+	MoveSheets        func(indices []int, toIndex int) error
+	SetSheetColor     func(index int, color string) error
+	SetSheetGroup     func(index int, group string) error
+	SwitchToSheet     func(index int) error
+	UpdateTabBar      func()
+	UpdateTableTitle  func()
+	MarkAsModified    func()
+	RenderActiveSheet func()
+	GetNames          func() []fileop.NamedRange
+	AddName           func(name, sheet, region string) error
+	UpdateName        func(oldName, name, sheet, region string) error
+	DeleteName        func(name string) error
+	GetCharts         func(index int) []fileop.ChartSpec
+	AddChart          func(index int, spec fileop.ChartSpec) error
+	UpdateChart       func(index int, chartIndex int, spec fileop.ChartSpec) error
+	DeleteChart       func(index int, chartIndex int) error
 }
 
-// SheetInfo contains information about a single sheet
+// SheetInfo contains information about a single sheet. Color and Group are
+// cosmetic labels a user assigns via the Organize action; UpdateTabBar's
+// implementation reads them off the same GetSheets() call to draw a matching
+// underline on the tab bar outside the manager.
 type SheetInfo struct {
 	Name      string
 	CellCount int
 	IsActive  bool
+	Color     string
+	Group     string
 }
 
 // WorkbookInfo contains information about the workbook
@@ -62,7 +196,19 @@ func ShowSheetManager(app *tview.Application, table *tview.Table, callbacks Shee
 		SetBorderColor(tcell.ColorLightBlue).
 		SetTitleAlign(tview.AlignLeft)
 
-	updateSheetList(list, callbacks)
+	selection := newSheetSelection()
+
+	updateSheetList(list, callbacks, selection)
+
+	// Filter input narrows the list below by substring match on a sheet's
+	// name or group; Ctrl+F focuses it, Enter/Esc hands focus back to list.
+	filterInput := tview.NewInputField().
+		SetLabel(" Filter: ").
+		SetFieldBackgroundColor(tcell.ColorBlack).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(tcell.ColorGray)
+	filterInput.SetBorder(true).
+		SetBorderColor(tcell.ColorLightBlue)
 
 	// Info panel with better formatting
 	infoPanel := tview.NewTextView().
@@ -77,11 +223,12 @@ func ShowSheetManager(app *tview.Application, table *tview.Table, callbacks Shee
 
 	// Layout assembly
 	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(filterInput, 3, 0, false).
 		AddItem(list, 0, 3, true).
 		AddItem(infoPanel, 12, 0, false)
 
 	mainContent := tview.NewFlex()
-	
+
 	mainLayout := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(mainContent, 0, 1, true)
 
@@ -90,54 +237,120 @@ func ShowSheetManager(app *tview.Application, table *tview.Table, callbacks Shee
 		SetBorderColor(tcell.ColorYellow).
 		SetTitleAlign(tview.AlignCenter)
 
-	actionPanel := createActionPanel(app, table, callbacks, list, infoPanel)
+	actionPanel := createActionPanel(app, table, callbacks, list, infoPanel, selection)
 
 	mainContent.
 		AddItem(leftPanel, 0, 2, true).
 		AddItem(actionPanel, 45, 0, false)
 
+	refreshSelectionInfo := func() {
+		sheets := callbacks.GetSheets()
+		if selection.Count() > 1 {
+			infoPanel.SetText(getMultiSheetInfoText(sheets, selection))
+			return
+		}
+		if real, ok := selection.Resolve(list.GetCurrentItem()); ok && real < len(sheets) {
+			infoPanel.SetText(getSheetInfoText(sheets[real], real, len(sheets)))
+		}
+	}
+
+	filterInput.SetChangedFunc(func(text string) {
+		selection.SetFilter(text)
+		updateSheetList(list, callbacks, selection)
+		refreshSelectionInfo()
+	})
+	filterInput.SetDoneFunc(func(key tcell.Key) {
+		app.SetFocus(list)
+	})
+
 	mainLayout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyEscape:
 			app.SetRoot(table, true).SetFocus(table)
 			return nil
+		case tcell.KeyCtrlF:
+			app.SetFocus(filterInput)
+			return nil
 		case tcell.KeyEnter:
-			switchToSelectedSheet(app, table, callbacks, list)
+			switchToSelectedSheet(app, table, callbacks, list, selection)
 			return nil
-		}
-
-		if event.Modifiers()&tcell.ModAlt != 0 {
-			switch event.Rune() {
-			case 'n', 'N':
-				showAddSheetDialog(app, callbacks, mainLayout, list, infoPanel)
-				return nil
-			case 'r', 'R':
-				showRenameSheetFromManager(app, callbacks, mainLayout, list, infoPanel)
-				return nil
-			case 'd', 'D':
-				confirmDeleteSheetFromManager(app, callbacks, mainLayout, list, infoPanel)
-				return nil
-			case 'm', 'M':
-				showMoveSheetDialog(app, callbacks, mainLayout, list, infoPanel)
-				return nil
-			case 'c', 'C':
-				duplicateSheetFromManager(app, table, callbacks, list, infoPanel)
+		case tcell.KeyCtrlA:
+			selection.SelectRows(selection.visible)
+			updateSheetList(list, callbacks, selection)
+			refreshSelectionInfo()
+			return nil
+		case tcell.KeyUp, tcell.KeyDown:
+			if event.Modifiers()&tcell.ModShift != 0 {
+				currentRow := list.GetCurrentItem()
+				nextRow := currentRow - 1
+				if event.Key() == tcell.KeyDown {
+					nextRow = currentRow + 1
+				}
+				currentReal, ok := selection.Resolve(currentRow)
+				if !ok {
+					return nil
+				}
+				nextReal, ok := selection.Resolve(nextRow)
+				if !ok {
+					return nil
+				}
+				selection.ExtendTo(currentReal)
+				selection.ExtendTo(nextReal)
+				list.SetCurrentItem(nextRow)
+				updateSheetList(list, callbacks, selection)
+				refreshSelectionInfo()
 				return nil
-			case 's', 'S':
-				switchToSelectedSheet(app, table, callbacks, list)
+			}
+		case tcell.KeyRune:
+			if event.Rune() == ' ' {
+				if real, ok := selection.Resolve(list.GetCurrentItem()); ok {
+					selection.Toggle(real)
+					updateSheetList(list, callbacks, selection)
+					refreshSelectionInfo()
+				}
 				return nil
 			}
 		}
 
-		return event
+		action, ok := keybindingsRegistry.Lookup(event)
+		if !ok {
+			if event.Modifiers()&tcell.ModAlt != 0 {
+				switch event.Rune() {
+				case 'g', 'G':
+					ShowNamedRangesManager(app, mainLayout, callbacks)
+					return nil
+				case 'h', 'H':
+					showChartsManagerFromSheetManager(app, callbacks, mainLayout, list, selection)
+					return nil
+				}
+			}
+			return event
+		}
+
+		switch action {
+		case keybindings.SheetAdd:
+			showAddSheetDialog(app, callbacks, mainLayout, list, infoPanel, selection)
+		case keybindings.SheetRename:
+			showRenameSheetFromManager(app, callbacks, mainLayout, list, infoPanel, selection)
+		case keybindings.SheetDelete:
+			confirmDeleteSheetFromManager(app, callbacks, mainLayout, list, infoPanel, selection)
+		case keybindings.SheetMove:
+			showMoveSheetDialog(app, callbacks, mainLayout, list, infoPanel, selection)
+		case keybindings.SheetDuplicate:
+			duplicateSheetFromManager(app, table, callbacks, list, infoPanel, selection)
+		case keybindings.SheetSwitch:
+			switchToSelectedSheet(app, table, callbacks, list, selection)
+		case keybindings.SheetOrganize:
+			showOrganizeSheetDialog(app, callbacks, mainLayout, list, infoPanel, selection)
+		default:
+			return event
+		}
+		return nil
 	})
 
-	// Update info panel when selection changes
+	// Update info panel when the cursor moves
 	list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-		sheets := callbacks.GetSheets()
-		if index >= 0 && index < len(sheets) {
-			infoPanel.SetText(getSheetInfoText(sheets[index], index, len(sheets)))
-		}
+		refreshSelectionInfo()
 	})
 
 	app.SetRoot(mainLayout, true).SetFocus(list)
@@ -145,8 +358,8 @@ func ShowSheetManager(app *tview.Application, table *tview.Table, callbacks Shee
 
 // createActionPanel creates an enhanced button panel with icons and descriptions
 func createActionPanel(app *tview.Application, table *tview.Table,
-	callbacks SheetManagerCallbacks, list *tview.List, infoPanel *tview.TextView) *tview.Flex {
-	
+	callbacks SheetManagerCallbacks, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) *tview.Flex {
+
 	actionPanel := tview.NewFlex().SetDirection(tview.FlexRow)
 	actionPanel.SetBorder(true).
 		SetTitle(" ⚡Actions ").
@@ -157,16 +370,16 @@ func createActionPanel(app *tview.Application, table *tview.Table,
 		btn := tview.NewBox().
 			SetBorder(true).
 			SetBorderColor(color)
-		
+
 		btn.SetDrawFunc(func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
 			tview.Print(screen, fmt.Sprintf(" %s %s", icon, label), x+1, y+1, width-2, tview.AlignLeft, color)
-			
+
 			tview.Print(screen, shortcut, x+width-len(shortcut)-2, y+1, len(shortcut), tview.AlignRight, tcell.ColorYellow)
-			
+
 			if len(description) > 0 {
 				tview.Print(screen, description, x+1, y+2, width-2, tview.AlignLeft, tcell.ColorGray)
 			}
-			
+
 			return x + 1, y + 3, width - 2, height - 3
 		})
 
@@ -183,45 +396,66 @@ func createActionPanel(app *tview.Application, table *tview.Table,
 
 	// Action buttons
 	newSheetBtn := createActionBtn(
-		"", "New Sheet", "Alt+N",
+		"", "New Sheet", keybindingsRegistry.String(keybindings.SheetAdd),
 		"Create a blank sheet",
 		tcell.ColorGreen,
-		func() { showAddSheetDialog(app, callbacks, actionPanel, list, infoPanel) },
+		func() { showAddSheetDialog(app, callbacks, actionPanel, list, infoPanel, selection) },
 	)
 
 	renameBtn := createActionBtn(
-		"", "Rename", "Alt+R",
+		"", "Rename", keybindingsRegistry.String(keybindings.SheetRename),
 		"Change sheet name",
 		tcell.ColorBlue,
-		func() { showRenameSheetFromManager(app, callbacks, actionPanel, list, infoPanel) },
+		func() { showRenameSheetFromManager(app, callbacks, actionPanel, list, infoPanel, selection) },
 	)
 
 	duplicateBtn := createActionBtn(
-		"", "Duplicate", "Alt+C",
-		"Copy entire sheet",
+		"", "Duplicate", keybindingsRegistry.String(keybindings.SheetDuplicate),
+		"Copy entire sheet (or the selection)",
 		tcell.ColorLightBlue,
-		func() { duplicateSheetFromManager(app, table, callbacks, list, infoPanel) },
+		func() { duplicateSheetFromManager(app, table, callbacks, list, infoPanel, selection) },
 	)
 
 	moveBtn := createActionBtn(
-		"", "Move/Reorder", "Alt+M",
+		"", "Move/Reorder", keybindingsRegistry.String(keybindings.SheetMove),
 		"Change sheet position",
 		tcell.ColorYellow,
-		func() { showMoveSheetDialog(app, callbacks, actionPanel, list, infoPanel) },
+		func() { showMoveSheetDialog(app, callbacks, actionPanel, list, infoPanel, selection) },
 	)
 
 	deleteBtn := createActionBtn(
-		"", "Delete", "Alt+D",
-		"Remove sheet permanently",
+		"", "Delete", keybindingsRegistry.String(keybindings.SheetDelete),
+		"Remove sheet (or the selection)",
 		tcell.ColorRed,
-		func() { confirmDeleteSheetFromManager(app, callbacks, actionPanel, list, infoPanel) },
+		func() { confirmDeleteSheetFromManager(app, callbacks, actionPanel, list, infoPanel, selection) },
 	)
 
 	switchBtn := createActionBtn(
 		"", "Switch To", "Enter",
 		"Open selected sheet",
 		tcell.ColorLightBlue,
-		func() { switchToSelectedSheet(app, table, callbacks, list) },
+		func() { switchToSelectedSheet(app, table, callbacks, list, selection) },
+	)
+
+	organizeBtn := createActionBtn(
+		"", "Organize", keybindingsRegistry.String(keybindings.SheetOrganize),
+		"Set color/group (or the selection)",
+		tcell.ColorPurple,
+		func() { showOrganizeSheetDialog(app, callbacks, actionPanel, list, infoPanel, selection) },
+	)
+
+	namesBtn := createActionBtn(
+		"", "Names", "Alt+G",
+		"Manage named ranges",
+		tcell.ColorLightBlue,
+		func() { ShowNamedRangesManager(app, actionPanel, callbacks) },
+	)
+
+	chartsBtn := createActionBtn(
+		"", "Charts", "Alt+H",
+		"Manage charts on the selected sheet",
+		tcell.ColorLightBlue,
+		func() { showChartsManagerFromSheetManager(app, callbacks, actionPanel, list, selection) },
 	)
 
 	exitBtn := createActionBtn(
@@ -238,29 +472,67 @@ func createActionPanel(app *tview.Application, table *tview.Table,
 		AddItem(moveBtn, 4, 0, false).
 		AddItem(deleteBtn, 4, 0, false).
 		AddItem(switchBtn, 4, 0, false).
+		AddItem(organizeBtn, 4, 0, false).
+		AddItem(namesBtn, 4, 0, false).
+		AddItem(chartsBtn, 4, 0, false).
 		AddItem(exitBtn, 4, 0, false).
 		AddItem(tview.NewBox(), 0, 1, false)
 
 	return actionPanel
 }
 
-// updateSheetList refreshes the sheet list with enhanced display
-func updateSheetList(list *tview.List, callbacks SheetManagerCallbacks) {
+// updateSheetList refreshes the sheet list with enhanced display, applying
+// selection's active filter first. Rows held in selection are painted with a
+// distinct background so a multi-selection remains visible alongside the
+// single-row cursor highlight tview already draws. selection.visible is
+// rebuilt here with the real sheet index behind every rendered row, so
+// callers translate list rows back with selection.Resolve.
+func updateSheetList(list *tview.List, callbacks SheetManagerCallbacks, selection *sheetSelection) {
 	list.Clear()
 	sheets := callbacks.GetSheets()
 
+	needle := strings.ToLower(strings.TrimSpace(selection.filter))
+	selection.visible = selection.visible[:0]
+
 	for i, sheet := range sheets {
+		if needle != "" &&
+			!strings.Contains(strings.ToLower(sheet.Name), needle) &&
+			!strings.Contains(strings.ToLower(sheet.Group), needle) {
+			continue
+		}
+		selection.visible = append(selection.visible, i)
+
 		icon := ""
 		badge := ""
-		
+
 		if sheet.IsActive {
 			icon = ""
 			badge = " [yellow::b]> ACTIVE[::-]"
 		}
 
-		mainText := fmt.Sprintf(" %s  %s%s", icon, sheet.Name, badge)
+		checkbox := "[ ]"
+		if selection.Has(i) {
+			checkbox = "[x]"
+		}
+
+		bullet := "○"
+		if sheet.Color != "" {
+			bullet = fmt.Sprintf("[%s]●[-]", strings.ToLower(sheet.Color))
+		}
+
+		group := ""
+		if sheet.Group != "" {
+			group = fmt.Sprintf("[%s]", sheet.Group)
+		}
+
+		mainText := fmt.Sprintf(" %s %s %s %s%s%s", checkbox, icon, bullet, group, sheet.Name, badge)
 		secondaryText := fmt.Sprintf("   └─ %d cells with data", sheet.CellCount)
 
+		if selection.Has(i) {
+			mainText = "[black:aqua]" + mainText + "[-:-]"
+			secondaryText = "[black:aqua]" + secondaryText + "[-:-]"
+		}
+
 		list.AddItem(
 			mainText,
 			secondaryText,
@@ -289,7 +561,7 @@ func getWorkbookInfoText(info WorkbookInfo) string {
 	statusIcon := ""
 	statusColor := "green"
 	statusText := "Saved"
-	
+
 	if info.HasChanges {
 		statusIcon = "o"
 		statusColor = "yellow"
@@ -318,7 +590,7 @@ func getSheetInfoText(sheet SheetInfo, index, total int) string {
 	statusIcon := "o"
 	statusColor := "gray"
 	statusText := "Inactive"
-	
+
 	if sheet.IsActive {
 		statusIcon = ">"
 		statusColor = "yellow"
@@ -341,18 +613,46 @@ func getSheetInfoText(sheet SheetInfo, index, total int) string {
 	)
 }
 
+// getMultiSheetInfoText summarizes an aggregate selection of two or more
+// sheets, in place of the single-sheet detail view.
+func getMultiSheetInfoText(sheets []SheetInfo, selection *sheetSelection) string {
+	rows := selection.Sorted()
+
+	totalCells := 0
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row < 0 || row >= len(sheets) {
+			continue
+		}
+		totalCells += sheets[row].CellCount
+		names = append(names, sheets[row].Name)
+	}
+
+	return fmt.Sprintf(
+		"[::b]SELECTION[::-]\n"+
+			"[gray]━━━━━━━━━━━━━━━━━━━━[-]\n"+
+			"[lightblue]Sheets:[-]  [white::b]%d selected[::-]\n"+
+			"[lightblue]Names:[-]\n  %s\n"+
+			"[lightblue]Content:[-]\n"+
+			"  • Total Cells: [white]%d[-]",
+		len(rows),
+		strings.Join(names, ", "),
+		totalCells,
+	)
+}
+
 // showAddSheetDialog shows enhanced dialog to add a new sheet
-func showAddSheetDialog(app *tview.Application, 
-	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView) {
-	
+func showAddSheetDialog(app *tview.Application,
+	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) {
+
 	form := tview.NewForm()
 	form.SetFieldBackgroundColor(tcell.ColorBlack)
 	form.SetButtonBackgroundColor(tcell.ColorDarkGreen)
 	form.SetButtonTextColor(tcell.ColorWhite)
-	
+
 	sheets := callbacks.GetSheets()
 	defaultName := fmt.Sprintf("Sheet%d", len(sheets)+1)
-	
+
 	nameInput := tview.NewInputField().
 		SetLabel("Sheet Name: ").
 		SetText(defaultName).
@@ -373,7 +673,8 @@ func showAddSheetDialog(app *tview.Application,
 				return
 			}
 
-			updateSheetList(list, callbacks)
+			selection.Clear()
+			updateSheetList(list, callbacks, selection)
 			callbacks.UpdateTabBar()
 			infoPanel.SetText(getWorkbookInfoText(callbacks.GetWorkbookInfo()))
 			callbacks.MarkAsModified()
@@ -394,12 +695,11 @@ func showAddSheetDialog(app *tview.Application,
 
 // showRenameSheetFromManager shows enhanced rename dialog
 func showRenameSheetFromManager(app *tview.Application,
-	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView) {
-	
-	selectedIndex := list.GetCurrentItem()
+	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) {
+
 	sheets := callbacks.GetSheets()
-	
-	if selectedIndex < 0 || selectedIndex >= len(sheets) {
+	selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+	if !ok || selectedIndex >= len(sheets) {
 		return
 	}
 
@@ -409,7 +709,7 @@ func showRenameSheetFromManager(app *tview.Application,
 	form.SetFieldBackgroundColor(tcell.ColorBlack)
 	form.SetButtonBackgroundColor(tcell.ColorDarkBlue)
 	form.SetButtonTextColor(tcell.ColorWhite)
-	
+
 	nameInput := tview.NewInputField().
 		SetLabel(" New Name: ").
 		SetText(sheet.Name).
@@ -430,7 +730,7 @@ func showRenameSheetFromManager(app *tview.Application,
 				return
 			}
 
-			updateSheetList(list, callbacks)
+			updateSheetList(list, callbacks, selection)
 			callbacks.UpdateTabBar()
 			callbacks.UpdateTableTitle()
 			infoPanel.SetText(getWorkbookInfoText(callbacks.GetWorkbookInfo()))
@@ -443,32 +743,62 @@ func showRenameSheetFromManager(app *tview.Application,
 		})
 
 	form.SetBorder(true).
-		SetTitle( " Rename Sheet ").
+		SetTitle(" Rename Sheet ").
 		SetBorderColor(tcell.ColorBlue).
 		SetTitleAlign(tview.AlignCenter)
 
 	app.SetRoot(form, true).SetFocus(form)
 }
 
-// confirmDeleteSheetFromManager shows enhanced deletion confirmation
+// confirmDeleteSheetFromManager shows enhanced deletion confirmation. When
+// two or more rows are selected it deletes the whole selection instead of
+// just the cursor row.
 func confirmDeleteSheetFromManager(app *tview.Application,
-	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView) {
-	
+	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) {
+
 	sheets := callbacks.GetSheets()
 	if len(sheets) <= 1 {
 		ShowWarningModal(app, returnTo, "⚠️  Cannot delete the last sheet!\n\nA workbook must have at least one sheet.")
 		return
 	}
 
-	selectedIndex := list.GetCurrentItem()
-	if selectedIndex < 0 || selectedIndex >= len(sheets) {
-		return
-	}
+	rows := selection.Sorted()
+	bulk := len(rows) > 1
 
-	sheet := sheets[selectedIndex]
+	var promptText string
+	if bulk {
+		if len(rows) >= len(sheets) {
+			ShowWarningModal(app, returnTo, "⚠️  Cannot delete every sheet!\n\nA workbook must have at least one sheet.")
+			return
+		}
 
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf(
+		names := make([]string, len(rows))
+		totalCells := 0
+		for i, row := range rows {
+			names[i] = sheets[row].Name
+			totalCells += sheets[row].CellCount
+		}
+
+		promptText = fmt.Sprintf(
+			"[red::b]⚠️  DELETE %d SHEETS[::-]\n\n"+
+				"Are you sure you want to delete:\n"+
+				"[yellow]%s[-]?\n\n"+
+				"[white]This will permanently remove:[-]\n"+
+				"  • [white]%d[-] cells with data\n"+
+				"  • All formulas and formatting\n"+
+				"  • All undo/redo history\n\n"+
+				"[red::b]⚠️  This action cannot be undone![::-]",
+			len(rows), strings.Join(names, ", "), totalCells,
+		)
+	} else {
+		selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+		if !ok || selectedIndex >= len(sheets) {
+			return
+		}
+		rows = []int{selectedIndex}
+		sheet := sheets[selectedIndex]
+
+		promptText = fmt.Sprintf(
 			"[red::b]⚠️  DELETE SHEET[::-]\n\n"+
 				"Are you sure you want to delete:\n"+
 				"[yellow]'%s'[-]?\n\n"+
@@ -479,17 +809,28 @@ func confirmDeleteSheetFromManager(app *tview.Application,
 				"[red::b]⚠️  This action cannot be undone![::-]",
 			sheet.Name,
 			sheet.CellCount,
-		)).
+		)
+	}
+
+	modal := tview.NewModal().
+		SetText(promptText).
 		AddButtons([]string{"X Delete", "x Cancel"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			if strings.Contains(buttonLabel, "Delete") {
-				if err := callbacks.DeleteSheet(selectedIndex); err != nil {
+				var err error
+				if bulk {
+					err = callbacks.DeleteSheets(rows)
+				} else {
+					err = callbacks.DeleteSheet(rows[0])
+				}
+				if err != nil {
 					ShowWarningModal(app, returnTo, err.Error())
 					app.SetRoot(returnTo, true).SetFocus(list)
 					return
 				}
 
-				updateSheetList(list, callbacks)
+				selection.Clear()
+				updateSheetList(list, callbacks, selection)
 				callbacks.UpdateTabBar()
 				callbacks.RenderActiveSheet()
 				callbacks.UpdateTableTitle()
@@ -505,70 +846,97 @@ func confirmDeleteSheetFromManager(app *tview.Application,
 	app.SetRoot(modal, true).SetFocus(modal)
 }
 
-// duplicateSheetFromManager duplicates with visual feedback
+// duplicateSheetFromManager duplicates with visual feedback. When two or
+// more rows are selected, it duplicates the whole selection.
 func duplicateSheetFromManager(app *tview.Application, table *tview.Table,
-	callbacks SheetManagerCallbacks, list *tview.List, infoPanel *tview.TextView) {
-	
-	selectedIndex := list.GetCurrentItem()
+	callbacks SheetManagerCallbacks, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) {
+
 	sheets := callbacks.GetSheets()
-	
-	if selectedIndex < 0 || selectedIndex >= len(sheets) {
-		return
-	}
+	rows := selection.Sorted()
 
-	if err := callbacks.DuplicateSheet(selectedIndex); err != nil {
-		ShowWarningModal(app, table, "X "+err.Error())
-		return
+	if len(rows) > 1 {
+		if err := callbacks.DuplicateSheets(rows); err != nil {
+			ShowWarningModal(app, table, "X "+err.Error())
+			return
+		}
+	} else {
+		selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+		if !ok || selectedIndex >= len(sheets) {
+			return
+		}
+
+		if err := callbacks.DuplicateSheet(selectedIndex); err != nil {
+			ShowWarningModal(app, table, "X "+err.Error())
+			return
+		}
 	}
 
-	updateSheetList(list, callbacks)
+	selection.Clear()
+	updateSheetList(list, callbacks, selection)
 	callbacks.UpdateTabBar()
 	infoPanel.SetText(getWorkbookInfoText(callbacks.GetWorkbookInfo()))
 	callbacks.MarkAsModified()
 
-	list.SetCurrentItem(len(sheets))
+	list.SetCurrentItem(len(callbacks.GetSheets()) - 1)
 }
 
-// showMoveSheetDialog shows enhanced reorder dialog
+// showMoveSheetDialog shows enhanced reorder dialog. When two or more rows
+// are selected, it reorders the whole selection as a block.
 func showMoveSheetDialog(app *tview.Application,
-	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView) {
-	
-	selectedIndex := list.GetCurrentItem()
+	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) {
+
 	sheets := callbacks.GetSheets()
-	
-	if selectedIndex < 0 || selectedIndex >= len(sheets) {
-		return
+	rows := selection.Sorted()
+	bulk := len(rows) > 1
+
+	selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+	if !bulk {
+		if !ok || selectedIndex >= len(sheets) {
+			return
+		}
+		rows = []int{selectedIndex}
 	}
 
 	form := tview.NewForm()
 	form.SetFieldBackgroundColor(tcell.ColorBlack)
 	form.SetButtonBackgroundColor(tcell.ColorDarkGoldenrod)
 	form.SetButtonTextColor(tcell.ColorWhite)
-	
+
 	positions := make([]string, len(sheets))
 	for i := range sheets {
-		if i == selectedIndex {
-			positions[i] = fmt.Sprintf("Position %d (current)", i+1)
-		} else {
-			positions[i] = fmt.Sprintf("Position %d", i+1)
+		label := fmt.Sprintf("Position %d", i+1)
+		if (!bulk && i == selectedIndex) || (bulk && selection.Has(i)) {
+			label += " (current)"
 		}
+		positions[i] = label
+	}
+
+	defaultPos := selectedIndex
+	if bulk {
+		defaultPos = rows[0]
 	}
 
-	form.AddDropDown("Move to:", positions, selectedIndex, nil).
+	form.AddDropDown("Move to:", positions, defaultPos, nil).
 		AddButton("Move", func() {
 			newPos, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
-			
-			if newPos == selectedIndex {
-				app.SetRoot(returnTo, true).SetFocus(list)
-				return
-			}
 
-			if err := callbacks.MoveSheet(selectedIndex, newPos); err != nil {
+			var err error
+			if bulk {
+				err = callbacks.MoveSheets(rows, newPos)
+			} else {
+				if newPos == selectedIndex {
+					app.SetRoot(returnTo, true).SetFocus(list)
+					return
+				}
+				err = callbacks.MoveSheet(selectedIndex, newPos)
+			}
+			if err != nil {
 				ShowWarningModal(app, form, err.Error())
 				return
 			}
 
-			updateSheetList(list, callbacks)
+			selection.Clear()
+			updateSheetList(list, callbacks, selection)
 			callbacks.UpdateTabBar()
 			infoPanel.SetText(getWorkbookInfoText(callbacks.GetWorkbookInfo()))
 			callbacks.MarkAsModified()
@@ -588,14 +956,112 @@ func showMoveSheetDialog(app *tview.Application,
 	app.SetRoot(form, true).SetFocus(form)
 }
 
+// sheetColorOptions lists the color labels offered by the Organize dialog.
+// Values (other than "None") are lowercased and used directly as tview
+// color tags by updateSheetList's bullet.
+var sheetColorOptions = []string{"None", "Red", "Orange", "Yellow", "Green", "Blue", "Purple", "Gray"}
+
+// showOrganizeSheetDialog shows a dialog to set the color label and group
+// name of a sheet, or the whole selection when two or more rows are held.
+func showOrganizeSheetDialog(app *tview.Application,
+	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, infoPanel *tview.TextView, selection *sheetSelection) {
+
+	sheets := callbacks.GetSheets()
+	rows := selection.Sorted()
+	bulk := len(rows) > 1
+
+	if !bulk {
+		selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+		if !ok || selectedIndex >= len(sheets) {
+			return
+		}
+		rows = []int{selectedIndex}
+	}
+
+	defaultColor := "None"
+	defaultGroup := ""
+	if !bulk {
+		sheet := sheets[rows[0]]
+		defaultGroup = sheet.Group
+		for i, opt := range sheetColorOptions {
+			if strings.EqualFold(opt, sheet.Color) {
+				defaultColor = sheetColorOptions[i]
+				break
+			}
+		}
+	}
+
+	form := tview.NewForm()
+	form.SetFieldBackgroundColor(tcell.ColorBlack)
+	form.SetButtonBackgroundColor(tcell.ColorDarkMagenta)
+	form.SetButtonTextColor(tcell.ColorWhite)
+
+	colorIndex := 0
+	for i, opt := range sheetColorOptions {
+		if opt == defaultColor {
+			colorIndex = i
+		}
+	}
+
+	groupInput := tview.NewInputField().
+		SetLabel(" Group: ").
+		SetText(defaultGroup).
+		SetFieldWidth(30).
+		SetFieldBackgroundColor(tcell.ColorBlack).
+		SetFieldTextColor(tcell.ColorWhite)
+
+	form.AddDropDown(" Color:", sheetColorOptions, colorIndex, nil).
+		AddFormItem(groupInput).
+		AddButton("Apply", func() {
+			colorPos, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+			color := sheetColorOptions[colorPos]
+			if color == "None" {
+				color = ""
+			}
+			group := strings.TrimSpace(groupInput.GetText())
+
+			for _, row := range rows {
+				if err := callbacks.SetSheetColor(row, color); err != nil {
+					ShowWarningModal(app, form, err.Error())
+					return
+				}
+				if err := callbacks.SetSheetGroup(row, group); err != nil {
+					ShowWarningModal(app, form, err.Error())
+					return
+				}
+			}
+
+			updateSheetList(list, callbacks, selection)
+			callbacks.UpdateTabBar()
+			infoPanel.SetText(getWorkbookInfoText(callbacks.GetWorkbookInfo()))
+			callbacks.MarkAsModified()
+
+			app.SetRoot(returnTo, true).SetFocus(list)
+		}).
+		AddButton("Cancel", func() {
+			app.SetRoot(returnTo, true).SetFocus(list)
+		})
+
+	title := " Organize Sheet "
+	if bulk {
+		title = fmt.Sprintf(" Organize %d Sheets ", len(rows))
+	}
+
+	form.SetBorder(true).
+		SetTitle(title).
+		SetBorderColor(tcell.ColorPurple).
+		SetTitleAlign(tview.AlignCenter)
+
+	app.SetRoot(form, true).SetFocus(form)
+}
+
 // switchToSelectedSheet switches with smooth feedback
-func switchToSelectedSheet(app *tview.Application, table *tview.Table, 
-	callbacks SheetManagerCallbacks, list *tview.List) {
-	
-	selectedIndex := list.GetCurrentItem()
+func switchToSelectedSheet(app *tview.Application, table *tview.Table,
+	callbacks SheetManagerCallbacks, list *tview.List, selection *sheetSelection) {
+
 	sheets := callbacks.GetSheets()
-	
-	if selectedIndex < 0 || selectedIndex >= len(sheets) {
+	selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+	if !ok || selectedIndex >= len(sheets) {
 		return
 	}
 
@@ -603,9 +1069,26 @@ func switchToSelectedSheet(app *tview.Application, table *tview.Table,
 		return
 	}
 
+	SetActiveSheetScope(callbacks.GetWorkbookInfo().FileName + "#" + sheets[selectedIndex].Name)
+
 	callbacks.UpdateTabBar()
 	callbacks.UpdateTableTitle()
 	callbacks.RenderActiveSheet()
-	
+
 	app.SetRoot(table, true).SetFocus(table)
 }
+
+// showChartsManagerFromSheetManager resolves the list's current selection to
+// a single real sheet index, the same way showOrganizeSheetDialog does, and
+// opens ShowChartsManager scoped to that sheet.
+func showChartsManagerFromSheetManager(app *tview.Application,
+	callbacks SheetManagerCallbacks, returnTo tview.Primitive, list *tview.List, selection *sheetSelection) {
+
+	sheets := callbacks.GetSheets()
+	selectedIndex, ok := selection.Resolve(list.GetCurrentItem())
+	if !ok || selectedIndex >= len(sheets) {
+		return
+	}
+
+	ShowChartsManager(app, returnTo, callbacks, selectedIndex, sheets[selectedIndex].Name)
+}
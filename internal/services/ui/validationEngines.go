@@ -0,0 +1,201 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// validationEngines.go adds two alternative backends CheckValidationRule and
+// ValidateValidationRule can dispatch to alongside the default govaluate
+// expression engine: a pure-regex backend for rules like postal codes, SKUs,
+// or phone numbers, and a CEL (google/cel-go) backend for typed expressions
+// with compile-time error messages. Which engine a cell's rule runs through
+// is stored on cell.Cell.ValEngine, one of the ValidationEngine* constants
+// below, set alongside Valrule whenever a preset is applied.
+
+package ui
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"gosheet/internal/services/cell"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// ValidationEngine* names the backend a rule runs through. The zero value,
+// ValidationEngineGovaluate, is the long-standing default and every rule
+// written before ValEngine existed behaves as if set to it.
+const (
+	ValidationEngineGovaluate = ""
+	ValidationEngineRegex     = "regex"
+	ValidationEngineCEL       = "cel"
+)
+
+// --- Regex backend -----------------------------------------------------
+
+// regexMatchRulePattern matches the rule text the "Regex Match" preset
+// stores: RegexMatch("pattern"), with the pattern's own quotes backslash-
+// escaped the same way BuildRule writes them.
+var regexMatchRulePattern = regexp.MustCompile(`^RegexMatch\("((?:[^"\\]|\\.)*)"\)$`)
+
+// parseRegexMatchRule extracts a "Regex Match" rule's pattern, unescaping
+// the quotes BuildRule escaped when it wrote the rule text.
+func parseRegexMatchRule(rule string) (pattern string, ok bool) {
+	m := regexMatchRulePattern.FindStringSubmatch(strings.TrimSpace(rule))
+	if m == nil {
+		return "", false
+	}
+	return strings.ReplaceAll(m[1], `\"`, `"`), true
+}
+
+// validateRegexRule reports whether rule is a well-formed RegexMatch(...)
+// rule whose pattern itself compiles.
+func validateRegexRule(rule string) error {
+	pattern, ok := parseRegexMatchRule(rule)
+	if !ok {
+		return fmt.Errorf(`expected RegexMatch("pattern")`)
+	}
+	_, err := regexp.Compile(pattern)
+	return err
+}
+
+// checkRegexRule matches value against rule's pattern.
+func checkRegexRule(rule, value string, cellData *cell.Cell) (bool, string) {
+	pattern, ok := parseRegexMatchRule(rule)
+	if !ok {
+		return false, fmt.Sprintf("Invalid regex validation rule: %s", rule)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid regex pattern: %s", err.Error())
+	}
+	if re.MatchString(value) {
+		return true, ""
+	}
+	if cellData.Valrulemsg != nil && strings.TrimSpace(*cellData.Valrulemsg) != "" {
+		return false, *cellData.Valrulemsg
+	}
+	return false, fmt.Sprintf("Value does not match pattern: %s", pattern)
+}
+
+// --- CEL backend ---------------------------------------------------------
+
+// buildValidationCELEnv declares THIS (the cell value under test) alongside
+// CEL function overloads for every GovalFuncs helper the govaluate presets
+// rely on, so a rule can move between engines without being rewritten.
+func buildValidationCELEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("THIS", cel.DynType),
+		cel.Function("LEN",
+			cel.Overload("len_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(func(val ref.Val) ref.Val {
+					return types.Int(len(string(val.(types.String))))
+				}))),
+		cel.Function("FLOOR",
+			cel.Overload("floor_double", []*cel.Type{cel.DoubleType}, cel.DoubleType,
+				cel.UnaryBinding(func(val ref.Val) ref.Val {
+					return types.Double(math.Floor(float64(val.(types.Double))))
+				}))),
+		cel.Function("CONTAINS",
+			cel.Overload("contains_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					return types.Bool(strings.Contains(string(a.(types.String)), string(b.(types.String))))
+				}))),
+		cel.Function("SUBSTR",
+			cel.Overload("substr_string_int", []*cel.Type{cel.StringType, cel.IntType}, cel.StringType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					s := string(a.(types.String))
+					start := int(b.(types.Int))
+					if start < 0 || start > len(s) {
+						return types.String("")
+					}
+					return types.String(s[start:])
+				}))),
+		cel.Function("INDEX",
+			cel.Overload("index_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					return types.Int(strings.Index(string(a.(types.String)), string(b.(types.String))))
+				}))),
+	)
+}
+
+// validateCELRule reports rule's compile-time error, if any, so the dialog
+// can surface it in the preview TextView while the rule is still being
+// written rather than only the next time a cell is edited.
+func validateCELRule(rule string) error {
+	env, err := buildValidationCELEnv()
+	if err != nil {
+		return err
+	}
+	_, issues := env.Compile(rule)
+	if issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	return nil
+}
+
+// checkCELRule compiles and evaluates rule with THIS bound to value,
+// expecting a boolean result.
+func checkCELRule(rule, value string, cellData *cell.Cell) (bool, string) {
+	env, err := buildValidationCELEnv()
+	if err != nil {
+		return false, fmt.Sprintf("CEL environment error: %s", err.Error())
+	}
+
+	ast, issues := env.Compile(rule)
+	if issues != nil && issues.Err() != nil {
+		return false, fmt.Sprintf("Invalid CEL expression: %s", issues.Err().Error())
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		return false, fmt.Sprintf("CEL program error: %s", err.Error())
+	}
+
+	out, _, err := prg.Eval(map[string]any{"THIS": value})
+	if err != nil {
+		return false, fmt.Sprintf("CEL evaluation error: %s", err.Error())
+	}
+
+	isValid, ok := out.Value().(bool)
+	if !ok {
+		return false, "CEL expression must return true/false"
+	}
+
+	if !isValid {
+		if cellData.Valrulemsg != nil && strings.TrimSpace(*cellData.Valrulemsg) != "" {
+			return false, *cellData.Valrulemsg
+		}
+		return false, fmt.Sprintf("Value does not meet validation rule: %s", rule)
+	}
+
+	return true, ""
+}
+
+// validationEngineIssues compile-checks rule against preset's engine and
+// returns a preview annotation describing any problem, or "" if the engine
+// is govaluate (already checked by the evaluate-with-THIS=5 probe elsewhere)
+// or the rule compiles cleanly.
+func validationEngineIssues(preset ValidationPreset, rule string) string {
+	if strings.TrimSpace(rule) == "" {
+		return ""
+	}
+
+	var err error
+	switch preset.Engine {
+	case ValidationEngineCEL:
+		err = validateCELRule(rule)
+	case ValidationEngineRegex:
+		err = validateRegexRule(rule)
+	default:
+		return ""
+	}
+	if err == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n\n[red]Engine error: %s[-]", err.Error())
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// validationReportUI.go batch-checks validation rules across a selection or
+// whole sheet and reports the cells that currently fail them, for data that
+// arrived after a rule was written - bulk paste, CSV import, or a rule
+// edited retroactively - which EnforceValidationOnEdit never sees since it
+// only runs at edit time.
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gosheet/internal/services/cell"
+	"gosheet/internal/utils"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ValidationResult is one cell whose current value no longer satisfies its
+// own Valrule.
+type ValidationResult struct {
+	Row     int32
+	Col     int32
+	Value   string
+	Rule    string
+	Message string
+}
+
+// EnforceValidationOnRange checks every cell in cells against its own rule
+// and returns one ValidationResult per cell that currently fails, skipping
+// cells with no rule or no value (validation never applies to an empty
+// cell, matching EnforceValidationOnEdit/CheckValidationRule).
+func EnforceValidationOnRange(app *tview.Application, table *tview.Table, cells []*cell.Cell, globalData map[[2]int]*cell.Cell) []ValidationResult {
+	var results []ValidationResult
+	for _, cellData := range cells {
+		if cellData.Valrule == nil || strings.TrimSpace(*cellData.Valrule) == "" {
+			continue
+		}
+		value := ""
+		if cellData.RawValue != nil {
+			value = *cellData.RawValue
+		}
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		ok, reason := CheckValidationRule(cellData, value, globalData)
+		if ok {
+			continue
+		}
+
+		msg := reason
+		if cellData.Valrulemsg != nil && strings.TrimSpace(*cellData.Valrulemsg) != "" {
+			msg = *cellData.Valrulemsg
+		}
+		results = append(results, ValidationResult{
+			Row:     cellData.Row,
+			Col:     cellData.Column,
+			Value:   value,
+			Rule:    *cellData.Valrule,
+			Message: msg,
+		})
+	}
+	return results
+}
+
+// redrawViewport repaints every cell currently inside vp's window. It
+// duplicates table.RenderVisible's grid loop rather than calling it,
+// because table already imports this package (for indel.go's warning
+// modals) and the reverse import would cycle.
+func redrawViewport(table *tview.Table, vp *utils.Viewport, globalData map[[2]int]*cell.Cell) {
+	table.Clear()
+	table.SetCell(0, 0, tview.NewTableCell("").SetAlign(tview.AlignCenter))
+
+	for c := vp.LeftCol; c < vp.LeftCol+vp.ViewCols; c++ {
+		label := utils.ColumnName(int32(c))
+		colCell := cell.NewCell(0, int32(c), label)
+		table.SetCell(0, int(c-vp.LeftCol+1), colCell.ToTViewCell().SetAlign(tview.AlignCenter))
+	}
+
+	for r := vp.TopRow; r < vp.TopRow+vp.ViewRows; r++ {
+		label := fmt.Sprintf("%d", r)
+		rowCell := cell.NewCell(int32(r), 0, label)
+		rowCell.MinWidth = 2
+		rowCell.MaxWidth = int16(len(label)) + 2
+		table.SetCell(int(r-vp.TopRow+1), 0, rowCell.ToTViewCell())
+	}
+
+	for r := vp.TopRow; r < vp.TopRow+vp.ViewRows; r++ {
+		for c := vp.LeftCol; c < vp.LeftCol+vp.ViewCols; c++ {
+			key := [2]int{int(r), int(c)}
+			visualRow := r - vp.TopRow + 1
+			visualCol := c - vp.LeftCol + 1
+
+			var tvCell *tview.TableCell
+			if cellData, exists := globalData[key]; exists {
+				tvCell = markListValidationIndicator(markInvalidCell(cellData.ToTViewCell(), key), cellData)
+			} else {
+				tvCell = tview.NewTableCell("").
+					SetAlign(tview.AlignLeft).
+					SetTextColor(tcell.NewRGBColor(255, 255, 255)).
+					SetBackgroundColor(tcell.NewRGBColor(0, 0, 0))
+			}
+			table.SetCell(int(visualRow), int(visualCol), tvCell)
+		}
+	}
+}
+
+// jumpViewportToCell brings (row, col) into vp's window if it isn't already
+// visible, then selects it, so picking a row in the validation report moves
+// the sheet's cursor there the same way arrow-key navigation would.
+func jumpViewportToCell(table *tview.Table, vp *utils.Viewport, globalData map[[2]int]*cell.Cell, row, col int32) {
+	if !vp.IsVisible(row, col) {
+		if row < vp.TopRow || row >= vp.TopRow+vp.ViewRows {
+			vp.TopRow = max(1, row-vp.ViewRows/2)
+		}
+		if col < vp.LeftCol || col >= vp.LeftCol+vp.ViewCols {
+			vp.LeftCol = max(1, col-vp.ViewCols/2)
+		}
+		redrawViewport(table, vp, globalData)
+	}
+	visualRow, visualCol := vp.ToRelative(row, col)
+	table.Select(int(visualRow), int(visualCol))
+}
+
+// ShowValidationReport displays results in a scrollable list (address,
+// current value, rule, and message per row). Highlighting a row jumps the
+// viewport to that cell; activating one (Enter) opens a small edit dialog
+// to fix it in place, removing it from the report on success.
+func ShowValidationReport(app *tview.Application, table *tview.Table, returnTo tview.Primitive, focus tview.Primitive, results []ValidationResult, globalData map[[2]int]*cell.Cell, globalViewport *utils.Viewport) {
+	list := tview.NewList().
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan).
+		SetSelectedTextColor(tcell.ColorWhite).
+		SetMainTextColor(tcell.ColorWhite).
+		SetSecondaryTextColor(tcell.ColorGray).
+		ShowSecondaryText(true)
+	list.SetBorder(true).
+		SetBorderColor(tcell.ColorLightBlue).
+		SetTitleAlign(tview.AlignLeft)
+
+	helpText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[gray]↑/↓ to jump to a cell, Enter to fix it, Esc to close.[-]")
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(helpText, 1, 0, false)
+	container.SetBorder(true).
+		SetTitle(" Validation Report ").
+		SetBorderColor(tcell.ColorYellow).
+		SetTitleAlign(tview.AlignCenter)
+
+	closeReport := func() {
+		app.SetRoot(returnTo, true).SetFocus(focus)
+	}
+
+	var rebuild func()
+	rebuild = func() {
+		list.Clear()
+		list.SetTitle(fmt.Sprintf(" Validation Report (%d failing) ", len(results)))
+
+		if len(results) == 0 {
+			closeReport()
+			return
+		}
+
+		for i, res := range results {
+			i, res := i, res
+			ref := utils.ColumnName(res.Col) + strconv.Itoa(int(res.Row))
+			main := fmt.Sprintf("%s = %s", ref, res.Value)
+			secondary := fmt.Sprintf("Rule: %s  |  %s", res.Rule, res.Message)
+
+			list.AddItem(main, secondary, 0, func() {
+				key := [2]int{int(res.Row), int(res.Col)}
+				cellData, exists := globalData[key]
+				if !exists {
+					return
+				}
+				jumpViewportToCell(table, globalViewport, globalData, res.Row, res.Col)
+				showFixCellDialog(app, table, container, container, cellData, globalData, globalViewport, func() {
+					results = append(append([]ValidationResult{}, results[:i]...), results[i+1:]...)
+					rebuild()
+				})
+			})
+		}
+
+		list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+			if index < 0 || index >= len(results) {
+				return
+			}
+			res := results[index]
+			jumpViewportToCell(table, globalViewport, globalData, res.Row, res.Col)
+		})
+	}
+	rebuild()
+
+	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeReport()
+			return nil
+		}
+		return event
+	})
+
+	app.SetRoot(container, true).SetFocus(list)
+}
+
+// showFixCellDialog is the "Fix" action's edit dialog: a single field
+// pre-filled with the cell's current value, re-checked against its own
+// Valrule on save so a still-bad value re-opens the same validation-failed
+// modal EnforceValidationOnEdit shows during normal editing.
+func showFixCellDialog(app *tview.Application, table *tview.Table, returnTo tview.Primitive, focus tview.Primitive, cellData *cell.Cell, globalData map[[2]int]*cell.Cell, globalViewport *utils.Viewport, onFixed func()) {
+	currentValue := ""
+	if cellData.RawValue != nil {
+		currentValue = *cellData.RawValue
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("New value:", currentValue, 40, nil, nil)
+	form.SetBorder(true).
+		SetTitle(" Fix Invalid Value ").
+		SetBorderColor(tcell.ColorLightBlue)
+
+	closeFix := func() {
+		app.SetRoot(returnTo, true).SetFocus(focus)
+	}
+
+	form.AddButton("Save", func() {
+		newValue := form.GetFormItem(0).(*tview.InputField).GetText()
+
+		if ok, reason := CheckValidationRule(cellData, newValue, globalData); !ok {
+			displayMsg := reason
+			if cellData.Valrulemsg != nil && strings.TrimSpace(*cellData.Valrulemsg) != "" {
+				displayMsg = *cellData.Valrulemsg
+			}
+			modal := tview.NewModal().
+				SetText(fmt.Sprintf("Validation Failed!\n\n%s\n\nValidation Rule:\n%s", displayMsg, *cellData.Valrule)).
+				AddButtons([]string{"OK"}).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					app.SetRoot(form, true).SetFocus(form)
+				})
+			modal.SetBackgroundColor(tcell.ColorDarkRed).SetBorderColor(tcell.ColorRed)
+			modal.SetButtonBackgroundColor(tcell.ColorDarkRed).SetButtonTextColor(tcell.ColorWhite)
+
+			app.SetRoot(modal, true).SetFocus(modal)
+			return
+		}
+
+		cellData.RawValue = &newValue
+		display := newValue
+		cellData.Display = &display
+
+		key := [2]int{int(cellData.Row), int(cellData.Column)}
+		delete(invalidCells, scopeKey(key))
+		if globalViewport.IsVisible(cellData.Row, cellData.Column) {
+			visualR, visualC := globalViewport.ToRelative(cellData.Row, cellData.Column)
+			tvCell := markListValidationIndicator(markInvalidCell(cellData.ToTViewCell(), key), cellData)
+			table.SetCell(int(visualR), int(visualC), tvCell)
+		}
+
+		closeFix()
+		onFixed()
+	})
+	form.AddButton("Cancel", closeFix)
+	form.SetCancelFunc(closeFix)
+
+	app.SetRoot(form, true).SetFocus(form)
+}
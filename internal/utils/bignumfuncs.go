@@ -0,0 +1,242 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// bignumfuncs.go adds an arbitrary-precision integer/float backend for the
+// functions most prone to silent overflow at float64/int precision:
+// FACTORIAL, PRODUCT, GCD/LCM, and the bit operations. BIGFACT(100) and
+// friends return *big.Int/*big.Float values directly rather than a
+// pre-formatted string, so a chain of BIG* calls such as
+// BIGMOD(BIGFACT(100), BIGPOW(10, 20)) stays exact all the way through — the
+// value is only rendered to a decimal string by toString when it reaches a
+// cell. govaluate's own - * / operators accept only float64 operands, so an
+// expression like BIGFACT(100) / BIGFACT(98) is a type error.
+package utils
+
+import (
+	"math/big"
+
+	"github.com/Knetic/govaluate"
+)
+
+// bignumFunctions returns the BIG* function pack, merged into GovalFuncs().
+func bignumFunctions() map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		"BIGSUM": func(args ...any) (any, error) {
+			if err := validateArgs("BIGSUM", args, 2, -1); err != nil {
+				return nil, err
+			}
+			sum := new(big.Float)
+			for _, arg := range flattenRangeArgs(args) {
+				f, err := toBigFloat(arg)
+				if err != nil {
+					return ErrValue, nil
+				}
+				sum.Add(sum, f)
+			}
+			return sum, nil
+		},
+
+		"BIGPRODUCT": func(args ...any) (any, error) {
+			if err := validateArgs("BIGPRODUCT", args, 2, -1); err != nil {
+				return nil, err
+			}
+			product := big.NewFloat(1)
+			for _, arg := range flattenRangeArgs(args) {
+				f, err := toBigFloat(arg)
+				if err != nil {
+					return ErrValue, nil
+				}
+				product.Mul(product, f)
+			}
+			return product, nil
+		},
+
+		"BIGFACT": func(args ...any) (any, error) {
+			if err := validateArgs("BIGFACT", args, 1, 1); err != nil {
+				return nil, err
+			}
+			n, err := toBigInt(args[0])
+			if err != nil || n.Sign() < 0 {
+				return ErrNum, nil
+			}
+			result := big.NewInt(1)
+			one := big.NewInt(1)
+			for i := big.NewInt(1); i.Cmp(n) <= 0; i.Add(i, one) {
+				result.Mul(result, i)
+			}
+			return result, nil
+		},
+
+		"BIGGCD": func(args ...any) (any, error) {
+			if err := validateArgs("BIGGCD", args, 2, -1); err != nil {
+				return nil, err
+			}
+			result, err := toBigInt(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			result = new(big.Int).Abs(result)
+			for _, arg := range args[1:] {
+				n, err := toBigInt(arg)
+				if err != nil {
+					return ErrValue, nil
+				}
+				result.GCD(nil, nil, result, new(big.Int).Abs(n))
+			}
+			return result, nil
+		},
+
+		"BIGLCM": func(args ...any) (any, error) {
+			if err := validateArgs("BIGLCM", args, 2, -1); err != nil {
+				return nil, err
+			}
+			result, err := toBigInt(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			result = new(big.Int).Abs(result)
+			for _, arg := range args[1:] {
+				n, err := toBigInt(arg)
+				if err != nil {
+					return ErrValue, nil
+				}
+				n = new(big.Int).Abs(n)
+				if result.Sign() == 0 || n.Sign() == 0 {
+					result = big.NewInt(0)
+					continue
+				}
+				gcd := new(big.Int).GCD(nil, nil, result, n)
+				result = new(big.Int).Mul(result, n)
+				result.Div(result, gcd)
+			}
+			return result, nil
+		},
+
+		"BIGPOW": func(args ...any) (any, error) {
+			if err := validateArgs("BIGPOW", args, 2, 2); err != nil {
+				return nil, err
+			}
+			base, err := toBigInt(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			exp, err := toBigInt(args[1])
+			if err != nil || exp.Sign() < 0 {
+				return ErrNum, nil
+			}
+			return new(big.Int).Exp(base, exp, nil), nil
+		},
+
+		"BIGMOD": func(args ...any) (any, error) {
+			if err := validateArgs("BIGMOD", args, 2, 2); err != nil {
+				return nil, err
+			}
+			a, err := toBigInt(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			b, err := toBigInt(args[1])
+			if err != nil {
+				return ErrValue, nil
+			}
+			if b.Sign() == 0 {
+				return ErrDivZero, nil
+			}
+			return new(big.Int).Mod(a, b), nil
+		},
+
+		"BIGBITAND": bigBitwise("BIGBITAND", func(a, b *big.Int) *big.Int { return new(big.Int).And(a, b) }),
+		"BIGBITOR":  bigBitwise("BIGBITOR", func(a, b *big.Int) *big.Int { return new(big.Int).Or(a, b) }),
+		"BIGBITXOR": bigBitwise("BIGBITXOR", func(a, b *big.Int) *big.Int { return new(big.Int).Xor(a, b) }),
+
+		"BIGSHIFTLEFT": func(args ...any) (any, error) {
+			if err := validateArgs("BIGSHIFTLEFT", args, 2, 2); err != nil {
+				return nil, err
+			}
+			n, err := toBigInt(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			shift, err := toBigInt(args[1])
+			if err != nil || shift.Sign() < 0 {
+				return ErrNum, nil
+			}
+			return new(big.Int).Lsh(n, uint(shift.Uint64())), nil
+		},
+
+		"BIGSHIFTRIGHT": func(args ...any) (any, error) {
+			if err := validateArgs("BIGSHIFTRIGHT", args, 2, 2); err != nil {
+				return nil, err
+			}
+			n, err := toBigInt(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			shift, err := toBigInt(args[1])
+			if err != nil || shift.Sign() < 0 {
+				return ErrNum, nil
+			}
+			return new(big.Int).Rsh(n, uint(shift.Uint64())), nil
+		},
+	}
+}
+
+// bigBitwise builds a two-argument big.Int bitwise function, shared by
+// BIGBITAND/OR/XOR.
+func bigBitwise(name string, op func(a, b *big.Int) *big.Int) govaluate.ExpressionFunction {
+	return func(args ...any) (any, error) {
+		if err := validateArgs(name, args, 2, 2); err != nil {
+			return nil, err
+		}
+		a, err := toBigInt(args[0])
+		if err != nil {
+			return ErrValue, nil
+		}
+		b, err := toBigInt(args[1])
+		if err != nil {
+			return ErrValue, nil
+		}
+		return op(a, b), nil
+	}
+}
+
+// toBigInt coerces a formula argument to a *big.Int, the BIG* counterpart
+// to toFloat. It accepts an existing *big.Int/*big.Float unchanged (a
+// big value flowing out of one BIG* call and into another stays exact),
+// plus the same float64/int/string values every other function accepts.
+func toBigInt(v any) (*big.Int, error) {
+	switch val := v.(type) {
+	case *big.Int:
+		return val, nil
+	case *big.Float:
+		i, _ := val.Int(nil)
+		return i, nil
+	default:
+		f, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		bf := new(big.Float).SetFloat64(f)
+		i, _ := bf.Int(nil)
+		return i, nil
+	}
+}
+
+// toBigFloat coerces a formula argument to a *big.Float, the BIG* float
+// counterpart to toFloat.
+func toBigFloat(v any) (*big.Float, error) {
+	switch val := v.(type) {
+	case *big.Float:
+		return val, nil
+	case *big.Int:
+		return new(big.Float).SetInt(val), nil
+	default:
+		f, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewFloat(f), nil
+	}
+}
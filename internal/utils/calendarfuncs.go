@@ -0,0 +1,305 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// calendarfuncs.go adds the spreadsheet-standard business-day calendar
+// functions on top of DATEDIFF/DATEADD: NETWORKDAYS, WORKDAY, EOMONTH,
+// EDATE, and YEARFRAC. Which days count as weekends/holidays is pluggable
+// via the Calendar interface, so a caller can register a regional holiday
+// provider or a market whose weekend isn't Sat/Sun.
+package utils
+
+import (
+	"time"
+
+	"github.com/Knetic/govaluate"
+)
+
+// Calendar decides which dates are non-business days for NETWORKDAYS and
+// WORKDAY.
+type Calendar interface {
+	IsWeekend(t time.Time) bool
+	IsHoliday(t time.Time) bool
+}
+
+// HolidaySet is a Calendar backed by an explicit set of holiday dates
+// (keyed by midnight UTC) plus a configurable weekend definition, e.g. for
+// markets that treat Friday/Saturday as the weekend.
+type HolidaySet struct {
+	Holidays map[time.Time]struct{}
+	Weekend  map[time.Weekday]struct{}
+}
+
+// NewHolidaySet builds a HolidaySet with the standard Saturday/Sunday
+// weekend and the given holiday dates (any time-of-day is truncated to
+// midnight UTC to match the map's key convention).
+func NewHolidaySet(holidays ...time.Time) *HolidaySet {
+	set := &HolidaySet{
+		Holidays: make(map[time.Time]struct{}, len(holidays)),
+		Weekend: map[time.Weekday]struct{}{
+			time.Saturday: {},
+			time.Sunday:   {},
+		},
+	}
+	for _, h := range holidays {
+		set.Holidays[midnightUTC(h)] = struct{}{}
+	}
+	return set
+}
+
+// IsWeekend reports whether t falls on one of the configured weekend days.
+func (h *HolidaySet) IsWeekend(t time.Time) bool {
+	_, ok := h.Weekend[t.Weekday()]
+	return ok
+}
+
+// IsHoliday reports whether t (truncated to midnight UTC) is in Holidays.
+func (h *HolidaySet) IsHoliday(t time.Time) bool {
+	_, ok := h.Holidays[midnightUTC(t)]
+	return ok
+}
+
+func midnightUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// defaultCalendar is used by NETWORKDAYS/WORKDAY when a formula doesn't
+// pass its own holiday list; it has the standard Sat/Sun weekend and no
+// holidays. It's process-wide state, following the same pattern as
+// defaultSession in the table package and userFunctions.
+var defaultCalendar Calendar = NewHolidaySet()
+
+// SetDefaultCalendar registers the Calendar used by NETWORKDAYS/WORKDAY
+// calls that don't supply an explicit holiday list, e.g. to swap in a
+// regional holiday provider or a non-Sat/Sun weekend.
+func SetDefaultCalendar(cal Calendar) {
+	defaultCalendar = cal
+}
+
+func isBusinessDay(cal Calendar, t time.Time) bool {
+	return !cal.IsWeekend(t) && !cal.IsHoliday(t)
+}
+
+// calendarFromArgs builds a one-off HolidaySet from a NETWORKDAYS/WORKDAY
+// trailing holiday-date argument list, falling back to defaultCalendar when
+// none were supplied.
+func calendarFromArgs(args []any) (Calendar, error) {
+	if len(args) == 0 {
+		return defaultCalendar, nil
+	}
+	dates := make([]time.Time, 0, len(args))
+	for _, arg := range flattenRangeArgs(args) {
+		t, err := ParseDateTime(toString(arg))
+		if err != nil {
+			return nil, ErrValue
+		}
+		dates = append(dates, t)
+	}
+	return NewHolidaySet(dates...), nil
+}
+
+func calendarFunctions() map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		"NETWORKDAYS": func(args ...any) (any, error) {
+			if err := validateArgs("NETWORKDAYS", args, 2, -1); err != nil {
+				return nil, err
+			}
+			start, err := ParseDateTime(toString(args[0]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			end, err := ParseDateTime(toString(args[1]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			cal, err := calendarFromArgs(args[2:])
+			if err != nil {
+				return ErrValue, nil
+			}
+
+			sign := 1
+			if end.Before(start) {
+				start, end = end, start
+				sign = -1
+			}
+			count := 0
+			for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+				if isBusinessDay(cal, d) {
+					count++
+				}
+			}
+			return float64(sign * count), nil
+		},
+
+		"WORKDAY": func(args ...any) (any, error) {
+			if err := validateArgs("WORKDAY", args, 2, -1); err != nil {
+				return nil, err
+			}
+			start, err := ParseDateTime(toString(args[0]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			n, err := toFloat(args[1])
+			if err != nil {
+				return ErrValue, nil
+			}
+			cal, err := calendarFromArgs(args[2:])
+			if err != nil {
+				return ErrValue, nil
+			}
+
+			step := 1
+			remaining := int(n)
+			if remaining < 0 {
+				step = -1
+				remaining = -remaining
+			}
+			d := start
+			for remaining > 0 {
+				d = d.AddDate(0, 0, step)
+				if isBusinessDay(cal, d) {
+					remaining--
+				}
+			}
+			return d.Format("2006-01-02"), nil
+		},
+
+		"EOMONTH": func(args ...any) (any, error) {
+			if err := validateArgs("EOMONTH", args, 2, 2); err != nil {
+				return nil, err
+			}
+			t, err := ParseDateTime(toString(args[0]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			months, err := toFloat(args[1])
+			if err != nil {
+				return ErrValue, nil
+			}
+			// First day of the target month, plus one month, minus one day,
+			// lands on the last day of the target month regardless of its length.
+			firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+			lastDay := firstOfMonth.AddDate(0, int(months)+1, -1)
+			return lastDay.Format("2006-01-02"), nil
+		},
+
+		"EDATE": func(args ...any) (any, error) {
+			if err := validateArgs("EDATE", args, 2, 2); err != nil {
+				return nil, err
+			}
+			t, err := ParseDateTime(toString(args[0]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			months, err := toFloat(args[1])
+			if err != nil {
+				return ErrValue, nil
+			}
+			return addMonthsClamped(t, int(months)).Format("2006-01-02"), nil
+		},
+
+		"YEARFRAC": func(args ...any) (any, error) {
+			if err := validateArgs("YEARFRAC", args, 2, 3); err != nil {
+				return nil, err
+			}
+			start, err := ParseDateTime(toString(args[0]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			end, err := ParseDateTime(toString(args[1]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			basis := 0.0
+			if len(args) == 3 {
+				basis, err = toFloat(args[2])
+				if err != nil {
+					return ErrValue, nil
+				}
+			}
+			frac, err := yearFrac(start, end, int(basis))
+			if err != nil {
+				return ErrNum, nil
+			}
+			return frac, nil
+		},
+	}
+}
+
+// addMonthsClamped adds months to t, clamping the day-of-month to the
+// target month's last day when the original day doesn't exist there (e.g.
+// Jan 31 + 1 month -> Feb 28 or 29, never rolling over into March).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+	lastDayOfTarget := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDayOfTarget {
+		day = lastDayOfTarget
+	}
+	return time.Date(target.Year(), target.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// yearFrac computes the fraction of a year between start and end under one
+// of Excel's four day-count bases: 0 = 30/360 US (NASD), 1 = actual/actual,
+// 2 = actual/360, 3 = actual/365.
+func yearFrac(start, end time.Time, basis int) (float64, error) {
+	sign := 1.0
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+
+	switch basis {
+	case 0:
+		return sign * days30360US(start, end) / 360, nil
+	case 2:
+		return sign * end.Sub(start).Hours() / 24 / 360, nil
+	case 3:
+		return sign * end.Sub(start).Hours() / 24 / 365, nil
+	case 1:
+		return sign * actualActualYearFrac(start, end), nil
+	default:
+		return 0, ErrNum
+	}
+}
+
+// days30360US implements the 30/360 US (NASD) day-count convention.
+func days30360US(start, end time.Time) float64 {
+	d1, d2 := start.Day(), end.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	years := end.Year() - start.Year()
+	months := int(end.Month()) - int(start.Month())
+	days := d2 - d1
+	return float64(years*360 + months*30 + days)
+}
+
+// actualActualYearFrac divides the actual day count by each covered year's
+// actual length (365 or 366), weighting a span across a year boundary by
+// how many of its days fall in each calendar year.
+func actualActualYearFrac(start, end time.Time) float64 {
+	if start.Year() == end.Year() {
+		return end.Sub(start).Hours() / 24 / daysInYear(start.Year())
+	}
+	total := 0.0
+	yearEnd := time.Date(start.Year()+1, 1, 1, 0, 0, 0, 0, start.Location())
+	total += yearEnd.Sub(start).Hours() / 24 / daysInYear(start.Year())
+	for y := start.Year() + 1; y < end.Year(); y++ {
+		total += 1.0
+	}
+	yearStart := time.Date(end.Year(), 1, 1, 0, 0, 0, 0, end.Location())
+	total += end.Sub(yearStart).Hours() / 24 / daysInYear(end.Year())
+	return total
+}
+
+func daysInYear(year int) float64 {
+	if time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+		return 366
+	}
+	return 365
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddMonthsClampedMonthEnd(t *testing.T) {
+	cases := []struct {
+		name   string
+		start  time.Time
+		months int
+		want   time.Time
+	}{
+		{
+			name:   "Jan 31 + 1 month clamps to Feb 28 (non-leap year)",
+			start:  time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			months: 1,
+			want:   time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Jan 31 + 1 month clamps to Feb 29 (leap year)",
+			start:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			months: 1,
+			want:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Mar 31 - 1 month clamps to Feb 28 (non-leap year)",
+			start:  time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+			months: -1,
+			want:   time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "day preserved when the target month is long enough",
+			start:  time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			months: 1,
+			want:   time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := addMonthsClamped(c.start, c.months); !got.Equal(c.want) {
+				t.Errorf("addMonthsClamped(%v, %d) = %v, want %v", c.start, c.months, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDaysInYearLeapYear(t *testing.T) {
+	cases := map[int]float64{
+		2023: 365, // not divisible by 4
+		2024: 366, // divisible by 4, not by 100
+		1900: 365, // divisible by 100, not by 400
+		2000: 366, // divisible by 400
+	}
+	for year, want := range cases {
+		if got := daysInYear(year); got != want {
+			t.Errorf("daysInYear(%d) = %v, want %v", year, got, want)
+		}
+	}
+}
+
+func TestYearFracActualActualAcrossLeapYear(t *testing.T) {
+	start := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	frac, err := yearFrac(start, end, 1)
+	if err != nil {
+		t.Fatalf("yearFrac error = %v, want nil", err)
+	}
+	// 31 days remaining in 2023 (365-day year) plus 60 days in 2024 up to
+	// Mar 1 (a leap year, so Jan+Feb = 31+29 = 60 days).
+	want := 31.0/365 + 60.0/366
+	if diff := frac - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("yearFrac across leap-year boundary = %v, want %v", frac, want)
+	}
+}
+
+func TestYearFracNegativeSpanIsSignedLikeExcel(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	frac, err := yearFrac(start, end, 3)
+	if err != nil {
+		t.Fatalf("yearFrac error = %v, want nil", err)
+	}
+	if frac >= 0 {
+		t.Errorf("yearFrac(start after end) = %v, want a negative value", frac)
+	}
+}
+
+func TestHolidaySetWeekendAndHoliday(t *testing.T) {
+	holiday := time.Date(2024, 7, 4, 15, 30, 0, 0, time.UTC)
+	cal := NewHolidaySet(holiday)
+
+	if !cal.IsHoliday(time.Date(2024, 7, 4, 9, 0, 0, 0, time.UTC)) {
+		t.Error("IsHoliday should ignore time-of-day and match on the calendar date")
+	}
+	if cal.IsHoliday(time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Error("IsHoliday should not match an unrelated date")
+	}
+
+	saturday := time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC)
+	if !cal.IsWeekend(saturday) {
+		t.Error("IsWeekend should treat Saturday as a weekend day by default")
+	}
+	monday := time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)
+	if cal.IsWeekend(monday) {
+		t.Error("IsWeekend should not treat Monday as a weekend day by default")
+	}
+}
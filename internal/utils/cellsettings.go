@@ -0,0 +1,39 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// cellsettings.go centralizes the workbook file-format version tag and the
+// blank-cell defaults fileop's readers (open.go, ods.go, xlsx.go) stamp onto
+// every cell.Cell they synthesize, so the "what does an empty cell look
+// like" answer lives in one place instead of being copied at each call
+// site. The ALL_CAPS naming (unlike this package's other exported
+// constants) matches the on-disk .gsheet FILEVER tag and predates this
+// file; it's kept as-is rather than renamed out from under every reader.
+package utils
+
+import "gosheet/internal/services/cell"
+
+// FILEVER is written as WorkbookData.Version on every save and used to
+// distinguish a legacy single-sheet file (Version == "") from the
+// multi-sheet workbook format.
+const FILEVER = "2.0"
+
+// Default blank-cell geometry and number formatting, applied when a reader
+// builds a cell.Cell with no saved style of its own (see openTxtFile).
+const (
+	DEFAULT_CELL_MAX_WIDTH           = int16(20)
+	DEFAULT_CELL_MIN_WIDTH           = int16(8)
+	DEFAULT_CELL_DECIMAL_POINTS      = int8(2)
+	DEFAULT_CELL_THOUSANDS_SEPARATOR = rune(',')
+	DEFAULT_CELL_DECIMAL_SEPARATOR   = rune('.')
+	DEFAULT_CELL_FINANCIAL_SIGN      = rune('$')
+)
+
+// ColorOptions names the default text/background colors a new cell gets,
+// the cell package's White/Black constants under the labels fileop's
+// readers and HTML exporter key into.
+var ColorOptions = map[string]cell.Color{
+	"White": cell.White,
+	"Black": cell.Black,
+}
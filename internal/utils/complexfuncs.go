@@ -0,0 +1,246 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// complexfuncs.go provides Excel's IM* complex-number function suite,
+// backed by math/cmplx. Complex numbers are passed around as strings in
+// Excel's own "a+bi" notation, matching how every other cell value already
+// flows through the formula engine.
+
+package utils
+
+import (
+	"fmt"
+	"math/cmplx"
+	"strconv"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+)
+
+// complexFunctions returns the IM* function pack, merged into GovalFuncs().
+func complexFunctions() map[string]govaluate.ExpressionFunction {
+	unary := func(name string, f func(complex128) complex128) govaluate.ExpressionFunction {
+		return func(args ...any) (any, error) {
+			if err := validateArgs(name, args, 1, 1); err != nil {
+				return nil, err
+			}
+			c, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return formatComplex(f(c)), nil
+		}
+	}
+
+	binary := func(name string, f func(a, b complex128) complex128) govaluate.ExpressionFunction {
+		return func(args ...any) (any, error) {
+			if err := validateArgs(name, args, 2, 2); err != nil {
+				return nil, err
+			}
+			a, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			b, err := parseComplex(args[1])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return formatComplex(f(a, b)), nil
+		}
+	}
+
+	return map[string]govaluate.ExpressionFunction{
+		"COMPLEX": func(args ...any) (any, error) {
+			if err := validateArgs("COMPLEX", args, 2, 3); err != nil {
+				return nil, err
+			}
+			re, err := toFloat(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			im, err := toFloat(args[1])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return formatComplex(complex(re, im)), nil
+		},
+
+		"IMREAL": func(args ...any) (any, error) {
+			if err := validateArgs("IMREAL", args, 1, 1); err != nil {
+				return nil, err
+			}
+			c, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return real(c), nil
+		},
+
+		"IMAGINARY": func(args ...any) (any, error) {
+			if err := validateArgs("IMAGINARY", args, 1, 1); err != nil {
+				return nil, err
+			}
+			c, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return imag(c), nil
+		},
+
+		"IMABS": func(args ...any) (any, error) {
+			if err := validateArgs("IMABS", args, 1, 1); err != nil {
+				return nil, err
+			}
+			c, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return cmplx.Abs(c), nil
+		},
+
+		"IMARGUMENT": func(args ...any) (any, error) {
+			if err := validateArgs("IMARGUMENT", args, 1, 1); err != nil {
+				return nil, err
+			}
+			c, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return cmplx.Phase(c), nil
+		},
+
+		"IMCONJUGATE": unary("IMCONJUGATE", cmplx.Conj),
+		"IMEXP":       unary("IMEXP", cmplx.Exp),
+		"IMLN":        unary("IMLN", cmplx.Log),
+		"IMLOG10":     unary("IMLOG10", cmplx.Log10),
+		"IMSQRT":      unary("IMSQRT", cmplx.Sqrt),
+		"IMSIN":       unary("IMSIN", cmplx.Sin),
+		"IMCOS":       unary("IMCOS", cmplx.Cos),
+		"IMTAN":       unary("IMTAN", cmplx.Tan),
+
+		"IMSUM": func(args ...any) (any, error) {
+			if err := validateArgs("IMSUM", args, 2, -1); err != nil {
+				return nil, err
+			}
+			sum := complex(0, 0)
+			for _, arg := range flattenRangeArgs(args) {
+				c, err := parseComplex(arg)
+				if err != nil {
+					return ErrNum, nil
+				}
+				sum += c
+			}
+			return formatComplex(sum), nil
+		},
+
+		"IMSUB":     binary("IMSUB", func(a, b complex128) complex128 { return a - b }),
+		"IMPRODUCT": binary("IMPRODUCT", func(a, b complex128) complex128 { return a * b }),
+		"IMDIV": func(args ...any) (any, error) {
+			if err := validateArgs("IMDIV", args, 2, 2); err != nil {
+				return nil, err
+			}
+			a, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			b, err := parseComplex(args[1])
+			if err != nil {
+				return ErrNum, nil
+			}
+			if b == 0 {
+				return ErrDivZero, nil
+			}
+			return formatComplex(a / b), nil
+		},
+		"IMPOWER": func(args ...any) (any, error) {
+			if err := validateArgs("IMPOWER", args, 2, 2); err != nil {
+				return nil, err
+			}
+			c, err := parseComplex(args[0])
+			if err != nil {
+				return ErrNum, nil
+			}
+			exp, err := toFloat(args[1])
+			if err != nil {
+				return ErrNum, nil
+			}
+			return formatComplex(cmplx.Pow(c, complex(exp, 0))), nil
+		},
+	}
+}
+
+// parseComplex parses Excel's "a+bi"/"a-bj" notation (and bare real numbers)
+// into a complex128.
+func parseComplex(v any) (complex128, error) {
+	if f, err := toFloat(v); err == nil {
+		return complex(f, 0), nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return 0, ErrNum
+	}
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "i")
+	s = strings.TrimSuffix(s, "j")
+
+	// Find the sign that separates the real and imaginary parts, skipping
+	// over a leading sign and any exponent sign ("1e-5").
+	splitAt := -1
+	for i := len(s) - 1; i > 0; i-- {
+		if (s[i] == '+' || s[i] == '-') && s[i-1] != 'e' && s[i-1] != 'E' {
+			splitAt = i
+			break
+		}
+	}
+
+	if splitAt == -1 {
+		im, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, ErrNum
+		}
+		return complex(0, im), nil
+	}
+
+	reStr, imStr := s[:splitAt], s[splitAt:]
+	re, err := strconv.ParseFloat(reStr, 64)
+	if err != nil {
+		return 0, ErrNum
+	}
+	if imStr == "+" {
+		imStr = "1"
+	} else if imStr == "-" {
+		imStr = "-1"
+	}
+	im, err := strconv.ParseFloat(imStr, 64)
+	if err != nil {
+		return 0, ErrNum
+	}
+	return complex(re, im), nil
+}
+
+// formatComplex renders a complex128 in Excel's "a+bi" notation.
+func formatComplex(c complex128) string {
+	re, im := real(c), imag(c)
+	if im == 0 {
+		return trimFloat(re)
+	}
+	if re == 0 {
+		return trimFloat(im) + "i"
+	}
+	sign := "+"
+	if im < 0 {
+		sign = "-"
+		im = -im
+	}
+	return fmt.Sprintf("%s%s%si", trimFloat(re), sign, trimFloat(im))
+}
+
+func trimFloat(f float64) string {
+	if f == float64(int64(f)) {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
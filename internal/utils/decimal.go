@@ -0,0 +1,294 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// decimal.go adds an opt-in arbitrary-precision numeric mode for money-safe
+// arithmetic. By default every helper funnels through float64 via toFloat,
+// so currency columns can lose precision (0.1+0.2 == 0.30000000000000004).
+// In Decimal mode, the functions listed below go through Number instead,
+// backed by math/big.Float at a configurable precision. There is no
+// external decimal dependency vendored into this tree, so big.Float is used
+// rather than shopspring/decimal; the two make the same money-safe
+// tradeoff (exact base-2 arithmetic at high precision instead of exact
+// base-10 arithmetic), which is enough to fix the 0.1+0.2 case.
+//
+// Transcendental functions (SIN, LOG, EXP, ...) and govaluate's own native
+// +/-/*// operators still compute in float64 — this is the documented
+// precision cap the request calls for.
+package utils
+
+import (
+	"math/big"
+)
+
+// NumericMode selects how the formula engine represents numbers.
+type NumericMode int
+
+const (
+	// Float64 is the default: every number is a plain float64.
+	Float64 NumericMode = iota
+	// Decimal computes ABS/ROUND/POW/SUM on arbitrary-precision Number
+	// values instead; comparisons and the financial functions such as PMT
+	// stay in float64.
+	Decimal
+)
+
+// DefaultDecimalPrecision is the big.Float mantissa precision (in bits) used
+// when Decimal mode is active. 200 bits is comfortably beyond float64's 53
+// bits while staying fast enough for interactive recalculation.
+const DefaultDecimalPrecision = 200
+
+var (
+	numericMode           = Float64
+	decimalPrecision uint = DefaultDecimalPrecision
+)
+
+// SetNumericMode switches the formula engine between Float64 and Decimal
+// mode. It is workbook-level, process-wide state, following the same
+// pattern as defaultSession in the table package and userFunctions: formulas are evaluated from
+// many call sites that have no natural place to thread a mode value through.
+func SetNumericMode(mode NumericMode) {
+	numericMode = mode
+}
+
+// GetNumericMode returns the currently active numeric mode.
+func GetNumericMode() NumericMode {
+	return numericMode
+}
+
+// SetDecimalPrecision configures the big.Float mantissa precision (in bits)
+// used while in Decimal mode.
+func SetDecimalPrecision(bits uint) {
+	if bits == 0 {
+		bits = DefaultDecimalPrecision
+	}
+	decimalPrecision = bits
+}
+
+// Number is an arithmetic value that behaves the same way whether the
+// formula engine is running in Float64 or Decimal mode.
+type Number interface {
+	Add(other Number) Number
+	Sub(other Number) Number
+	Mul(other Number) Number
+	Quo(other Number) (Number, error)
+	Cmp(other Number) int
+	Abs() Number
+	Neg() Number
+	Round(places int, halfToEven bool) Number
+	Float64() float64
+	String() string
+}
+
+// floatNumber is the Float64-mode Number, a thin wrapper over float64.
+type floatNumber float64
+
+func (n floatNumber) Add(other Number) Number { return floatNumber(float64(n) + other.Float64()) }
+func (n floatNumber) Sub(other Number) Number { return floatNumber(float64(n) - other.Float64()) }
+func (n floatNumber) Mul(other Number) Number { return floatNumber(float64(n) * other.Float64()) }
+func (n floatNumber) Quo(other Number) (Number, error) {
+	if other.Float64() == 0 {
+		return nil, ErrDivZero
+	}
+	return floatNumber(float64(n) / other.Float64()), nil
+}
+func (n floatNumber) Cmp(other Number) int {
+	switch {
+	case float64(n) < other.Float64():
+		return -1
+	case float64(n) > other.Float64():
+		return 1
+	default:
+		return 0
+	}
+}
+func (n floatNumber) Abs() Number      { return floatNumber(bigAbsFloat64(float64(n))) }
+func (n floatNumber) Neg() Number      { return floatNumber(-n) }
+func (n floatNumber) Float64() float64 { return float64(n) }
+func (n floatNumber) String() string   { return trimFloat(float64(n)) }
+
+// Round rounds n to places decimal digits using plain float64 arithmetic -
+// Float64 mode makes no precision guarantees beyond what float64 already
+// offers, so there's no extra precision to preserve here the way
+// decimalNumber.Round must.
+func (n floatNumber) Round(places int, halfToEven bool) Number {
+	scale := pow10(places)
+	scaled := float64(n) * scale
+	var rounded float64
+	if halfToEven {
+		rounded = roundHalfToEven(scaled)
+	} else {
+		rounded = roundHalfAwayFromZero(scaled)
+	}
+	return floatNumber(rounded / scale)
+}
+
+func bigAbsFloat64(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// decimalNumber is the Decimal-mode Number, backed by math/big.Float at
+// decimalPrecision bits.
+type decimalNumber struct{ v *big.Float }
+
+func newDecimal(f float64) decimalNumber {
+	return decimalNumber{v: new(big.Float).SetPrec(decimalPrecision).SetFloat64(f)}
+}
+
+func (n decimalNumber) Add(other Number) Number {
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Add(n.v, asBigFloat(other))}
+}
+func (n decimalNumber) Sub(other Number) Number {
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Sub(n.v, asBigFloat(other))}
+}
+func (n decimalNumber) Mul(other Number) Number {
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Mul(n.v, asBigFloat(other))}
+}
+func (n decimalNumber) Quo(other Number) (Number, error) {
+	o := asBigFloat(other)
+	if o.Sign() == 0 {
+		return nil, ErrDivZero
+	}
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Quo(n.v, o)}, nil
+}
+func (n decimalNumber) Cmp(other Number) int { return n.v.Cmp(asBigFloat(other)) }
+func (n decimalNumber) Abs() Number {
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Abs(n.v)}
+}
+func (n decimalNumber) Neg() Number {
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Neg(n.v)}
+}
+func (n decimalNumber) Float64() float64 { f, _ := n.v.Float64(); return f }
+func (n decimalNumber) String() string   { return n.v.Text('f', -1) }
+
+// Round rounds n to places decimal digits entirely in big.Float/big.Int
+// arithmetic, never collapsing through Float64 - that round-trip would
+// reintroduce the float64 precision loss Decimal mode exists to avoid.
+// It scales n by 10^places, truncates to the integer part, decides
+// whether to round that integer up from the fractional remainder (half
+// away from zero, or half to even when halfToEven is true), then scales
+// back down.
+func (n decimalNumber) Round(places int, halfToEven bool) Number {
+	scale := new(big.Float).SetPrec(decimalPrecision).SetFloat64(pow10(places))
+	scaled := new(big.Float).SetPrec(decimalPrecision).Mul(n.v, scale)
+
+	neg := scaled.Sign() < 0
+	if neg {
+		scaled.Neg(scaled)
+	}
+
+	whole, _ := scaled.Int(nil)
+	wholeFloat := new(big.Float).SetPrec(decimalPrecision).SetInt(whole)
+	frac := new(big.Float).SetPrec(decimalPrecision).Sub(scaled, wholeFloat)
+
+	half := big.NewFloat(0.5).SetPrec(decimalPrecision)
+	roundUp := false
+	switch frac.Cmp(half) {
+	case 1:
+		roundUp = true
+	case 0:
+		if halfToEven {
+			roundUp = whole.Bit(0) != 0
+		} else {
+			roundUp = true
+		}
+	}
+	if roundUp {
+		whole.Add(whole, big.NewInt(1))
+	}
+
+	result := new(big.Float).SetPrec(decimalPrecision).SetInt(whole)
+	if neg {
+		result.Neg(result)
+	}
+	return decimalNumber{new(big.Float).SetPrec(decimalPrecision).Quo(result, scale)}
+}
+
+func asBigFloat(n Number) *big.Float {
+	if d, ok := n.(decimalNumber); ok {
+		return d.v
+	}
+	return new(big.Float).SetPrec(decimalPrecision).SetFloat64(n.Float64())
+}
+
+// toNumber converts a formula argument into a Number in the currently
+// active numeric mode, the Number-mode counterpart to toFloat.
+func toNumber(v any) (Number, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	if numericMode == Decimal {
+		return newDecimal(f), nil
+	}
+	return floatNumber(f), nil
+}
+
+// numberFromFloat wraps a plain float64 as a Number in the active mode,
+// e.g. the accumulator seed for SUM.
+func numberFromFloat(f float64) Number {
+	if numericMode == Decimal {
+		return newDecimal(f)
+	}
+	return floatNumber(f)
+}
+
+// roundNumber rounds n to places decimal digits. When halfToEven is true it
+// rounds half-to-even (banker's rounding), matching how financial systems
+// round to avoid systematic upward bias; otherwise it rounds half away from
+// zero, matching Excel's ROUND. It rounds through n's own Round method, so
+// Decimal-mode values stay in big.Float arithmetic throughout rather than
+// collapsing to float64 and back.
+func roundNumber(n Number, places int, halfToEven bool) Number {
+	return n.Round(places, halfToEven)
+}
+
+func pow10(n int) float64 {
+	result := 1.0
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	if neg {
+		return 1 / result
+	}
+	return result
+}
+
+func roundHalfAwayFromZero(f float64) float64 {
+	if f < 0 {
+		return -roundHalfAwayFromZero(-f)
+	}
+	whole := float64(int64(f))
+	frac := f - whole
+	if frac >= 0.5 {
+		return whole + 1
+	}
+	return whole
+}
+
+func roundHalfToEven(f float64) float64 {
+	if f < 0 {
+		return -roundHalfToEven(-f)
+	}
+	whole := float64(int64(f))
+	frac := f - whole
+	switch {
+	case frac < 0.5:
+		return whole
+	case frac > 0.5:
+		return whole + 1
+	default:
+		if int64(whole)%2 == 0 {
+			return whole
+		}
+		return whole + 1
+	}
+}
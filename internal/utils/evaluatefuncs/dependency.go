@@ -0,0 +1,151 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// dependency.go orders cross-sheet formula recalculation topologically and
+// rejects cycles, the same way excelize and other mature spreadsheet
+// engines refuse to recalculate a workbook where two cells (possibly on
+// different sheets) depend on each other. Nodes are addressed as
+// "Sheet!A1" strings, matching cell.Cell's existing DependsOn/Dependents
+// convention.
+
+package evaluatefuncs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependencyGraph is a directed graph of "Sheet!A1"-style cell addresses,
+// edge A->B meaning "A's formula reads B".
+type DependencyGraph struct {
+	edges map[string][]string
+}
+
+// NewDependencyGraph returns an empty graph.
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{edges: make(map[string][]string)}
+}
+
+// AddEdge records that from's formula depends on to, registering both ends
+// as nodes even if to has no dependencies of its own.
+func (g *DependencyGraph) AddEdge(from, to string) {
+	g.edges[from] = append(g.edges[from], to)
+	if _, ok := g.edges[to]; !ok {
+		g.edges[to] = nil
+	}
+}
+
+// Dependencies returns the nodes node's formula directly reads, i.e. the
+// other end of every edge leaving node. Used after TopoOrder to check
+// whether a node's upstream cells evaluated cleanly before evaluating it.
+func (g *DependencyGraph) Dependencies(node string) []string {
+	return g.edges[node]
+}
+
+// CycleError reports a dependency cycle discovered during TopoOrder, naming
+// every cell address involved so the caller can point the user at it.
+type CycleError struct {
+	Cells []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("circular reference detected: %s", strings.Join(e.Cells, " -> "))
+}
+
+// TopoOrder returns the graph's nodes in dependency order (a node appears
+// only after everything it depends on) or a *CycleError if recalculation
+// cannot proceed. It uses Tarjan's algorithm to find strongly connected
+// components first: any SCC bigger than a single node, or a single node
+// with a self-edge, is a cycle.
+func (g *DependencyGraph) TopoOrder() ([]string, error) {
+	sccs := g.stronglyConnectedComponents()
+
+	for _, scc := range sccs {
+		if len(scc) > 1 || g.hasSelfEdge(scc[0]) {
+			return nil, &CycleError{Cells: scc}
+		}
+	}
+
+	// Tarjan yields SCCs in reverse topological order, so a single-node SCC
+	// per node, in that order, is already a valid recalculation order.
+	order := make([]string, 0, len(sccs))
+	for i := len(sccs) - 1; i >= 0; i-- {
+		order = append(order, sccs[i]...)
+	}
+	return order, nil
+}
+
+func (g *DependencyGraph) hasSelfEdge(node string) bool {
+	for _, dep := range g.edges[node] {
+		if dep == node {
+			return true
+		}
+	}
+	return false
+}
+
+// tarjanState carries the bookkeeping Tarjan's algorithm needs across its
+// recursive calls without threading five extra parameters through.
+type tarjanState struct {
+	graph   *DependencyGraph
+	index   map[string]int
+	lowlink map[string]int
+	onStack map[string]bool
+	stack   []string
+	counter int
+	sccs    [][]string
+}
+
+// stronglyConnectedComponents runs Tarjan's algorithm over every node in
+// the graph, returning its SCCs in reverse topological order.
+func (g *DependencyGraph) stronglyConnectedComponents() [][]string {
+	st := &tarjanState{
+		graph:   g,
+		index:   make(map[string]int),
+		lowlink: make(map[string]int),
+		onStack: make(map[string]bool),
+	}
+
+	for node := range g.edges {
+		if _, visited := st.index[node]; !visited {
+			st.strongconnect(node)
+		}
+	}
+	return st.sccs
+}
+
+func (st *tarjanState) strongconnect(v string) {
+	st.index[v] = st.counter
+	st.lowlink[v] = st.counter
+	st.counter++
+	st.stack = append(st.stack, v)
+	st.onStack[v] = true
+
+	for _, w := range st.graph.edges[v] {
+		if _, visited := st.index[w]; !visited {
+			st.strongconnect(w)
+			st.lowlink[v] = min(st.lowlink[v], st.lowlink[w])
+		} else if st.onStack[w] {
+			st.lowlink[v] = min(st.lowlink[v], st.index[w])
+		}
+	}
+
+	if st.lowlink[v] != st.index[v] {
+		return
+	}
+
+	var scc []string
+	for {
+		n := len(st.stack) - 1
+		w := st.stack[n]
+		st.stack = st.stack[:n]
+		st.onStack[w] = false
+		scc = append(scc, w)
+		if w == v {
+			break
+		}
+	}
+	st.sccs = append(st.sccs, scc)
+}
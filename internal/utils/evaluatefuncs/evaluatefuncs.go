@@ -1,27 +1,24 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// evaluatefuncs.go is the package's entry point: GovalFuncs returns the
+// full same-sheet function set, and NewEvaluator (evaluator.go) layers
+// cross-sheet references and named ranges on top of it.
+
 package evaluatefuncs
 
-import(
-	"maps"
+import (
+	"gosheet/internal/utils"
+
 	"github.com/Knetic/govaluate"
 )
 
+// GovalFuncs returns the standard, same-sheet function set. It is a thin
+// alias over utils.GovalFuncs so callers that only ever touch one sheet
+// don't need a WorkbookResolver; anything that needs Sheet2!A1-style
+// references or named ranges should use NewEvaluator instead.
 func GovalFuncs() map[string]govaluate.ExpressionFunction {
-	functions := make(map[string]govaluate.ExpressionFunction)
-
-	// Merge all function categories
-	mergeFunctions(functions, MathFunctions())
-	mergeFunctions(functions, StatisticalFunctions())
-	mergeFunctions(functions, StringFunctions())
-	mergeFunctions(functions, DateTimeFunctions())
-	mergeFunctions(functions, LogicalFunctions())
-
-	return functions
-}
-
-// mergeFunctions merges source functions into target map
-func mergeFunctions(target, source map[string]govaluate.ExpressionFunction) {
-	if target == nil || source == nil {
-		return
-	}
-	maps.Copy(target, source)
+	return utils.GovalFuncs()
 }
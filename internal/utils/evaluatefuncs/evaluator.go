@@ -0,0 +1,201 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// evaluator.go adds cross-sheet references (Sheet2!A1, 'Sales 2024'!B2:B10)
+// and named ranges (SUM(Revenue)) on top of GovalFuncs. Like ExpandRangeRefs
+// rewrites a same-sheet A1:B3 into RANGE("A1:B3"), ExpandCrossSheetRefs
+// rewrites a sheet-qualified reference into a CELLREF/RANGEREF call before
+// govaluate ever sees it; those calls are resolved against a
+// WorkbookResolver supplied by the caller.
+
+package evaluatefuncs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gosheet/internal/utils"
+
+	"github.com/Knetic/govaluate"
+)
+
+// Evaluator evaluates spreadsheet expressions with access to other sheets
+// and named ranges, on top of the same function set every same-sheet
+// formula uses.
+type Evaluator struct {
+	resolver  WorkbookResolver
+	functions map[string]govaluate.ExpressionFunction
+}
+
+// NewEvaluator returns an Evaluator whose INDIRECT, VLOOKUP, cross-sheet
+// references, and named ranges are resolved through resolver.
+func NewEvaluator(resolver WorkbookResolver) *Evaluator {
+	return &Evaluator{
+		resolver:  resolver,
+		functions: functionsWithResolver(resolver),
+	}
+}
+
+// Evaluate parses and runs expr, first expanding cross-sheet and named-range
+// references into the function calls functionsWithResolver understands.
+func (e *Evaluator) Evaluate(expr string) (any, error) {
+	expr = utils.ExpandRangeRefs(ExpandCrossSheetRefs(expr))
+
+	parsed, err := govaluate.NewEvaluableExpressionWithFunctions(expr, e.functions)
+	if err != nil {
+		return utils.ErrName, nil
+	}
+
+	result, err := parsed.Evaluate(nil)
+	if err != nil {
+		return utils.ErrValue, nil
+	}
+	return result, nil
+}
+
+// crossSheetRefPattern matches a sheet-qualified cell or range reference:
+// Sheet2!A1, Sheet2!A1:B3, or 'Sales 2024'!B2:B10 when the sheet name has
+// spaces or other characters that would otherwise break the parser.
+var crossSheetRefPattern = regexp.MustCompile(`(?:'([^']+)'|([A-Za-z_][A-Za-z0-9_]*))!([A-Z]+\d+)(?::([A-Z]+\d+))?`)
+
+// ExpandCrossSheetRefs rewrites every sheet-qualified reference in expr into
+// a CELLREF(sheet, ref) or RANGEREF(sheet, from, to) call, mirroring how
+// ExpandRangeRefs turns a same-sheet range into RANGE(...).
+func ExpandCrossSheetRefs(expr string) string {
+	return crossSheetRefPattern.ReplaceAllStringFunc(expr, func(match string) string {
+		groups := crossSheetRefPattern.FindStringSubmatch(match)
+		sheet := groups[1]
+		if sheet == "" {
+			sheet = groups[2]
+		}
+		from, to := groups[3], groups[4]
+
+		if to == "" {
+			return fmt.Sprintf(`CELLREF("%s","%s")`, sheet, from)
+		}
+		return fmt.Sprintf(`RANGEREF("%s","%s","%s")`, sheet, from, to)
+	})
+}
+
+// functionsWithResolver returns the standard function set (with same-sheet
+// ranges already wired up) plus CELLREF/RANGEREF (used internally by
+// ExpandCrossSheetRefs), INDIRECT, and a resolver-aware VLOOKUP that can
+// look a value up on another sheet or in a named range.
+func functionsWithResolver(resolver WorkbookResolver) map[string]govaluate.ExpressionFunction {
+	sameSheetRange := func(ref string) (utils.CellRange, error) {
+		return utils.CellRange{}, fmt.Errorf("same-sheet range %q requires a same-sheet resolver", ref)
+	}
+	functions := utils.GovalFuncsWithRanges(sameSheetRange)
+
+	functions["CELLREF"] = func(args ...any) (any, error) {
+		if err := checkArgs("CELLREF", args, 2); err != nil {
+			return nil, err
+		}
+		sheet, ref := fmt.Sprint(args[0]), fmt.Sprint(args[1])
+		row, col, err := parseCellRef(ref)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		value, err := resolver.ResolveCell(sheet, row, col)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		return value, nil
+	}
+
+	functions["RANGEREF"] = func(args ...any) (any, error) {
+		if err := checkArgs("RANGEREF", args, 3); err != nil {
+			return nil, err
+		}
+		sheet, from, to := fmt.Sprint(args[0]), fmt.Sprint(args[1]), fmt.Sprint(args[2])
+		r1, c1, err := parseCellRef(from)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		r2, c2, err := parseCellRef(to)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		rng, err := resolver.ResolveRange(sheet, r1, c1, r2, c2)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		return rng, nil
+	}
+
+	functions["INDIRECT"] = func(args ...any) (any, error) {
+		if err := checkArgs("INDIRECT", args, 1); err != nil {
+			return nil, err
+		}
+		ref, ok := args[0].(string)
+		if !ok {
+			return utils.ErrValue, nil
+		}
+		expanded := utils.ExpandRangeRefs(ExpandCrossSheetRefs(ref))
+		parsed, err := govaluate.NewEvaluableExpressionWithFunctions(expanded, functions)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		result, err := parsed.Evaluate(nil)
+		if err != nil {
+			return utils.ErrRef, nil
+		}
+		return result, nil
+	}
+
+	// Named ranges: SUM(Revenue) and friends resolve the bare identifier
+	// through the resolver before falling back to the govaluate variable
+	// lookup, which would otherwise fail with "unknown variable".
+	functions["NAME"] = func(args ...any) (any, error) {
+		if err := checkArgs("NAME", args, 1); err != nil {
+			return nil, err
+		}
+		name, ok := args[0].(string)
+		if !ok {
+			return utils.ErrValue, nil
+		}
+		value, err := resolver.ResolveName(name)
+		if err != nil {
+			return utils.ErrName, nil
+		}
+		return value, nil
+	}
+
+	return functions
+}
+
+// checkArgs is the evaluatefuncs equivalent of the utils package's
+// validateArgs, kept local so this package doesn't need an exported
+// variant just for its own fixed-arity helpers.
+func checkArgs(name string, args []any, want int) error {
+	if len(args) != want {
+		return fmt.Errorf("%s expects %d argument(s), got %d", name, want, len(args))
+	}
+	return nil
+}
+
+var cellRefPattern = regexp.MustCompile(`^([A-Z]+)(\d+)$`)
+
+// parseCellRef parses a cell reference like "B12" into its 1-indexed row
+// and column, the same addressing the rest of the app uses.
+func parseCellRef(ref string) (row, col int, err error) {
+	m := cellRefPattern.FindStringSubmatch(strings.ToUpper(ref))
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+
+	col = 0
+	for _, ch := range m[1] {
+		col = col*26 + int(ch-'A') + 1
+	}
+
+	row = 0
+	for _, ch := range m[2] {
+		row = row*10 + int(ch-'0')
+	}
+
+	return row, col, nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// resolver.go defines WorkbookResolver, the seam between the formula
+// evaluator and whatever holds the actual workbook data (fileop's
+// sheetsResolver in recalc.go, over a loaded workbook's sheets), so this
+// package can stay ignorant of sheets, tabs, and cells.
+
+package evaluatefuncs
+
+import "gosheet/internal/utils"
+
+// WorkbookResolver is implemented by fileop's sheetsResolver and is the
+// only way the evaluator reaches outside the expression it is evaluating:
+// to another sheet's cell, a range on some sheet, or a named range.
+type WorkbookResolver interface {
+	// ResolveCell returns the value of a single cell on sheet, 1-indexed
+	// like the rest of the app (row 1, col 1 is A1).
+	ResolveCell(sheet string, row, col int) (any, error)
+
+	// ResolveRange returns the rectangular range [r1,c1]..[r2,c2] on sheet
+	// as a CellRange, row-major, so range-aware functions (SUM, VLOOKUP,
+	// ...) can consume it the same way they consume a same-sheet range.
+	ResolveRange(sheet string, r1, c1, r2, c2 int) (utils.CellRange, error)
+
+	// ResolveName returns the value bound to a user-defined name: the
+	// resolved cell if the name is a single cell, or a CellRange if it
+	// spans more than one.
+	ResolveName(name string) (any, error)
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// financialfuncs.go provides the standard Excel-style financial functions
+// (PMT, PV, FV, NPV, IRR, RATE, NPER, IPMT, PPMT), merged into GovalFuncs.
+
+package utils
+
+import (
+	"math"
+
+	"github.com/Knetic/govaluate"
+)
+
+// financialFunctions returns the financial function pack. It is merged
+// into GovalFuncs() rather than exported directly so callers keep using
+// the single GovalFuncs entry point for the whole formula engine.
+//
+// These functions still compute in float64 even when Decimal numeric mode
+// (see decimal.go) is active; the Newton-Raphson solvers in RATE and IRR
+// need float64's cheap iteration, so PMT-family precision follows the
+// documented transcendental-function precision cap rather than Number.
+func financialFunctions() map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		// PMT computes the periodic payment for a loan/annuity.
+		"PMT": func(args ...any) (any, error) {
+			if err := validateArgs("PMT", args, 3, 5); err != nil {
+				return nil, err
+			}
+			rate, pv, nper, fv, dueAtStart, err := loanArgs(args, 3)
+			if err != nil {
+				return ErrValue, nil
+			}
+			if rate == 0 {
+				return -(pv + fv) / nper, nil
+			}
+			factor := math.Pow(1+rate, nper)
+			pmt := -(pv*factor + fv) * rate / (factor - 1)
+			if dueAtStart {
+				pmt /= 1 + rate
+			}
+			return pmt, nil
+		},
+
+		// PV computes the present value of a series of future payments.
+		"PV": func(args ...any) (any, error) {
+			if err := validateArgs("PV", args, 3, 5); err != nil {
+				return nil, err
+			}
+			rate, pmt, nper, fv, dueAtStart, err := loanArgs(args, 3)
+			if err != nil {
+				return ErrValue, nil
+			}
+			if rate == 0 {
+				return -(fv + pmt*nper), nil
+			}
+			factor := math.Pow(1+rate, nper)
+			due := 1.0
+			if dueAtStart {
+				due = 1 + rate
+			}
+			return -(fv + pmt*due*(factor-1)/rate) / factor, nil
+		},
+
+		// FV computes the future value of a series of payments.
+		"FV": func(args ...any) (any, error) {
+			if err := validateArgs("FV", args, 3, 5); err != nil {
+				return nil, err
+			}
+			rate, pmt, nper, pv, dueAtStart, err := loanArgs(args, 3)
+			if err != nil {
+				return ErrValue, nil
+			}
+			if rate == 0 {
+				return -(pv + pmt*nper), nil
+			}
+			factor := math.Pow(1+rate, nper)
+			due := 1.0
+			if dueAtStart {
+				due = 1 + rate
+			}
+			return -(pv*factor + pmt*due*(factor-1)/rate), nil
+		},
+
+		// NPER computes the number of periods for a loan/annuity.
+		"NPER": func(args ...any) (any, error) {
+			if err := validateArgs("NPER", args, 3, 5); err != nil {
+				return nil, err
+			}
+			rate, pmt, pv, fv, dueAtStart, err := loanArgs(args, 3)
+			if err != nil {
+				return ErrValue, nil
+			}
+			if rate == 0 {
+				return -(pv + fv) / pmt, nil
+			}
+			due := 1.0
+			if dueAtStart {
+				due = 1 + rate
+			}
+			numerator := pmt*due - fv*rate
+			denominator := pv*rate + pmt*due
+			if denominator == 0 || numerator/denominator <= 0 {
+				return ErrNum, nil
+			}
+			return math.Log(numerator/denominator) / math.Log(1+rate), nil
+		},
+
+		// IPMT computes the interest portion of a payment at a given period.
+		"IPMT": func(args ...any) (any, error) {
+			if err := validateArgs("IPMT", args, 4, 6); err != nil {
+				return nil, err
+			}
+			rate, err := toFloat(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			period, err := toFloat(args[1])
+			if err != nil {
+				return ErrValue, nil
+			}
+			rest, err := toFloats(args[2:])
+			if err != nil {
+				return ErrValue, nil
+			}
+			nper, pv := rest[0], rest[1]
+			fv := 0.0
+			if len(rest) > 2 {
+				fv = rest[2]
+			}
+
+			pmt, err := pmtOf(rate, pv, nper, fv)
+			if err != nil {
+				return ErrNum, nil
+			}
+			balance := balanceBeforePeriod(rate, pv, pmt, period)
+			return -balance * rate, nil
+		},
+
+		// PPMT computes the principal portion of a payment at a given period.
+		"PPMT": func(args ...any) (any, error) {
+			if err := validateArgs("PPMT", args, 4, 6); err != nil {
+				return nil, err
+			}
+			rate, err := toFloat(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			period, err := toFloat(args[1])
+			if err != nil {
+				return ErrValue, nil
+			}
+			rest, err := toFloats(args[2:])
+			if err != nil {
+				return ErrValue, nil
+			}
+			nper, pv := rest[0], rest[1]
+			fv := 0.0
+			if len(rest) > 2 {
+				fv = rest[2]
+			}
+
+			pmt, err := pmtOf(rate, pv, nper, fv)
+			if err != nil {
+				return ErrNum, nil
+			}
+			balance := balanceBeforePeriod(rate, pv, pmt, period)
+			return pmt + balance*rate, nil
+		},
+
+		// RATE solves for the periodic interest rate by Newton-Raphson
+		// iteration, since it has no closed form.
+		"RATE": func(args ...any) (any, error) {
+			if err := validateArgs("RATE", args, 3, 5); err != nil {
+				return nil, err
+			}
+			nper, pmt, pv, fv, _, err := loanArgs(args, 3)
+			if err != nil {
+				return ErrValue, nil
+			}
+
+			guess := 0.1
+			for i := 0; i < 50; i++ {
+				pmtAt, dErr := pmtOf(guess, pv, nper, fv)
+				if dErr != nil {
+					break
+				}
+				diff := pmtAt - pmt
+				if math.Abs(diff) < 1e-8 {
+					return guess, nil
+				}
+				delta := 1e-6
+				pmtAtDelta, dErr2 := pmtOf(guess+delta, pv, nper, fv)
+				if dErr2 != nil {
+					break
+				}
+				derivative := (pmtAtDelta - pmtAt) / delta
+				if derivative == 0 {
+					break
+				}
+				guess -= diff / derivative
+			}
+			return ErrNum, nil
+		},
+
+		// NPV computes the net present value of a series of cash flows
+		// occurring at the end of each period, discounted at rate.
+		"NPV": func(args ...any) (any, error) {
+			if err := validateArgs("NPV", args, 2, -1); err != nil {
+				return nil, err
+			}
+			rate, err := toFloat(args[0])
+			if err != nil {
+				return ErrValue, nil
+			}
+			flows, err := toFloats(flattenRangeArgs(args[1:]))
+			if err != nil {
+				return ErrValue, nil
+			}
+
+			npv := 0.0
+			for i, cf := range flows {
+				npv += cf / math.Pow(1+rate, float64(i+1))
+			}
+			return npv, nil
+		},
+
+		// IRR solves for the discount rate that makes NPV(rate, flows) == 0,
+		// by Newton-Raphson iteration on the NPV function.
+		"IRR": func(args ...any) (any, error) {
+			if err := validateArgs("IRR", args, 1, 2); err != nil {
+				return nil, err
+			}
+			flows, err := toFloats(flattenRangeArgs(args[:1]))
+			if err != nil {
+				return ErrValue, nil
+			}
+			guess := 0.1
+			if len(args) == 2 {
+				g, err := toFloat(args[1])
+				if err == nil {
+					guess = g
+				}
+			}
+
+			npvAt := func(rate float64) float64 {
+				sum := 0.0
+				for i, cf := range flows {
+					sum += cf / math.Pow(1+rate, float64(i))
+				}
+				return sum
+			}
+
+			rate := guess
+			for i := 0; i < 100; i++ {
+				value := npvAt(rate)
+				delta := 1e-6
+				derivative := (npvAt(rate+delta) - value) / delta
+				if derivative == 0 {
+					break
+				}
+				next := rate - value/derivative
+				if math.Abs(next-rate) < 1e-9 {
+					return next, nil
+				}
+				rate = next
+			}
+			return ErrNum, nil
+		},
+	}
+}
+
+// loanArgs normalizes the shared (rate, arg1, nper, optionalArg, [type])
+// argument shape used by PMT/PV/FV/NPER: three required numeric arguments
+// plus an optional trailing value (default 0) and an optional "type" flag
+// (0 = payments at period end, 1 = at period start).
+func loanArgs(args []any, requiredCount int) (a, b, c, optional float64, dueAtStart bool, err error) {
+	values, err := toFloats(args[:requiredCount])
+	if err != nil {
+		return 0, 0, 0, 0, false, ErrValue
+	}
+	a, b, c = values[0], values[1], values[2]
+
+	if len(args) >= 4 {
+		if optional, err = toFloat(args[3]); err != nil {
+			return 0, 0, 0, 0, false, ErrValue
+		}
+	}
+	if len(args) == 5 {
+		typeFlag, err2 := toFloat(args[4])
+		if err2 != nil {
+			return 0, 0, 0, 0, false, ErrValue
+		}
+		dueAtStart = typeFlag != 0
+	}
+	return a, b, c, optional, dueAtStart, nil
+}
+
+// balanceBeforePeriod amortizes a loan starting at pv, with fixed periodic
+// payment pmt, and returns the remaining balance just before the given
+// (1-indexed) period's payment is applied.
+func balanceBeforePeriod(rate, pv, pmt, period float64) float64 {
+	balance := pv
+	for p := 1.0; p < period; p++ {
+		balance = balance*(1+rate) + pmt
+	}
+	return balance
+}
+
+// pmtOf computes PMT(rate, pv, nper, fv) with payments at period end, shared
+// by RATE, IPMT and PPMT which all need the payment amount for a schedule.
+func pmtOf(rate, pv, nper, fv float64) (float64, error) {
+	if nper == 0 {
+		return 0, ErrDivZero
+	}
+	if rate == 0 {
+		return -(pv + fv) / nper, nil
+	}
+	factor := math.Pow(1+rate, nper)
+	return -(pv*factor + fv) * rate / (factor - 1), nil
+}
+
+// toFloats converts every element of args to float64, failing on the first
+// one that can't be converted.
+func toFloats(args []any) ([]float64, error) {
+	out := make([]float64, len(args))
+	for i, arg := range args {
+		f, err := toFloat(arg)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = f
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// formulaerror.go provides Excel-style typed formula errors that propagate
+// through expressions instead of aborting evaluation with a plain Go error.
+
+package utils
+
+// FormulaError is a first-class formula error token, matching the standard
+// Excel/excelize error values. Functions in GovalFuncs return one of these
+// (as the result, not the error) so that passing an errored cell into e.g.
+// SUM yields the same error instead of aborting the whole evaluation.
+type FormulaError string
+
+const (
+	ErrValue       FormulaError = "#VALUE!"
+	ErrDivZero     FormulaError = "#DIV/0!"
+	ErrName        FormulaError = "#NAME?"
+	ErrRef         FormulaError = "#REF!"
+	ErrNum         FormulaError = "#NUM!"
+	ErrNA          FormulaError = "#N/A"
+	ErrNull        FormulaError = "#NULL!"
+	ErrGettingData FormulaError = "#GETTING_DATA"
+	// ErrTZ is not a standard Excel error; it surfaces an unknown/invalid
+	// IANA timezone name passed to a timezone-aware date/time function
+	// (NOW, TODAY, TZCONVERT, WEEKDAY, DATEDIFF, DATEADD) instead of
+	// letting time.LoadLocation's Go error abort the whole evaluation.
+	ErrTZ FormulaError = "#TZ!"
+)
+
+// Error implements the error interface so a FormulaError can still be
+// returned/wrapped anywhere plain errors are expected.
+func (e FormulaError) Error() string {
+	return string(e)
+}
+
+// formatError maps every FormulaError to its display token. It exists
+// alongside the FormulaError constants (rather than just using the
+// underlying string) so toString has one place to render error cells and
+// downstream renderers can extend the mapping without touching FormulaError
+// itself.
+var formatError = map[FormulaError]string{
+	ErrValue:       "#VALUE!",
+	ErrDivZero:     "#DIV/0!",
+	ErrName:        "#NAME?",
+	ErrRef:         "#REF!",
+	ErrNum:         "#NUM!",
+	ErrNA:          "#N/A",
+	ErrNull:        "#NULL!",
+	ErrGettingData: "#GETTING_DATA",
+	ErrTZ:          "#TZ!",
+}
+
+// IsFormulaError reports whether v is a FormulaError, so downstream
+// renderers can style error cells (e.g. red text) without a type switch.
+func IsFormulaError(v any) (FormulaError, bool) {
+	fe, ok := v.(FormulaError)
+	return fe, ok
+}
+
+// firstFormulaError scans args for a FormulaError and returns it, so any
+// aggregate function can short-circuit on a propagated error the same way
+// Excel does: SUM(A1, #DIV/0!) yields #DIV/0!.
+func firstFormulaError(args ...any) (FormulaError, bool) {
+	for _, arg := range args {
+		if fe, ok := IsFormulaError(arg); ok {
+			return fe, true
+		}
+	}
+	return "", false
+}
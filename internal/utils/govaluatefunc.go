@@ -10,6 +10,7 @@ package utils
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 	"time"
 
@@ -21,6 +22,11 @@ import (
 // Helper function to convert any type to string
 func toString(v any) string {
 	switch val := v.(type) {
+	case FormulaError:
+		if s, ok := formatError[val]; ok {
+			return s
+		}
+		return string(val)
 	case string:
 		return val
 	case float64:
@@ -35,6 +41,10 @@ func toString(v any) string {
 			return "TRUE"
 		}
 		return "FALSE"
+	case *big.Int:
+		return val.String()
+	case *big.Float:
+		return val.Text('f', -1)
 	default:
 		return fmt.Sprintf("%v", val)
 	}
@@ -43,16 +53,27 @@ func toString(v any) string {
 // Helper function to convert any type to float64
 func toFloat(v any) (float64, error) {
 	switch val := v.(type) {
+	case FormulaError:
+		return 0, val
 	case float64:
 		return val, nil
 	case int:
 		return float64(val), nil
 	case string:
 		var f float64
-		_, err := fmt.Sscanf(val, "%f", &f)
-		return f, err
+		if _, err := fmt.Sscanf(val, "%f", &f); err != nil {
+			return 0, ErrValue
+		}
+		return f, nil
+	case *big.Int:
+		f := new(big.Float).SetInt(val)
+		result, _ := f.Float64()
+		return result, nil
+	case *big.Float:
+		result, _ := val.Float64()
+		return result, nil
 	default:
-		return 0, fmt.Errorf("cannot convert %T to float64", v)
+		return 0, ErrValue
 	}
 }
 
@@ -69,10 +90,28 @@ func validateArgs(funcName string, args []any, minArgs, maxArgs int) error {
 	return nil
 }
 
-func GovalFuncs() map[string]govaluate.ExpressionFunction {
+// GovalFuncsOption customizes the map GovalFuncs returns. It's applied
+// last, after every builtin and user-defined function has been merged in,
+// so an option can see (and override) either.
+type GovalFuncsOption func(map[string]govaluate.ExpressionFunction)
+
+// WithOverrides merges overrides into GovalFuncs' result, replacing any
+// builtin or user-defined function of the same name. It's for a call site
+// that needs a one-off substitution (e.g. a sandboxed evaluation that must
+// not allow RAND/NOW) without registering it through RegisterUserFunction,
+// which would affect every other caller.
+func WithOverrides(overrides map[string]govaluate.ExpressionFunction) GovalFuncsOption {
+	return func(functions map[string]govaluate.ExpressionFunction) {
+		for name, fn := range overrides {
+			functions[name] = fn
+		}
+	}
+}
+
+func GovalFuncs(opts ...GovalFuncsOption) map[string]govaluate.ExpressionFunction {
 	functions := map[string]govaluate.ExpressionFunction{
-        // Trigonometric functions
-    	"SIN": func(args ...any) (any, error) {
+		// Trigonometric functions
+		"SIN": func(args ...any) (any, error) {
 			if err := validateArgs("SIN", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -111,17 +150,20 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
+				if fe, ok := err.(FormulaError); ok {
+					return fe, nil
+				}
 				return nil, fmt.Errorf("CTAN: %v", err)
 			}
 			tanVal := math.Tan(f)
 			if math.Abs(tanVal) < 1e-10 {
-				return math.Inf(1), fmt.Errorf("CTAN: division by zero")
+				return ErrDivZero, nil
 			}
 			return 1 / tanVal, nil
 		},
 
-        // Inverse trigonometric functions
-        "ASIN": func(args ...any) (any, error) { 
+		// Inverse trigonometric functions
+		"ASIN": func(args ...any) (any, error) {
 			if err := validateArgs("ASIN", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -131,7 +173,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			}
 			return math.Asin(f), nil
 		},
-        "ACOS": func(args ...any) (any, error) { 
+		"ACOS": func(args ...any) (any, error) {
 			if err := validateArgs("ACOS", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -141,7 +183,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			}
 			return math.Acos(f), nil
 		},
-        "ATAN": func(args ...any) (any, error) { 
+		"ATAN": func(args ...any) (any, error) {
 			if err := validateArgs("ATAN", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -151,7 +193,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			}
 			return math.Atan(f), nil
 		},
-        "ATAN2": func(args ...any) (any, error) { 
+		"ATAN2": func(args ...any) (any, error) {
 			if err := validateArgs("ATAN2", args, 2, 2); err != nil {
 				return nil, err
 			}
@@ -162,35 +204,38 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			f2, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("ATAN2: %v", err)
-			}	
-			return math.Atan2(f1, f2), nil 
+			}
+			return math.Atan2(f1, f2), nil
 		},
-		"ACTAN": func(args ...any) (any, error) { 
+		"ACTAN": func(args ...any) (any, error) {
 			if err := validateArgs("ACTAN", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ACTAN: %v", err)
-			}	
-			return math.Pi/2 - math.Atan(f), nil 
+			}
+			return math.Pi/2 - math.Atan(f), nil
 		},
 
 		// Additional trigonometric functions
-		"SEC": func(args ...any) (any, error) { 
+		"SEC": func(args ...any) (any, error) {
 			if err := validateArgs("SEC", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
+				if fe, ok := err.(FormulaError); ok {
+					return fe, nil
+				}
 				return nil, fmt.Errorf("SEC: %v", err)
 			}
 			if math.Cos(f) == 0 {
-				return math.Inf(0), fmt.Errorf("division by zero")
+				return ErrDivZero, nil
 			}
-			return 1 / math.Cos(f), nil 
+			return 1 / math.Cos(f), nil
 		},
-		"CSEC": func(args ...any) (any, error) { 
+		"CSEC": func(args ...any) (any, error) {
 			if err := validateArgs("CSEC", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -201,9 +246,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if math.Sin(f) == 0 {
 				return math.Inf(0), fmt.Errorf("division by zero")
 			}
-			return 1 / math.Sin(f), nil 
+			return 1 / math.Sin(f), nil
 		},
-		"ASEC": func(args ...any) (any, error) { 
+		"ASEC": func(args ...any) (any, error) {
 			if err := validateArgs("ASEC", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -211,9 +256,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("ASEC: %v", err)
 			}
-			return math.Acos(1 / f), nil 
+			return math.Acos(1 / f), nil
 		},
-		"ACSC": func(args ...any) (any, error) { 
+		"ACSC": func(args ...any) (any, error) {
 			if err := validateArgs("ACSC", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -221,11 +266,11 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("ACSC: %v", err)
 			}
-			return math.Asin(1 / f), nil 
+			return math.Asin(1 / f), nil
 		},
 
 		// Degrees/radians conversion
-		"RAD": func(args ...any) (any, error) { 
+		"RAD": func(args ...any) (any, error) {
 			if err := validateArgs("RAD", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -233,9 +278,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("RAD: %v", err)
 			}
-			return f * math.Pi / 180, nil 
+			return f * math.Pi / 180, nil
 		},
-		"DEG": func(args ...any) (any, error) { 
+		"DEG": func(args ...any) (any, error) {
 			if err := validateArgs("DEG", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -243,11 +288,11 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("DEG: %v", err)
 			}
-			return f * 180 / math.Pi, nil 
+			return f * 180 / math.Pi, nil
 		},
 
-        // Hyperbolic functions
-        "SINH": func(args ...any) (any, error) { 
+		// Hyperbolic functions
+		"SINH": func(args ...any) (any, error) {
 			if err := validateArgs("SINH", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -255,9 +300,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("SINH: %v", err)
 			}
-			return math.Sinh(f), nil 
+			return math.Sinh(f), nil
 		},
-        "COSH": func(args ...any) (any, error) { 
+		"COSH": func(args ...any) (any, error) {
 			if err := validateArgs("COSH", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -265,9 +310,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("COSH: %v", err)
 			}
-			return math.Cosh(f), nil 
+			return math.Cosh(f), nil
 		},
-        "TANH": func(args ...any) (any, error) { 
+		"TANH": func(args ...any) (any, error) {
 			if err := validateArgs("TANH", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -275,9 +320,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("TANH: %v", err)
 			}
-			return math.Tanh(f), nil 
+			return math.Tanh(f), nil
 		},
-		"CTANH": func(args ...any) (any, error) { 
+		"CTANH": func(args ...any) (any, error) {
 			if err := validateArgs("CTANH", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -288,11 +333,11 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if math.Tanh(f) == 0 {
 				return math.Inf(0), fmt.Errorf("division by zero")
 			}
-			return 1/math.Tanh(f), nil 
+			return 1 / math.Tanh(f), nil
 		},
 
 		// Additional hyperbolic functions
-		"SECH": func(args ...any) (any, error) { 
+		"SECH": func(args ...any) (any, error) {
 			if err := validateArgs("SECH", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -303,9 +348,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if math.Cosh(f) == 0 {
 				return math.Inf(0), fmt.Errorf("division by zero")
 			}
-			return 1 / math.Cosh(f), nil 
+			return 1 / math.Cosh(f), nil
 		},
-		"CSCH": func(args ...any) (any, error) { 
+		"CSCH": func(args ...any) (any, error) {
 			if err := validateArgs("CSCH", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -316,91 +361,97 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if math.Sinh(f) == 0 {
 				return math.Inf(0), fmt.Errorf("division by zero")
 			}
-			return 1 / math.Sinh(f), nil 
+			return 1 / math.Sinh(f), nil
 		},
-		"ASINH": func(args ...any) (any, error) { 
-		    if err := validateArgs("ASINH", args, 1, 1); err != nil {
+		"ASINH": func(args ...any) (any, error) {
+			if err := validateArgs("ASINH", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ASINH: %v", err)
 			}
-		    return math.Log(f + math.Sqrt(f*f + 1)), nil 
+			return math.Log(f + math.Sqrt(f*f+1)), nil
 		},
-		"ACOSH": func(args ...any) (any, error) { 
-		    if err := validateArgs("ACOSH", args, 1, 1); err != nil {
+		"ACOSH": func(args ...any) (any, error) {
+			if err := validateArgs("ACOSH", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ACOSH: %v", err)
 			}
-		    return math.Log(f + math.Sqrt(f*f - 1)), nil 
+			return math.Log(f + math.Sqrt(f*f-1)), nil
 		},
-		"ATANH": func(args ...any) (any, error) { 
-		    if err := validateArgs("ATANH", args, 1, 1); err != nil {
+		"ATANH": func(args ...any) (any, error) {
+			if err := validateArgs("ATANH", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ATANH: %v", err)
 			}
-		    return 0.5 * math.Log((1+f)/(1-f)), nil 
+			return 0.5 * math.Log((1+f)/(1-f)), nil
 		},
-		"ASECH": func(args ...any) (any, error) { 
-		    if err := validateArgs("ASECH", args, 1, 1); err != nil {
+		"ASECH": func(args ...any) (any, error) {
+			if err := validateArgs("ASECH", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ASECH: %v", err)
 			}
-		    return math.Log((1 + math.Sqrt(1-f*f)) / f), nil 
+			return math.Log((1 + math.Sqrt(1-f*f)) / f), nil
 		},
-		"ACSCH": func(args ...any) (any, error) { 
-		    if err := validateArgs("ACSCH", args, 1, 1); err != nil {
+		"ACSCH": func(args ...any) (any, error) {
+			if err := validateArgs("ACSCH", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ACSCH: %v", err)
 			}
-		    return math.Log((1/f) + math.Sqrt(1+(1/(f*f)))), nil 
+			return math.Log((1 / f) + math.Sqrt(1+(1/(f*f)))), nil
 		},
-		"ACOTH": func(args ...any) (any, error) { 
-		    if err := validateArgs("ACOTH", args, 1, 1); err != nil {
+		"ACOTH": func(args ...any) (any, error) {
+			if err := validateArgs("ACOTH", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ACOTH: %v", err)
 			}
-		    return 0.5 * math.Log((f+1)/(f-1)), nil 
+			return 0.5 * math.Log((f+1)/(f-1)), nil
 		},
 
-        // Exponential and logarithmic
-        "EXP":  func(args ...any) (any, error) { 
+		// Exponential and logarithmic
+		"EXP": func(args ...any) (any, error) {
 			if err := validateArgs("EXP", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("EXP: %v", err)
-			}	
-			return math.Exp(f), nil 
+			}
+			return math.Exp(f), nil
 		},
-        "LOG":  func(args ...any) (any, error) { 
+		"LOG": func(args ...any) (any, error) {
 			if err := validateArgs("LOG", args, 1, 1); err != nil {
 				return nil, err
 			}
 			f, err := toFloat(args[0])
 			if err != nil {
-				return nil, fmt.Errorf("LOG: %v", err)
+				if fe, ok := err.(FormulaError); ok {
+					return fe, nil
+				}
+				return nil, err
+			}
+			if f <= 0 {
+				return ErrNum, nil
 			}
-			return math.Log(f), nil 
+			return math.Log(f), nil
 		},
-        "LOG10":func(args ...any) (any, error) { 
+		"LOG10": func(args ...any) (any, error) {
 			if err := validateArgs("LOG10", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -408,9 +459,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("LOG10: %v", err)
 			}
-			return math.Log10(f), nil 
+			return math.Log10(f), nil
 		},
-        "LOG2": func(args ...any) (any, error) { 
+		"LOG2": func(args ...any) (any, error) {
 			if err := validateArgs("LOG2", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -418,11 +469,11 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("LOG2: %v", err)
 			}
-			return math.Log2(f), nil 
+			return math.Log2(f), nil
 		},
 
-        // Power and roots
-        "SQRT": func(args ...any) (any, error) { 
+		// Power and roots
+		"SQRT": func(args ...any) (any, error) {
 			if err := validateArgs("SQRT", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -430,9 +481,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("SQRT: %v", err)
 			}
-			return math.Sqrt(f), nil 
+			return math.Sqrt(f), nil
 		},
-        "CBRT": func(args ...any) (any, error) { 
+		"CBRT": func(args ...any) (any, error) {
 			if err := validateArgs("CBRT", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -440,12 +491,39 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("CBRT: %v", err)
 			}
-			return math.Cbrt(f), nil 
+			return math.Cbrt(f), nil
 		},
-        "POW":  func(args ...any) (any, error) {
+		"POW": func(args ...any) (any, error) {
 			if err := validateArgs("POW", args, 2, 2); err != nil {
 				return nil, err
 			}
+			if GetNumericMode() == Decimal {
+				base, err := toNumber(args[0])
+				if err != nil {
+					return nil, fmt.Errorf("POW: %v", err)
+				}
+				exp, err := toFloat(args[1])
+				if err != nil {
+					return nil, fmt.Errorf("POW: %v", err)
+				}
+				result := numberFromFloat(1)
+				n := int(exp)
+				neg := n < 0
+				if neg {
+					n = -n
+				}
+				for i := 0; i < n; i++ {
+					result = result.Mul(base)
+				}
+				if neg {
+					quo, err := numberFromFloat(1).Quo(result)
+					if err != nil {
+						return ErrDivZero, nil
+					}
+					return quo.String(), nil
+				}
+				return result.String(), nil
+			}
 			f1, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("POW: %v", err)
@@ -454,21 +532,24 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("POW: %v", err)
 			}
-			return math.Pow(f1, f2), nil 
+			return math.Pow(f1, f2), nil
 		},
 
-        // Other math utilities
-        "ABS":   func(args ...any) (any, error) { 
+		// Other math utilities
+		"ABS": func(args ...any) (any, error) {
 			if err := validateArgs("ABS", args, 1, 1); err != nil {
 				return nil, err
 			}
-			f, err := toFloat(args[0])
+			n, err := toNumber(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ABS: %v", err)
 			}
-			return math.Abs(f), nil 
+			if GetNumericMode() == Decimal {
+				return n.Abs().String(), nil
+			}
+			return n.Abs().Float64(), nil
 		},
-        "CEIL":  func(args ...any) (any, error) { 
+		"CEIL": func(args ...any) (any, error) {
 			if err := validateArgs("CEIL", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -476,9 +557,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("CEIL: %v", err)
 			}
-			return math.Ceil(f), nil 
+			return math.Ceil(f), nil
 		},
-        "FLOOR": func(args ...any) (any, error) { 
+		"FLOOR": func(args ...any) (any, error) {
 			if err := validateArgs("FLOOR", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -486,28 +567,66 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("FLOOR: %v", err)
 			}
-			return math.Floor(f), nil 
+			return math.Floor(f), nil
 		},
-        "ROUND": func(args ...any) (any, error) { 
-			if err := validateArgs("ROUND", args, 1, 1); err != nil {
+		"ROUND": func(args ...any) (any, error) {
+			if err := validateArgs("ROUND", args, 1, 2); err != nil {
 				return nil, err
 			}
-			f, err := toFloat(args[0])
+			places := 0
+			if len(args) == 2 {
+				p, err := toFloat(args[1])
+				if err != nil {
+					return nil, fmt.Errorf("ROUND: %v", err)
+				}
+				places = int(p)
+			}
+			n, err := toNumber(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("ROUND: %v", err)
 			}
-			return math.Round(f), nil 
+			result := roundNumber(n, places, false)
+			if GetNumericMode() == Decimal {
+				return result.String(), nil
+			}
+			return result.Float64(), nil
 		},
-        "MIN": func(args ...any) (any, error) {
-			if err := validateArgs("MIN", args, 2, -1); err != nil {
+		// BANKERS_ROUND rounds half-to-even, matching how financial systems
+		// round to avoid the systematic upward bias of ROUND's half-away-
+		// from-zero rule.
+		"BANKERS_ROUND": func(args ...any) (any, error) {
+			if err := validateArgs("BANKERS_ROUND", args, 1, 2); err != nil {
 				return nil, err
 			}
-			f, err := toFloat(args[0])
+			places := 0
+			if len(args) == 2 {
+				p, err := toFloat(args[1])
+				if err != nil {
+					return nil, fmt.Errorf("BANKERS_ROUND: %v", err)
+				}
+				places = int(p)
+			}
+			n, err := toNumber(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("BANKERS_ROUND: %v", err)
+			}
+			result := roundNumber(n, places, true)
+			if GetNumericMode() == Decimal {
+				return result.String(), nil
+			}
+			return result.Float64(), nil
+		},
+		"MIN": func(args ...any) (any, error) {
+			if err := validateArgs("MIN", args, 1, -1); err != nil {
+				return nil, err
+			}
+			flat := flattenRangeArgs(args)
+			f, err := toFloat(flat[0])
 			if err != nil {
 				return nil, fmt.Errorf("MIN: %v", err)
 			}
 			minNR := f
-			for _, arg := range args {
+			for _, arg := range flat {
 				f, err = toFloat(arg)
 				if err != nil {
 					return nil, fmt.Errorf("MIN: %v", err)
@@ -516,16 +635,17 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			}
 			return minNR, nil
 		},
-        "MAX": func(args ...any) (any, error) { 
-			if err := validateArgs("MAX", args, 2, -1); err != nil {
+		"MAX": func(args ...any) (any, error) {
+			if err := validateArgs("MAX", args, 1, -1); err != nil {
 				return nil, err
 			}
-			f, err := toFloat(args[0])
+			flat := flattenRangeArgs(args)
+			f, err := toFloat(flat[0])
 			if err != nil {
 				return nil, fmt.Errorf("MAX: %v", err)
 			}
 			maxNR := f
-			for _, arg := range args {
+			for _, arg := range flat {
 				f, err = toFloat(arg)
 				if err != nil {
 					return nil, fmt.Errorf("MAX: %v", err)
@@ -535,15 +655,59 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			return maxNR, nil
 		},
 		"AVG": func(args ...any) (any, error) {
-			if err := validateArgs("AVG", args, 2, -1); err != nil {
+			if err := validateArgs("AVG", args, 1, -1); err != nil {
 				return nil, err
 			}
-    		sum := 0.0
-    		for _, arg := range args {
+			flat := flattenRangeArgs(args)
+			sum := 0.0
+			for _, arg := range flat {
 				f, _ := toFloat(arg)
-    		    sum += f
-    		}
-    		return sum / float64(len(args)), nil
+				sum += f
+			}
+			return sum / float64(len(flat)), nil
+		},
+		"AVERAGE": func(args ...any) (any, error) {
+			if err := validateArgs("AVERAGE", args, 1, -1); err != nil {
+				return nil, err
+			}
+			flat := flattenRangeArgs(args)
+			sum, n := 0.0, 0
+			for _, arg := range flat {
+				f, err := toFloat(arg)
+				if err != nil {
+					continue
+				}
+				sum += f
+				n++
+			}
+			if n == 0 {
+				return ErrDivZero, nil
+			}
+			return sum / float64(n), nil
+		},
+		"COUNTA": func(args ...any) (any, error) {
+			if err := validateArgs("COUNTA", args, 1, -1); err != nil {
+				return nil, err
+			}
+			count := 0
+			for _, arg := range flattenRangeArgs(args) {
+				if toString(arg) != "" {
+					count++
+				}
+			}
+			return float64(count), nil
+		},
+		"COUNTBLANK": func(args ...any) (any, error) {
+			if err := validateArgs("COUNTBLANK", args, 1, -1); err != nil {
+				return nil, err
+			}
+			count := 0
+			for _, arg := range flattenRangeArgs(args) {
+				if toString(arg) == "" {
+					count++
+				}
+			}
+			return float64(count), nil
 		},
 
 		// Utility functions
@@ -551,16 +715,16 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err := validateArgs("SIGN", args, 1, 1); err != nil {
 				return nil, err
 			}
-		    x, err := toFloat(args[0])
+			x, err := toFloat(args[0])
 			if err != nil {
 				return nil, fmt.Errorf("SIGN: %v", err)
 			}
-		    if x > 0 {
-		        return 1.0, nil
-		    } else if x < 0 {
-		        return -1.0, nil
-		    }
-		    return 0.0, nil
+			if x > 0 {
+				return 1.0, nil
+			} else if x < 0 {
+				return -1.0, nil
+			}
+			return 0.0, nil
 		},
 		"CLAMP": func(args ...any) (any, error) {
 			if err := validateArgs("CLAMP", args, 3, 3); err != nil {
@@ -578,16 +742,16 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("CLAMP: %v", err)
 			}
-		    if x < min {
-		        return min, nil
-		    }
-		    if x > max {
-		        return max, nil
-		    }
-		    return x, nil
+			if x < min {
+				return min, nil
+			}
+			if x > max {
+				return max, nil
+			}
+			return x, nil
 		},
 		"LERP": func(args ...any) (any, error) {
-		if err := validateArgs("LERP", args, 3, 3); err != nil {
+			if err := validateArgs("LERP", args, 3, 3); err != nil {
 				return nil, err
 			}
 			a, err := toFloat(args[0])
@@ -602,9 +766,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("LERP: %v", err)
 			}
-		    return a + t*(b-a), nil
+			return a + t*(b-a), nil
 		},
-	
+
 		// Logical functions
 		"IF": func(args ...any) (any, error) {
 			if err := validateArgs("IF", args, 3, 3); err != nil {
@@ -673,18 +837,18 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err := validateArgs("LEFT", args, 2, 2); err != nil {
 				return nil, err
 			}
-    		text := toString(args[0])
-    		length, err := toFloat(args[1])
+			text := toString(args[0])
+			length, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("LEFT: %v", err)
 			}
-		    n := int(length)
-		    runes := []rune(text)
-		    if n > len(runes) {
-		        return text, nil
-		    }
-		    return string(runes[:n]), nil
-		},	
+			n := int(length)
+			runes := []rune(text)
+			if n > len(runes) {
+				return text, nil
+			}
+			return string(runes[:n]), nil
+		},
 		"RIGHT": func(args ...any) (any, error) {
 			if err := validateArgs("RIGHT", args, 2, 2); err != nil {
 				return nil, err
@@ -696,10 +860,10 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			}
 			n := int(length)
 			runes := []rune(text)
-		    if n > len(runes) {
-		        return text, nil
-		    }
-		    return string(runes[len(runes)-n:]), nil
+			if n > len(runes) {
+				return text, nil
+			}
+			return string(runes[len(runes)-n:]), nil
 		},
 		"MID": func(args ...any) (any, error) {
 			if err := validateArgs("MID", args, 3, 3); err != nil {
@@ -723,58 +887,58 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if startIdx+n > len(runes) {
 				return string(runes[startIdx:]), nil
 			}
-			return string(runes[startIdx:startIdx+n]), nil
+			return string(runes[startIdx : startIdx+n]), nil
 		},
 		"UPPER": func(args ...any) (any, error) {
 			if err := validateArgs("UPPER", args, 1, 1); err != nil {
 				return nil, err
 			}
-			return strings.ToUpper(toString(args[0])), nil 
+			return strings.ToUpper(toString(args[0])), nil
 		},
 		"LOWER": func(args ...any) (any, error) {
 			if err := validateArgs("LOWER", args, 1, 1); err != nil {
 				return nil, err
 			}
-			return strings.ToLower(toString(args[0])), nil 
+			return strings.ToLower(toString(args[0])), nil
 		},
 		"PROPER": func(args ...any) (any, error) {
 			if err := validateArgs("PROPER", args, 1, 1); err != nil {
 				return nil, err
 			}
-		    caser := cases.Title(language.English)
-		    return caser.String(strings.ToLower(toString(args[0]))), nil 
-		},	
+			caser := cases.Title(language.English)
+			return caser.String(strings.ToLower(toString(args[0]))), nil
+		},
 		"TRIM": func(args ...any) (any, error) {
 			if err := validateArgs("TRIM", args, 1, 1); err != nil {
 				return nil, err
 			}
-			return strings.TrimSpace(toString(args[0])), nil 
+			return strings.TrimSpace(toString(args[0])), nil
 		},
 		"FIND": func(args ...any) (any, error) {
 			if err := validateArgs("FIND", args, 2, 3); err != nil {
 				return nil, err
 			}
-    		findText := toString(args[0])
-    		withinText := toString(args[1])
-    		startPos := 1
-    		if len(args) > 2 {
-    		    sp, err := toFloat(args[2])
+			findText := toString(args[0])
+			withinText := toString(args[1])
+			startPos := 1
+			if len(args) > 2 {
+				sp, err := toFloat(args[2])
 				if err != nil {
 					return nil, fmt.Errorf("FIND: %v", err)
 				}
-    		    startPos = int(sp)
-    		}
-    		if startPos < 1 {
-    		    return nil, fmt.Errorf("start position must be >= 1")
-    		}
-    		if startPos > len(withinText) {
-    		    return -1.0, nil
-    		}
-    		pos := strings.Index(withinText[startPos-1:], findText)
-    		if pos == -1 {
-    		    return -1.0, nil
-    		}
-    		return float64(pos + startPos), nil
+				startPos = int(sp)
+			}
+			if startPos < 1 {
+				return nil, fmt.Errorf("start position must be >= 1")
+			}
+			if startPos > len(withinText) {
+				return -1.0, nil
+			}
+			pos := strings.Index(withinText[startPos-1:], findText)
+			if pos == -1 {
+				return -1.0, nil
+			}
+			return float64(pos + startPos), nil
 		},
 		"SUBSTITUTE": func(args ...any) (any, error) {
 			if err := validateArgs("SUBSTITUTE", args, 3, 4); err != nil {
@@ -791,11 +955,11 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 				}
 				instanceNum = int(in)
 			}
-			
+
 			if instanceNum == -1 {
 				return strings.ReplaceAll(text, oldText, newText), nil
 			}
-			
+
 			parts := strings.Split(text, oldText)
 			if instanceNum >= len(parts) {
 				return text, nil
@@ -807,25 +971,68 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err := validateArgs("LEN", args, 1, 1); err != nil {
 				return nil, err
 			}
-			return float64(len(toString(args[0]))), nil 
+			return float64(len(toString(args[0]))), nil
 		},
 		"CONCAT": func(args ...any) (any, error) {
 			if err := validateArgs("CONCAT", args, 1, -1); err != nil {
 				return nil, err
 			}
-		    result := ""
-		    for _, arg := range args {
-		        result += toString(arg)
-		    }
-		    return result, nil
+			result := ""
+			for _, arg := range args {
+				result += toString(arg)
+			}
+			return result, nil
 		},
 
 		// Date/Time functions
-		"NOW": func(args ...any) (any, error) { 
-			return time.Now().Format("2006-01-02 15:04:05"), nil 
+		"NOW": func(args ...any) (any, error) {
+			if err := validateArgs("NOW", args, 0, 1); err != nil {
+				return nil, err
+			}
+			loc := time.UTC
+			if len(args) == 1 {
+				var err error
+				loc, err = loadLocation(toString(args[0]))
+				if err != nil {
+					return ErrTZ, nil
+				}
+			}
+			return time.Now().In(loc).Format("2006-01-02 15:04:05"), nil
 		},
 		"TODAY": func(args ...any) (any, error) {
-			return time.Now().Format("2006-01-02"), nil
+			if err := validateArgs("TODAY", args, 0, 1); err != nil {
+				return nil, err
+			}
+			loc := time.UTC
+			if len(args) == 1 {
+				var err error
+				loc, err = loadLocation(toString(args[0]))
+				if err != nil {
+					return ErrTZ, nil
+				}
+			}
+			return time.Now().In(loc).Format("2006-01-02"), nil
+		},
+		"TZCONVERT": func(args ...any) (any, error) {
+			if err := validateArgs("TZCONVERT", args, 3, 3); err != nil {
+				return nil, err
+			}
+			t, err := ParseDateTime(toString(args[0]))
+			if err != nil {
+				return nil, fmt.Errorf("TZCONVERT: %v", err)
+			}
+			fromLoc, err := loadLocation(toString(args[1]))
+			if err != nil {
+				return ErrTZ, nil
+			}
+			toLoc, err := loadLocation(toString(args[2]))
+			if err != nil {
+				return ErrTZ, nil
+			}
+			// t is naive (no zone attached by ParseDateTime), so it's
+			// first pinned to fromLoc before converting to toLoc.
+			pinned := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), fromLoc)
+			return pinned.In(toLoc).Format("2006-01-02 15:04:05"), nil
 		},
 		"DATE": func(args ...any) (any, error) {
 			if err := validateArgs("DATE", args, 3, 3); err != nil {
@@ -925,7 +1132,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			return float64(t.Second()), nil
 		},
 		"WEEKDAY": func(args ...any) (any, error) {
-			if err := validateArgs("WEEKDAY", args, 1, 1); err != nil {
+			if err := validateArgs("WEEKDAY", args, 1, 3); err != nil {
 				return nil, err
 			}
 			dateStr := toString(args[0])
@@ -933,40 +1140,82 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("invalid date format")
 			}
+			numberingType := 1.0
+			if len(args) >= 2 {
+				numberingType, err = toFloat(args[1])
+				if err != nil {
+					return ErrValue, nil
+				}
+			}
+			if len(args) == 3 {
+				loc, err := loadLocation(toString(args[2]))
+				if err != nil {
+					return ErrTZ, nil
+				}
+				t = t.In(loc)
+			}
+			// type 1 (default): Sunday=1..Saturday=7. type 2: Monday=1..Sunday=7.
+			if numberingType == 2 {
+				return float64((int(t.Weekday())+6)%7) + 1, nil
+			}
 			return float64(t.Weekday()) + 1, nil
 		},
 		"DATEDIFF": func(args ...any) (any, error) {
-			if err := validateArgs("DATEDIFF", args, 2, 2); err != nil {
+			if err := validateArgs("DATEDIFF", args, 2, 4); err != nil {
 				return nil, err
 			}
-		    date1Str := toString(args[0])
-		    date2Str := toString(args[1])
-		    t1, err := ParseDateTime(date1Str)
+			date1Str := toString(args[0])
+			date2Str := toString(args[1])
+			t1, err := ParseDateTime(date1Str)
 			if err != nil {
 				return nil, fmt.Errorf("DATEDIFF: %v", err)
 			}
-		    t2, err := ParseDateTime(date2Str)
+			t2, err := ParseDateTime(date2Str)
 			if err != nil {
 				return nil, fmt.Errorf("DATEDIFF: %v", err)
 			}
-		    days := t2.Sub(t1).Hours() / 24
-		    return days, nil
+			unit, err := parseDateUnit(argOrNil(args, 2))
+			if err != nil {
+				return ErrValue, nil
+			}
+			if len(args) == 4 {
+				loc, err := loadLocation(toString(args[3]))
+				if err != nil {
+					return ErrTZ, nil
+				}
+				t1, t2 = t1.In(loc), t2.In(loc)
+			}
+			return dateDiff(t1, t2, unit), nil
 		},
 		"DATEADD": func(args ...any) (any, error) {
-			if err := validateArgs("DATEADD", args, 2, 2); err != nil {
+			if err := validateArgs("DATEADD", args, 2, 4); err != nil {
 				return nil, err
 			}
-		    dateStr := toString(args[0])
-		    days, err := toFloat(args[1])
+			dateStr := toString(args[0])
+			amount, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("DATEADD: %v", err)
 			}
-		    t, err := ParseDateTime(dateStr)
+			t, err := ParseDateTime(dateStr)
 			if err != nil {
 				return nil, fmt.Errorf("DATEADD: %v", err)
 			}
-		    newDate := t.AddDate(0, 0, int(days))
-		    return newDate.Format("2006-01-02"), nil
+			unit, err := parseDateUnit(argOrNil(args, 2))
+			if err != nil {
+				return ErrValue, nil
+			}
+			if len(args) == 4 {
+				loc, err := loadLocation(toString(args[3]))
+				if err != nil {
+					return ErrTZ, nil
+				}
+				t = t.In(loc)
+			}
+			newDate := dateAdd(t, amount, unit)
+			if unit == unitHours || unit == unitMinutes || unit == unitSeconds {
+				return newDate.Format("2006-01-02 15:04:05"), nil
+			}
+			return newDate.Format("2006-01-02"), nil
 		},
 
 		// Type checking functions
@@ -1012,7 +1261,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 				return nil, err
 			}
 			count := 0
-			for _, arg := range args {
+			for _, arg := range flattenRangeArgs(args) {
 				if _, ok := arg.(float64); ok {
 					count++
 				}
@@ -1020,38 +1269,46 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			return float64(count), nil
 		},
 		"SUM": func(args ...any) (any, error) {
-			if err := validateArgs("SUM", args, 2, -1); err != nil {
+			if err := validateArgs("SUM", args, 1, -1); err != nil {
 				return nil, err
 			}
-		    sum := 0.0
-		    for _, arg := range args {
-				f, _ := toFloat(arg)
-		        sum += f
-		    }
-		    return sum, nil
+			sum := numberFromFloat(0)
+			for _, arg := range flattenRangeArgs(args) {
+				if fe, ok := IsFormulaError(arg); ok {
+					return fe, nil
+				}
+				n, err := toNumber(arg)
+				if err != nil {
+					continue
+				}
+				sum = sum.Add(n)
+			}
+			if GetNumericMode() == Decimal {
+				return sum.String(), nil
+			}
+			return sum.Float64(), nil
 		},
 		"PRODUCT": func(args ...any) (any, error) {
-			if err := validateArgs("PRODUCT", args, 2, -1); err != nil {
+			if err := validateArgs("PRODUCT", args, 1, -1); err != nil {
 				return nil, err
 			}
-		    product := 1.0
-		    for _, arg := range args {
+			product := 1.0
+			for _, arg := range flattenRangeArgs(args) {
 				f, _ := toFloat(arg)
-		        product *= f
-		    }
-		    return product, nil
+				product *= f
+			}
+			return product, nil
 		},
 
 		// Constants
-		"PI": func(args ...any) (any, error) { return math.Pi, nil },
-		"E": func(args ...any) (any, error) { return math.E, nil },
+		"PI":  func(args ...any) (any, error) { return math.Pi, nil },
+		"E":   func(args ...any) (any, error) { return math.E, nil },
 		"PHI": func(args ...any) (any, error) { return (1 + math.Sqrt(5)) / 2, nil },
 		"INF": func(args ...any) (any, error) { return math.Inf(1), nil },
 		"NAN": func(args ...any) (any, error) { return math.NaN(), nil },
 
-
 		// Special mathematical functions
-		"ERF": func(args ...any) (any, error) { 
+		"ERF": func(args ...any) (any, error) {
 			if err := validateArgs("ERF", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -1059,9 +1316,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("ERF: %v", err)
 			}
-			return math.Erf(f), nil 
+			return math.Erf(f), nil
 		},
-		"ERFC": func(args ...any) (any, error) { 
+		"ERFC": func(args ...any) (any, error) {
 			if err := validateArgs("ERFC", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -1069,9 +1326,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("ERFC: %v", err)
 			}
-			return math.Erfc(f), nil 
+			return math.Erfc(f), nil
 		},
-		"GAMMA": func(args ...any) (any, error) { 
+		"GAMMA": func(args ...any) (any, error) {
 			if err := validateArgs("GAMMA", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -1079,9 +1336,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("GAMMA: %v", err)
 			}
-			return math.Gamma(f), nil 
+			return math.Gamma(f), nil
 		},
-		"J0": func(args ...any) (any, error) { 
+		"J0": func(args ...any) (any, error) {
 			if err := validateArgs("J0", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -1089,9 +1346,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("J0: %v", err)
 			}
-			return math.J0(f), nil 
+			return math.J0(f), nil
 		},
-		"J1": func(args ...any) (any, error) { 
+		"J1": func(args ...any) (any, error) {
 			if err := validateArgs("J1", args, 1, 1); err != nil {
 				return nil, err
 			}
@@ -1099,9 +1356,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("J1: %v", err)
 			}
-			return math.J1(f), nil 
+			return math.J1(f), nil
 		},
-		"YN": func(args ...any) (any, error) { 
+		"YN": func(args ...any) (any, error) {
 			if err := validateArgs("YN", args, 2, 2); err != nil {
 				return nil, err
 			}
@@ -1113,7 +1370,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("YN: %v", err)
 			}
-			return math.Yn(int(n), x), nil 
+			return math.Yn(int(n), x), nil
 		},
 
 		// Additional rounding and precision
@@ -1125,7 +1382,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("TRUNC: %v", err)
 			}
-			return math.Trunc(f), nil 
+			return math.Trunc(f), nil
 		},
 		"ROUNDTO": func(args ...any) (any, error) {
 			if err := validateArgs("ROUNDTO", args, 2, 2); err != nil {
@@ -1139,8 +1396,8 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("ROUNDTO: %v", err)
 			}
-		    scale := math.Pow(10, places)
-		    return math.Round(value * scale) / scale, nil
+			scale := math.Pow(10, places)
+			return math.Round(value*scale) / scale, nil
 		},
 
 		// Engineering functions
@@ -1156,9 +1413,9 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("HYPOT: %v", err)
 			}
-		    return math.Hypot(f1, f2), nil 
+			return math.Hypot(f1, f2), nil
 		},
-		"MOD": func(args ...any) (any, error) { 
+		"MOD": func(args ...any) (any, error) {
 			if err := validateArgs("MOD", args, 2, 2); err != nil {
 				return nil, err
 			}
@@ -1169,10 +1426,10 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			f2, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("MOD: %v", err)
-			}	
-		    return math.Mod(f1, f2), nil 
+			}
+			return math.Mod(f1, f2), nil
 		},
-		"REMAINDER": func(args ...any) (any, error) { 
+		"REMAINDER": func(args ...any) (any, error) {
 			if err := validateArgs("REMAINDER", args, 2, 2); err != nil {
 				return nil, err
 			}
@@ -1184,7 +1441,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("REMAINDER: %v", err)
 			}
-		    return math.Remainder(f1, f2), nil 
+			return math.Remainder(f1, f2), nil
 		},
 
 		// Bit operations
@@ -1199,8 +1456,8 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			f2, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("BITAND: %v", err)
-			}	
-		    return float64(int(f1) & int(f2)), nil
+			}
+			return float64(int(f1) & int(f2)), nil
 		},
 		"BITOR": func(args ...any) (any, error) {
 			if err := validateArgs("BITOR", args, 2, 2); err != nil {
@@ -1214,7 +1471,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("BITOR: %v", err)
 			}
-		    return float64(int(f1) | int(f2)), nil
+			return float64(int(f1) | int(f2)), nil
 		},
 		"BITXOR": func(args ...any) (any, error) {
 			if err := validateArgs("BITXOR", args, 2, 2); err != nil {
@@ -1228,7 +1485,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("BITXOR: %v", err)
 			}
-		    return float64(int(f1) ^ int(f2)), nil
+			return float64(int(f1) ^ int(f2)), nil
 		},
 		"BITSHIFTLEFT": func(args ...any) (any, error) {
 			if err := validateArgs("BITSHIFTLEFT", args, 2, 2); err != nil {
@@ -1241,8 +1498,8 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			f2, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("BITSHIFTLEFT: %v", err)
-			}	
-		    return float64(int(f1) << int(f2)), nil
+			}
+			return float64(int(f1) << int(f2)), nil
 		},
 		"BITSHIFTRIGHT": func(args ...any) (any, error) {
 			if err := validateArgs("BITSHIFTRIGHT", args, 2, 2); err != nil {
@@ -1256,7 +1513,7 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err != nil {
 				return nil, fmt.Errorf("BITSHIFTRIGHT: %v", err)
 			}
-		    return float64(int(f1) >> int(f2)), nil
+			return float64(int(f1) >> int(f2)), nil
 		},
 
 		// Additional utility functions
@@ -1264,12 +1521,12 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			if err := validateArgs("FACTORIAL", args, 1, 1); err != nil {
 				return nil, err
 			}
-		    n, _ := toFloat(args[0])
-		    result := 1.0
-		    for i := 2; i <= int(n); i++ {
-		        result *= float64(i)
-		    }
-		    return result, nil
+			n, _ := toFloat(args[0])
+			result := 1.0
+			for i := 2; i <= int(n); i++ {
+				result *= float64(i)
+			}
+			return result, nil
 		},
 		"GCD": func(args ...any) (any, error) {
 			if err := validateArgs("GCD", args, 2, 2); err != nil {
@@ -1282,12 +1539,12 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			f2, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("GCD: %v", err)
-			}	
+			}
 			a, b := int(f1), int(f2)
-		    for b != 0 {
-		        a, b = b, a%b
-		    }
-		    return float64(a), nil
+			for b != 0 {
+				a, b = b, a%b
+			}
+			return float64(a), nil
 		},
 		"LCM": func(args ...any) (any, error) {
 			if err := validateArgs("LCM", args, 2, 2); err != nil {
@@ -1300,16 +1557,50 @@ func GovalFuncs() map[string]govaluate.ExpressionFunction {
 			f2, err := toFloat(args[1])
 			if err != nil {
 				return nil, fmt.Errorf("LCM: %v", err)
-			}	
-		    a, b := int(f1), int(f2)
-		    gcd := a
-		    temp := b
-		    for temp != 0 {
-		        gcd, temp = temp, gcd%temp
-		    }
-		    return float64(a / gcd * b), nil
+			}
+			a, b := int(f1), int(f2)
+			gcd := a
+			temp := b
+			for temp != 0 {
+				gcd, temp = temp, gcd%temp
+			}
+			return float64(a / gcd * b), nil
 		},
 	}
 
+	for name, fn := range financialFunctions() {
+		functions[name] = fn
+	}
+	for name, fn := range complexFunctions() {
+		functions[name] = fn
+	}
+	for name, fn := range bignumFunctions() {
+		functions[name] = fn
+	}
+	for name, fn := range statsFunctions() {
+		functions[name] = fn
+	}
+	for name, fn := range calendarFunctions() {
+		functions[name] = fn
+	}
+	for name, fn := range helpFunctions() {
+		functions[name] = fn
+	}
+
+	builtinNames := make(map[string]struct{}, len(functions))
+	for name := range functions {
+		builtinNames[name] = struct{}{}
+	}
+	for name, fn := range userFunctions.AsGovalFuncs() {
+		if _, isBuiltin := builtinNames[name]; isBuiltin {
+			continue
+		}
+		functions[name] = fn
+	}
+
+	for _, opt := range opts {
+		opt(functions)
+	}
+
 	return functions
 }
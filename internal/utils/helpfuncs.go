@@ -0,0 +1,61 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// helpfuncs.go adds HELP(name), which looks up a one-line usage string for
+// a function name: first in builtinDocs below, then (since a user-defined
+// name never collides with a builtin - Register rejects that unless
+// AllowOverride is set) in the UDF registry's own FunctionMeta.Doc via
+// UserFunctionHelp. builtinDocs only covers a starter set of the ~150
+// builtins; an undocumented builtin returns #N/A rather than panicking or
+// silently lying about its signature.
+package utils
+
+import (
+	"strings"
+
+	"github.com/Knetic/govaluate"
+)
+
+// builtinDocs is a starter set of HELP entries for commonly-reached-for
+// builtins, not an exhaustive reference for every function GovalFuncs
+// registers - extend it as functions get HELP requests in practice.
+var builtinDocs = map[string]string{
+	"SIN":         "SIN(x) - sine of x radians",
+	"COS":         "COS(x) - cosine of x radians",
+	"TAN":         "TAN(x) - tangent of x radians",
+	"ROUND":       "ROUND(x, digits) - round x to digits decimal places, half away from zero",
+	"SUM":         "SUM(n1, n2, ...) - sum of all arguments",
+	"AVERAGE":     "AVERAGE(n1, n2, ...) - arithmetic mean of all arguments",
+	"NOW":         "NOW([tz]) - current date and time, optionally in an IANA timezone",
+	"TODAY":       "TODAY([tz]) - current date, optionally in an IANA timezone",
+	"TZCONVERT":   "TZCONVERT(datetime, fromTZ, toTZ) - convert a datetime between IANA timezones",
+	"WEEKDAY":     "WEEKDAY(date, [type], [tz]) - day-of-week number for date",
+	"DATEDIFF":    "DATEDIFF(date1, date2, [unit], [tz]) - difference between two dates in unit (days|hours|minutes|seconds|months|years)",
+	"DATEADD":     "DATEADD(date, amount, [unit], [tz]) - date shifted by amount units",
+	"NETWORKDAYS": "NETWORKDAYS(start, end, [holidays...]) - count of business days between start and end",
+	"WORKDAY":     "WORKDAY(start, n, [holidays...]) - date n business days after (or before, if n<0) start",
+	"EOMONTH":     "EOMONTH(date, months) - last day of the month months away from date",
+	"EDATE":       "EDATE(date, months) - date months away from date, clamped to the target month's last day",
+	"YEARFRAC":    "YEARFRAC(start, end, [basis]) - fraction of a year between start and end",
+	"HELP":        "HELP(name) - one-line usage for a builtin or user-defined function",
+}
+
+func helpFunctions() map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		"HELP": func(args ...any) (any, error) {
+			if err := validateArgs("HELP", args, 1, 1); err != nil {
+				return nil, err
+			}
+			name := strings.ToUpper(strings.TrimSpace(toString(args[0])))
+			if doc, ok := builtinDocs[name]; ok {
+				return doc, nil
+			}
+			if doc, ok := UserFunctionHelp(name); ok {
+				return doc, nil
+			}
+			return ErrNA, nil
+		},
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// rangeargs.go adds range/array arguments to the formula engine and the
+// aggregate functions (SUM, SUMIF, COUNTIF, AVERAGEIF, VLOOKUP, INDEX,
+// MATCH, SUBTOTAL) that depend on them. govaluate itself only understands scalar
+// function arguments, so a range like A1:B3 is rewritten into a RANGE(...)
+// call before the expression is parsed; RANGE resolves it to a CellRange
+// via the caller-supplied RangeResolver.
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+)
+
+// CellRange is a resolved rectangular range of cell values, kept row-major
+// so functions that care about shape (VLOOKUP, INDEX) can still address it,
+// while functions that don't (SUM, COUNT) can just range over Values.
+type CellRange struct {
+	Values     []any
+	Rows, Cols int
+}
+
+// At returns the value at (row, col), 0-indexed within the range.
+func (r CellRange) At(row, col int) (any, bool) {
+	if row < 0 || row >= r.Rows || col < 0 || col >= r.Cols {
+		return nil, false
+	}
+	return r.Values[row*r.Cols+col], true
+}
+
+// Column returns every value in the given 0-indexed column.
+func (r CellRange) Column(col int) []any {
+	out := make([]any, 0, r.Rows)
+	for row := 0; row < r.Rows; row++ {
+		if v, ok := r.At(row, col); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// RangeResolver resolves a spreadsheet range reference (e.g. "A1:B3") to its
+// current values. It is supplied by the caller (the formula evaluator),
+// which is the only place that has access to the sheet's cell data.
+type RangeResolver func(rangeRef string) (CellRange, error)
+
+var rangeRefPattern = regexp.MustCompile(`\b([A-Z]+\d+):([A-Z]+\d+)\b`)
+
+// ExpandRangeRefs rewrites every "A1:B3"-shaped range reference in expr into
+// a RANGE("A1:B3") call so govaluate's parser (which has no concept of a
+// range literal) can still parse the expression.
+func ExpandRangeRefs(expr string) string {
+	return rangeRefPattern.ReplaceAllString(expr, `RANGE("$1:$2")`)
+}
+
+// GovalFuncsWithRanges returns the standard function set plus RANGE and the
+// range-aware aggregate functions, bound to resolver. Pass the result to
+// govaluate.NewEvaluableExpressionWithFunctions after preprocessing the
+// expression with ExpandRangeRefs.
+func GovalFuncsWithRanges(resolver RangeResolver) map[string]govaluate.ExpressionFunction {
+	functions := GovalFuncs()
+
+	functions["RANGE"] = func(args ...any) (any, error) {
+		if err := validateArgs("RANGE", args, 1, 1); err != nil {
+			return nil, err
+		}
+		ref, ok := args[0].(string)
+		if !ok {
+			return nil, ErrRef
+		}
+		rng, err := resolver(ref)
+		if err != nil {
+			return ErrRef, nil
+		}
+		return rng, nil
+	}
+
+	functions["SUMIF"] = func(args ...any) (any, error) {
+		if err := validateArgs("SUMIF", args, 2, 3); err != nil {
+			return nil, err
+		}
+		rng, ok := args[0].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+		sumRange := rng
+		if len(args) == 3 {
+			sr, ok := args[2].(CellRange)
+			if !ok {
+				return ErrValue, nil
+			}
+			sumRange = sr
+		}
+
+		sum := 0.0
+		for i, v := range rng.Values {
+			if !matchesCriteria(v, args[1]) {
+				continue
+			}
+			if i >= len(sumRange.Values) {
+				continue
+			}
+			f, err := toFloat(sumRange.Values[i])
+			if err != nil {
+				continue
+			}
+			sum += f
+		}
+		return sum, nil
+	}
+
+	functions["COUNTIF"] = func(args ...any) (any, error) {
+		if err := validateArgs("COUNTIF", args, 2, 2); err != nil {
+			return nil, err
+		}
+		rng, ok := args[0].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+		count := 0
+		for _, v := range rng.Values {
+			if matchesCriteria(v, args[1]) {
+				count++
+			}
+		}
+		return float64(count), nil
+	}
+
+	functions["AVERAGEIF"] = func(args ...any) (any, error) {
+		if err := validateArgs("AVERAGEIF", args, 2, 3); err != nil {
+			return nil, err
+		}
+		rng, ok := args[0].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+		avgRange := rng
+		if len(args) == 3 {
+			ar, ok := args[2].(CellRange)
+			if !ok {
+				return ErrValue, nil
+			}
+			avgRange = ar
+		}
+
+		sum, n := 0.0, 0
+		for i, v := range rng.Values {
+			if !matchesCriteria(v, args[1]) || i >= len(avgRange.Values) {
+				continue
+			}
+			f, err := toFloat(avgRange.Values[i])
+			if err != nil {
+				continue
+			}
+			sum += f
+			n++
+		}
+		if n == 0 {
+			return ErrDivZero, nil
+		}
+		return sum / float64(n), nil
+	}
+
+	functions["VLOOKUP"] = func(args ...any) (any, error) {
+		if err := validateArgs("VLOOKUP", args, 3, 4); err != nil {
+			return nil, err
+		}
+		lookup := args[0]
+		table, ok := args[1].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+		colIndex, err := toFloat(args[2])
+		if err != nil {
+			return ErrValue, nil
+		}
+		col := int(colIndex) - 1
+		if col < 0 || col >= table.Cols {
+			return ErrRef, nil
+		}
+
+		for row := 0; row < table.Rows; row++ {
+			v, _ := table.At(row, 0)
+			if matchesCriteria(v, lookup) {
+				result, ok := table.At(row, col)
+				if !ok {
+					return ErrRef, nil
+				}
+				return result, nil
+			}
+		}
+		return ErrNA, nil
+	}
+
+	functions["INDEX"] = func(args ...any) (any, error) {
+		if err := validateArgs("INDEX", args, 2, 3); err != nil {
+			return nil, err
+		}
+		table, ok := args[0].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+		rowF, err := toFloat(args[1])
+		if err != nil {
+			return ErrValue, nil
+		}
+		col := 0
+		if len(args) == 3 {
+			colF, err := toFloat(args[2])
+			if err != nil {
+				return ErrValue, nil
+			}
+			col = int(colF) - 1
+		}
+		v, ok := table.At(int(rowF)-1, col)
+		if !ok {
+			return ErrRef, nil
+		}
+		return v, nil
+	}
+
+	functions["MATCH"] = func(args ...any) (any, error) {
+		if err := validateArgs("MATCH", args, 2, 3); err != nil {
+			return nil, err
+		}
+		lookup := args[0]
+		rng, ok := args[1].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+		for i, v := range rng.Values {
+			if matchesCriteria(v, lookup) {
+				return float64(i + 1), nil
+			}
+		}
+		return ErrNA, nil
+	}
+
+	functions["SUMIFS"] = func(args ...any) (any, error) {
+		if err := validateArgs("SUMIFS", args, 3, -1); err != nil {
+			return nil, err
+		}
+		if len(args)%2 != 1 {
+			return nil, fmt.Errorf("SUMIFS requires a sum range followed by criteria-range/criterion pairs")
+		}
+		sumRange, ok := args[0].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+
+		sum := 0.0
+		for i := range sumRange.Values {
+			if !matchesAllCriteria(args[1:], i) {
+				continue
+			}
+			f, err := toFloat(sumRange.Values[i])
+			if err != nil {
+				continue
+			}
+			sum += f
+		}
+		return sum, nil
+	}
+
+	functions["COUNTIFS"] = func(args ...any) (any, error) {
+		if err := validateArgs("COUNTIFS", args, 2, -1); err != nil {
+			return nil, err
+		}
+		if len(args)%2 != 0 {
+			return nil, fmt.Errorf("COUNTIFS requires criteria-range/criterion pairs")
+		}
+		firstRange, ok := args[0].(CellRange)
+		if !ok {
+			return ErrValue, nil
+		}
+
+		count := 0
+		for i := range firstRange.Values {
+			if matchesAllCriteria(args, i) {
+				count++
+			}
+		}
+		return float64(count), nil
+	}
+
+	functions["SUBTOTAL"] = func(args ...any) (any, error) {
+		if err := validateArgs("SUBTOTAL", args, 2, -1); err != nil {
+			return nil, err
+		}
+		funcNum, err := toFloat(args[0])
+		if err != nil {
+			return ErrValue, nil
+		}
+		rest := flattenRangeArgs(args[1:])
+		switch int(funcNum) {
+		case 1:
+			return functions["AVERAGE"](rest...)
+		case 2:
+			return functions["COUNT"](rest...)
+		case 3:
+			return functions["COUNTA"](rest...)
+		case 4:
+			return functions["MAX"](rest...)
+		case 5:
+			return functions["MIN"](rest...)
+		case 9:
+			return functions["SUM"](rest...)
+		default:
+			return ErrValue, nil
+		}
+	}
+
+	return functions
+}
+
+// matchesAllCriteria checks row i of every (range, criterion) pair in pairs
+// against matchesCriteria, the shared implementation behind SUMIFS/COUNIFS'
+// AND-of-conditions semantics.
+func matchesAllCriteria(pairs []any, i int) bool {
+	for p := 0; p+1 < len(pairs); p += 2 {
+		rng, ok := pairs[p].(CellRange)
+		if !ok || i >= len(rng.Values) {
+			return false
+		}
+		if !matchesCriteria(rng.Values[i], pairs[p+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenRangeArgs expands any CellRange arguments in place so scalar
+// aggregate functions (SUM, COUNT, PRODUCT, ...) can treat SUM(A1:A10) the
+// same as SUM(A1, A2, ..., A10).
+func flattenRangeArgs(args []any) []any {
+	flat := make([]any, 0, len(args))
+	for _, arg := range args {
+		if rng, ok := arg.(CellRange); ok {
+			flat = append(flat, rng.Values...)
+			continue
+		}
+		flat = append(flat, arg)
+	}
+	return flat
+}
+
+// matchesCriteria implements Excel's SUMIF/COUNTIF-style criteria matching:
+// a bare value is compared for equality, while a string starting with a
+// comparison operator (">10", "<=5", "<>0") is evaluated as that comparison.
+func matchesCriteria(value, criteria any) bool {
+	criteriaStr, isString := criteria.(string)
+	if !isString {
+		return equalLoose(value, criteria)
+	}
+
+	for _, op := range []string{">=", "<=", "<>", ">", "<", "="} {
+		if strings.HasPrefix(criteriaStr, op) {
+			rhs := strings.TrimSpace(strings.TrimPrefix(criteriaStr, op))
+			return compareOp(value, rhs, op)
+		}
+	}
+
+	if strings.ContainsAny(criteriaStr, "*?") {
+		return matchesGlob(toString(value), criteriaStr)
+	}
+
+	return equalLoose(value, criteriaStr)
+}
+
+// matchesGlob implements Excel's SUMIF/COUNTIF wildcard criteria: "*" matches
+// any run of characters and "?" matches exactly one, both case-insensitive.
+func matchesGlob(value, pattern string) bool {
+	pattern = regexp.QuoteMeta(strings.ToLower(pattern))
+	pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+	pattern = strings.ReplaceAll(pattern, `\?`, ".")
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.ToLower(value))
+}
+
+func compareOp(value any, rhsStr, op string) bool {
+	vf, vErr := toFloat(value)
+	rf, rErr := toFloat(rhsStr)
+
+	if vErr == nil && rErr == nil {
+		switch op {
+		case ">":
+			return vf > rf
+		case "<":
+			return vf < rf
+		case ">=":
+			return vf >= rf
+		case "<=":
+			return vf <= rf
+		case "<>":
+			return vf != rf
+		case "=":
+			return vf == rf
+		}
+	}
+
+	if op == "<>" {
+		return toString(value) != rhsStr
+	}
+	return toString(value) == rhsStr
+}
+
+func equalLoose(a, b any) bool {
+	af, aErr := toFloat(a)
+	bf, bErr := toFloat(b)
+	if aErr == nil && bErr == nil {
+		return af == bf
+	}
+	return strings.EqualFold(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// region.go defines Range, a rectangular block of cells identified by its
+// corners. It backs merged-cell regions (table.Sheet.Merges) and is kept
+// here rather than in the table package so both the sheet model and the
+// fileop codecs can depend on it without a cycle.
+package utils
+
+// Range is a rectangular, 1-indexed, inclusive block of cells.
+type Range struct {
+	TopRow, LeftCol, BottomRow, RightCol int32
+}
+
+// Contains reports whether (row, col) falls inside r.
+func (r Range) Contains(row, col int32) bool {
+	return row >= r.TopRow && row <= r.BottomRow && col >= r.LeftCol && col <= r.RightCol
+}
+
+// Overlaps reports whether r and other share any cell.
+func (r Range) Overlaps(other Range) bool {
+	return r.TopRow <= other.BottomRow && other.TopRow <= r.BottomRow &&
+		r.LeftCol <= other.RightCol && other.LeftCol <= r.RightCol
+}
+
+// RowSpan returns how many rows r covers.
+func (r Range) RowSpan() int32 {
+	return r.BottomRow - r.TopRow + 1
+}
+
+// ColSpan returns how many columns r covers.
+func (r Range) ColSpan() int32 {
+	return r.RightCol - r.LeftCol + 1
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// statsfuncs.go provides a numerically stable statistical suite built on
+// Welford's online algorithm, so VAR/STDEV/SKEW/KURT don't suffer the
+// catastrophic cancellation a naive sum-of-squares formula hits on large
+// inputs. MEDIAN/PERCENTILE/QUARTILE use quickselect (Hoare partition)
+// instead of a full sort, so they stay O(n) rather than O(n log n).
+//
+// Excel spells these VAR.P/VAR.S/STDEV.P/STDEV.S; govaluate's expression
+// parser treats "." as a parameter accessor rather than part of an
+// identifier, so a literal VAR.P(...) call wouldn't tokenize as a function
+// call. Both the dot and underscore spellings are registered so existing
+// Excel-style formulas and code calling the underscore form both work.
+package utils
+
+import (
+	"math"
+
+	"github.com/Knetic/govaluate"
+)
+
+// welfordMoments holds the running n/mean/M2/M3/M4 accumulators from
+// Welford's recurrence, computed once and shared by VAR/STDEV/SKEW/KURT so
+// each one doesn't re-walk the input independently.
+type welfordMoments struct {
+	n          float64
+	mean       float64
+	m2, m3, m4 float64
+}
+
+func computeWelford(values []float64) welfordMoments {
+	var w welfordMoments
+	for _, x := range values {
+		n1 := w.n
+		w.n++
+		delta := x - w.mean
+		deltaN := delta / w.n
+		deltaN2 := deltaN * deltaN
+		term1 := delta * deltaN * n1
+
+		w.m4 += term1*deltaN2*(w.n*w.n-3*w.n+3) + 6*deltaN2*w.m2 - 4*deltaN*w.m3
+		w.m3 += term1*deltaN*(w.n-2) - 3*deltaN*w.m2
+		w.m2 += term1
+		w.mean += deltaN
+	}
+	return w
+}
+
+func statsFunctions() map[string]govaluate.ExpressionFunction {
+	numericArgs := func(name string, args []any) ([]float64, error) {
+		flat := flattenRangeArgs(args)
+		values := make([]float64, 0, len(flat))
+		for _, arg := range flat {
+			f, err := toFloat(arg)
+			if err != nil {
+				continue
+			}
+			values = append(values, f)
+		}
+		if len(values) == 0 {
+			return nil, ErrDivZero
+		}
+		return values, nil
+	}
+
+	varP := func(args ...any) (any, error) {
+		if err := validateArgs("VAR.P", args, 1, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("VAR.P", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		w := computeWelford(values)
+		return w.m2 / w.n, nil
+	}
+	varS := func(args ...any) (any, error) {
+		if err := validateArgs("VAR.S", args, 2, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("VAR.S", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		w := computeWelford(values)
+		if w.n < 2 {
+			return ErrDivZero, nil
+		}
+		return w.m2 / (w.n - 1), nil
+	}
+	stdevP := func(args ...any) (any, error) {
+		if err := validateArgs("STDEV.P", args, 1, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("STDEV.P", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		w := computeWelford(values)
+		return math.Sqrt(w.m2 / w.n), nil
+	}
+	stdevS := func(args ...any) (any, error) {
+		if err := validateArgs("STDEV.S", args, 2, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("STDEV.S", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		w := computeWelford(values)
+		if w.n < 2 {
+			return ErrDivZero, nil
+		}
+		return math.Sqrt(w.m2 / (w.n - 1)), nil
+	}
+	skew := func(args ...any) (any, error) {
+		if err := validateArgs("SKEW", args, 2, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("SKEW", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		w := computeWelford(values)
+		if w.m2 == 0 {
+			return ErrDivZero, nil
+		}
+		return math.Sqrt(w.n) * w.m3 / math.Pow(w.m2, 1.5), nil
+	}
+	kurt := func(args ...any) (any, error) {
+		if err := validateArgs("KURT", args, 2, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("KURT", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		w := computeWelford(values)
+		if w.m2 == 0 {
+			return ErrDivZero, nil
+		}
+		return w.n*w.m4/(w.m2*w.m2) - 3, nil
+	}
+	median := func(args ...any) (any, error) {
+		if err := validateArgs("MEDIAN", args, 1, -1); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("MEDIAN", args)
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		return quantile(values, 0.5), nil
+	}
+	percentile := func(args ...any) (any, error) {
+		if err := validateArgs("PERCENTILE", args, 2, 2); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("PERCENTILE", args[:1])
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		p, ferr := toFloat(args[1])
+		if ferr != nil || p < 0 || p > 1 {
+			return ErrNum, nil
+		}
+		return quantile(values, p), nil
+	}
+	quartile := func(args ...any) (any, error) {
+		if err := validateArgs("QUARTILE", args, 2, 2); err != nil {
+			return nil, err
+		}
+		values, err := numericArgs("QUARTILE", args[:1])
+		if err != nil {
+			return err.(FormulaError), nil
+		}
+		q, ferr := toFloat(args[1])
+		if ferr != nil || q < 0 || q > 4 {
+			return ErrNum, nil
+		}
+		return quantile(values, q/4), nil
+	}
+
+	return map[string]govaluate.ExpressionFunction{
+		"VAR.P": varP, "VAR_P": varP,
+		"VAR.S": varS, "VAR_S": varS,
+		"STDEV.P": stdevP, "STDEV_P": stdevP,
+		"STDEV.S": stdevS, "STDEV_S": stdevS,
+		"SKEW":       skew,
+		"KURT":       kurt,
+		"MEDIAN":     median,
+		"PERCENTILE": percentile,
+		"QUARTILE":   quartile,
+	}
+}
+
+// quantile returns the value at fraction p (0..1) of values via quickselect,
+// so it runs in expected O(n) rather than paying for a full sort. Excel's
+// linear-interpolation convention is used between the two bracketing order
+// statistics when p*(n-1) isn't a whole number.
+func quantile(values []float64, p float64) float64 {
+	work := append([]float64(nil), values...)
+	n := len(work)
+	if n == 1 {
+		return work[0]
+	}
+	pos := p * float64(n-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	frac := pos - float64(lo)
+
+	loVal := quickselect(work, lo)
+	if hi == lo {
+		return loVal
+	}
+	hiVal := quickselect(work, hi)
+	return loVal + frac*(hiVal-loVal)
+}
+
+// quickselect returns the k-th smallest element (0-indexed) of values using
+// an in-place Hoare partition, mutating values in the process.
+func quickselect(values []float64, k int) float64 {
+	lo, hi := 0, len(values)-1
+	for lo < hi {
+		pivotIndex := hoarePartition(values, lo, hi)
+		switch {
+		case k == pivotIndex:
+			return values[k]
+		case k < pivotIndex:
+			hi = pivotIndex - 1
+		default:
+			lo = pivotIndex + 1
+		}
+	}
+	return values[lo]
+}
+
+func hoarePartition(values []float64, lo, hi int) int {
+	pivot := values[(lo+hi)/2]
+	i, j := lo-1, hi+1
+	for {
+		for {
+			i++
+			if values[i] >= pivot {
+				break
+			}
+		}
+		for {
+			j--
+			if values[j] <= pivot {
+				break
+			}
+		}
+		if i >= j {
+			return j
+		}
+		values[i], values[j] = values[j], values[i]
+	}
+}
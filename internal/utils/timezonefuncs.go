@@ -0,0 +1,168 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// timezonefuncs.go adds timezone and unit awareness to the date/time
+// functions in govaluatefunc.go: NOW/TODAY/WEEKDAY/DATEDIFF/DATEADD all
+// gained an optional trailing IANA timezone argument, DATEDIFF/DATEADD
+// gained an optional unit ("days"|"hours"|"minutes"|"seconds"|"months"|
+// "years"), and TZCONVERT/NOW/TODAY are new. time.LoadLocation results are
+// cached in a sync.Map keyed by zone name, since it's resolved (and may hit
+// the filesystem for the system zoneinfo database) on every call otherwise.
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var locationCache sync.Map // string -> *time.Location
+
+// dateTimeLayouts are the formats ParseDateTime tries in turn, covering
+// every shape DATE/TIME/NOW/TODAY's own Format calls produce: a full
+// datetime, an ISO "T"-separated datetime, a bare date, and a bare time
+// (used when only HOUR/MINUTE/SECOND care about the result).
+var dateTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// ParseDateTime parses s against dateTimeLayouts in turn, returning the
+// first match as a naive time.Time (no location attached - callers that
+// care about a zone, e.g. TZCONVERT, pin one on afterwards via time.Date).
+// A date-only or time-only match fills in the zero value for whichever
+// half is missing (year 0 for a bare time, midnight for a bare date).
+func ParseDateTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date/time %q", s)
+}
+
+// argOrNil returns args[i], or nil if the call site didn't supply that many
+// arguments — used for the trailing optional unit/timezone arguments shared
+// by DATEDIFF/DATEADD.
+func argOrNil(args []any, i int) any {
+	if i >= len(args) {
+		return nil
+	}
+	return args[i]
+}
+
+// loadLocation resolves an IANA timezone name, caching the result. An empty
+// name resolves to UTC, matching every existing date/time function's
+// unqualified (zone-less) behavior.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if cached, ok := locationCache.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrTZ
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
+
+// dateUnit is one of the units DATEDIFF/DATEADD accept.
+type dateUnit string
+
+const (
+	unitDays    dateUnit = "days"
+	unitHours   dateUnit = "hours"
+	unitMinutes dateUnit = "minutes"
+	unitSeconds dateUnit = "seconds"
+	unitMonths  dateUnit = "months"
+	unitYears   dateUnit = "years"
+)
+
+// parseDateUnit normalizes a unit argument, defaulting to days to match
+// DATEDIFF/DATEADD's original whole-days-only behavior.
+func parseDateUnit(v any) (dateUnit, error) {
+	if v == nil {
+		return unitDays, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(toString(v))) {
+	case "", "day", "days":
+		return unitDays, nil
+	case "hour", "hours":
+		return unitHours, nil
+	case "minute", "minutes":
+		return unitMinutes, nil
+	case "second", "seconds":
+		return unitSeconds, nil
+	case "month", "months":
+		return unitMonths, nil
+	case "year", "years":
+		return unitYears, nil
+	default:
+		return "", ErrValue
+	}
+}
+
+// dateDiff returns t2-t1 expressed in unit, using AddDate-consistent
+// whole-month/whole-year counting for "months"/"years" (calendar
+// subtraction, not a fixed 30/365-day approximation) and time.Duration
+// arithmetic otherwise.
+func dateDiff(t1, t2 time.Time, unit dateUnit) float64 {
+	switch unit {
+	case unitHours:
+		return t2.Sub(t1).Hours()
+	case unitMinutes:
+		return t2.Sub(t1).Minutes()
+	case unitSeconds:
+		return t2.Sub(t1).Seconds()
+	case unitMonths:
+		return monthsBetween(t1, t2)
+	case unitYears:
+		return monthsBetween(t1, t2) / 12
+	default: // unitDays
+		return t2.Sub(t1).Hours() / 24
+	}
+}
+
+// monthsBetween counts whole calendar months between t1 and t2 (negative if
+// t2 is before t1), the same way Excel's DATEDIF("m") does.
+func monthsBetween(t1, t2 time.Time) float64 {
+	sign := 1.0
+	if t2.Before(t1) {
+		t1, t2 = t2, t1
+		sign = -1
+	}
+	months := (t2.Year()-t1.Year())*12 + int(t2.Month()) - int(t1.Month())
+	if t2.Day() < t1.Day() {
+		months--
+	}
+	return sign * float64(months)
+}
+
+// dateAdd adds amount units to t, using time.Time.AddDate for
+// months/years/days (so calendar length, not a fixed duration, governs
+// month/year math) and time.Duration for hours/minutes/seconds.
+func dateAdd(t time.Time, amount float64, unit dateUnit) time.Time {
+	switch unit {
+	case unitHours:
+		return t.Add(time.Duration(amount * float64(time.Hour)))
+	case unitMinutes:
+		return t.Add(time.Duration(amount * float64(time.Minute)))
+	case unitSeconds:
+		return t.Add(time.Duration(amount * float64(time.Second)))
+	case unitMonths:
+		return t.AddDate(0, int(amount), 0)
+	case unitYears:
+		return t.AddDate(int(amount), 0, 0)
+	default: // unitDays
+		return t.AddDate(0, 0, int(amount))
+	}
+}
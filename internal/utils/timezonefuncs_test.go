@@ -0,0 +1,164 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// loadAmericaNewYork skips the test if the system zoneinfo database doesn't
+// carry America/New_York, rather than failing a DST test for an environment
+// reason unrelated to the code under test.
+func loadAmericaNewYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := loadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("America/New_York not available in this environment: %v", err)
+	}
+	return loc
+}
+
+func TestDateAddAcrossSpringForwardDST(t *testing.T) {
+	loc := loadAmericaNewYork(t)
+	// 2024-03-10 02:00 America/New_York is the spring-forward transition
+	// (clocks jump 2:00 -> 3:00). Adding a day via AddDate must land on the
+	// following calendar day at the same wall-clock hour, not be thrown off
+	// by the missing hour.
+	start := time.Date(2024, 3, 9, 1, 30, 0, 0, loc)
+	got := dateAdd(start, 1, unitDays)
+	want := time.Date(2024, 3, 10, 1, 30, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("dateAdd across spring-forward DST = %v, want %v", got, want)
+	}
+
+	// Adding 24 real hours, by contrast, is clock-duration arithmetic and
+	// does observe the missing hour: 1:30 AM + 24h lands on 2:30 AM, not
+	// 1:30 AM, because one fewer wall-clock hour elapsed that day.
+	gotHours := dateAdd(start, 24, unitHours)
+	wantHours := time.Date(2024, 3, 10, 2, 30, 0, 0, loc)
+	if !gotHours.Equal(wantHours) {
+		t.Errorf("dateAdd 24 hours across spring-forward DST = %v, want %v", gotHours, wantHours)
+	}
+}
+
+func TestDateDiffAcrossFallBackDST(t *testing.T) {
+	loc := loadAmericaNewYork(t)
+	// 2024-11-03 America/New_York is the fall-back transition (clocks repeat
+	// 1:00-2:00), so the calendar day has 25 hours. dateDiff in "hours"
+	// reflects the actual elapsed duration, not a naive 24.
+	start := time.Date(2024, 11, 3, 0, 0, 0, 0, loc)
+	end := time.Date(2024, 11, 4, 0, 0, 0, 0, loc)
+	if got := dateDiff(start, end, unitHours); got != 25 {
+		t.Errorf("dateDiff hours across fall-back DST = %v, want 25", got)
+	}
+	// dateDiff's "days" unit is duration-based (hours/24), like "hours" -
+	// it doesn't count calendar days, so the 25-hour span is 25/24 days,
+	// not a naive 1.
+	want := 25.0 / 24.0
+	if got := dateDiff(start, end, unitDays); got != want {
+		t.Errorf("dateDiff days across fall-back DST = %v, want %v", got, want)
+	}
+}
+
+func TestDateDiffNegativeDuration(t *testing.T) {
+	t1 := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	if got := dateDiff(t1, t2, unitDays); got >= 0 {
+		t.Errorf("dateDiff days for an earlier t2 = %v, want a negative value", got)
+	}
+	if got := dateDiff(t1, t2, unitHours); got != -123 {
+		t.Errorf("dateDiff hours for an earlier t2 = %v, want -123", got)
+	}
+	if got := monthsBetween(t1, t2); got >= 0 {
+		t.Errorf("monthsBetween for an earlier t2 = %v, want a negative value", got)
+	}
+}
+
+func TestDateAddNegativeAmount(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := dateAdd(start, -5, unitDays)
+	want := time.Date(2023, 12, 27, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("dateAdd -5 days = %v, want %v", got, want)
+	}
+
+	gotMonths := dateAdd(start, -1, unitMonths)
+	wantMonths := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	if !gotMonths.Equal(wantMonths) {
+		t.Errorf("dateAdd -1 months = %v, want %v", gotMonths, wantMonths)
+	}
+}
+
+func TestLoadLocationUnknownZone(t *testing.T) {
+	if _, err := loadLocation("Not/A_Real_Zone"); err != ErrTZ {
+		t.Errorf("loadLocation(unknown) error = %v, want ErrTZ", err)
+	}
+}
+
+func TestLoadLocationEmptyIsUTC(t *testing.T) {
+	loc, err := loadLocation("")
+	if err != nil {
+		t.Fatalf("loadLocation(\"\") error = %v, want nil", err)
+	}
+	if loc != time.UTC {
+		t.Errorf("loadLocation(\"\") = %v, want time.UTC", loc)
+	}
+}
+
+func TestParseDateUnit(t *testing.T) {
+	cases := map[string]dateUnit{
+		"":       unitDays,
+		"Days":   unitDays,
+		"HOUR":   unitHours,
+		"minute": unitMinutes,
+		"second": unitSeconds,
+		"Month":  unitMonths,
+		"years":  unitYears,
+	}
+	for in, want := range cases {
+		got, err := parseDateUnit(in)
+		if err != nil {
+			t.Errorf("parseDateUnit(%q) error = %v, want nil", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseDateUnit(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := parseDateUnit("fortnights"); err != ErrValue {
+		t.Errorf("parseDateUnit(invalid) error = %v, want ErrValue", err)
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-06-15 13:45:30", time.Date(2024, 6, 15, 13, 45, 30, 0, time.UTC)},
+		{"2024-06-15T13:45:30", time.Date(2024, 6, 15, 13, 45, 30, 0, time.UTC)},
+		{"2024-06-15", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"13:45:30", time.Date(0, 1, 1, 13, 45, 30, 0, time.UTC)},
+		{"  2024-06-15  ", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, err := ParseDateTime(c.in)
+		if err != nil {
+			t.Errorf("ParseDateTime(%q) error = %v, want nil", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("ParseDateTime(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+
+	if _, err := ParseDateTime("not a date"); err == nil {
+		t.Errorf("ParseDateTime(invalid) error = nil, want an error")
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// gosandbox.go interprets a restricted subset of Go statements and
+// expressions for UDFs of KindGo. It never compiles or executes real Go
+// code (no go/build, no plugin, no os/exec) — it walks a parsed AST by
+// hand, so a UDF body can only do arithmetic, comparisons, local variables,
+// if/else, and bounded for loops over the whitelisted math functions below.
+package udf
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+)
+
+// maxLoopIterations bounds every for-loop so a UDF body can't hang the
+// evaluator; it is generous enough for any legitimate spreadsheet function.
+const maxLoopIterations = 1_000_000
+
+// program is a compiled KindGo UDF body, ready to be interpreted by run.
+type program struct {
+	body   *ast.BlockStmt
+	params []string
+}
+
+// sandboxFuncs is the closed set of functions a Go-bodied UDF may call.
+// Anything not listed here (imports, method calls, goroutines, I/O) simply
+// isn't reachable by the interpreter.
+var sandboxFuncs = map[string]func(args []float64) (float64, error){
+	"abs":   func(a []float64) (float64, error) { return math.Abs(a[0]), nil },
+	"sqrt":  func(a []float64) (float64, error) { return math.Sqrt(a[0]), nil },
+	"pow":   func(a []float64) (float64, error) { return math.Pow(a[0], a[1]), nil },
+	"floor": func(a []float64) (float64, error) { return math.Floor(a[0]), nil },
+	"ceil":  func(a []float64) (float64, error) { return math.Ceil(a[0]), nil },
+	"round": func(a []float64) (float64, error) { return math.Round(a[0]), nil },
+	"min":   func(a []float64) (float64, error) { return math.Min(a[0], a[1]), nil },
+	"max":   func(a []float64) (float64, error) { return math.Max(a[0], a[1]), nil },
+	"mod":   func(a []float64) (float64, error) { return math.Mod(a[0], a[1]), nil },
+}
+
+// compileGo parses body as the statement list of a function taking params
+// and returning a single value, e.g.:
+//
+//	if a > b { return a }
+//	return b
+func compileGo(body string, params []string) (*program, error) {
+	src := "package udf\nfunc udf() {\n" + body + "\n}\n"
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	fn, ok := file.Decls[0].(*ast.FuncDecl)
+	if !ok || fn.Body == nil {
+		return nil, fmt.Errorf("expected a function body")
+	}
+
+	return &program{body: fn.Body, params: params}, nil
+}
+
+// returnValue signals a return statement unwinding out of nested blocks.
+type returnValue struct{ value float64 }
+
+func (returnValue) Error() string { return "return" }
+
+// run interprets a compiled program against a set of bound parameter values.
+func run(prog *program, params map[string]any) (any, error) {
+	env := make(map[string]float64, len(params))
+	for name, v := range params {
+		f, err := toFloatArg(v)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		env[name] = f
+	}
+
+	err := execStmts(prog.body.List, env)
+	if ret, ok := err.(returnValue); ok {
+		return ret.value, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("function body did not return a value")
+}
+
+func execStmts(stmts []ast.Stmt, env map[string]float64) error {
+	for _, stmt := range stmts {
+		if err := execStmt(stmt, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func execStmt(stmt ast.Stmt, env map[string]float64) error {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt:
+		if len(s.Results) != 1 {
+			return fmt.Errorf("return must yield exactly one value")
+		}
+		v, err := evalExpr(s.Results[0], env)
+		if err != nil {
+			return err
+		}
+		return returnValue{value: v}
+
+	case *ast.AssignStmt:
+		if len(s.Lhs) != 1 || len(s.Rhs) != 1 {
+			return fmt.Errorf("only single-value assignment is supported")
+		}
+		ident, ok := s.Lhs[0].(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("assignment target must be a plain variable")
+		}
+		v, err := evalExpr(s.Rhs[0], env)
+		if err != nil {
+			return err
+		}
+		switch s.Tok {
+		case token.DEFINE, token.ASSIGN:
+			env[ident.Name] = v
+		case token.ADD_ASSIGN:
+			env[ident.Name] += v
+		case token.SUB_ASSIGN:
+			env[ident.Name] -= v
+		case token.MUL_ASSIGN:
+			env[ident.Name] *= v
+		case token.QUO_ASSIGN:
+			env[ident.Name] /= v
+		default:
+			return fmt.Errorf("unsupported assignment operator %s", s.Tok)
+		}
+		return nil
+
+	case *ast.IfStmt:
+		if s.Init != nil {
+			if err := execStmt(s.Init, env); err != nil {
+				return err
+			}
+		}
+		cond, err := evalCond(s.Cond, env)
+		if err != nil {
+			return err
+		}
+		if cond {
+			return execStmts(s.Body.List, env)
+		}
+		if s.Else != nil {
+			return execStmt(s.Else, env)
+		}
+		return nil
+
+	case *ast.BlockStmt:
+		return execStmts(s.List, env)
+
+	case *ast.ForStmt:
+		if s.Init != nil {
+			if err := execStmt(s.Init, env); err != nil {
+				return err
+			}
+		}
+		for i := 0; ; i++ {
+			if i >= maxLoopIterations {
+				return fmt.Errorf("loop exceeded %d iterations", maxLoopIterations)
+			}
+			if s.Cond != nil {
+				cond, err := evalCond(s.Cond, env)
+				if err != nil {
+					return err
+				}
+				if !cond {
+					break
+				}
+			}
+			if err := execStmt(s.Body, env); err != nil {
+				return err
+			}
+			if s.Post != nil {
+				if err := execStmt(s.Post, env); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case *ast.ExprStmt:
+		_, err := evalExpr(s.X, env)
+		return err
+
+	default:
+		return fmt.Errorf("unsupported statement %T", stmt)
+	}
+}
+
+func evalCond(expr ast.Expr, env map[string]float64) (bool, error) {
+	if be, ok := expr.(*ast.BinaryExpr); ok {
+		switch be.Op {
+		case token.LAND, token.LOR:
+			left, err := evalCond(be.X, env)
+			if err != nil {
+				return false, err
+			}
+			if be.Op == token.LAND && !left {
+				return false, nil
+			}
+			if be.Op == token.LOR && left {
+				return true, nil
+			}
+			return evalCond(be.Y, env)
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			l, err := evalExpr(be.X, env)
+			if err != nil {
+				return false, err
+			}
+			r, err := evalExpr(be.Y, env)
+			if err != nil {
+				return false, err
+			}
+			switch be.Op {
+			case token.EQL:
+				return l == r, nil
+			case token.NEQ:
+				return l != r, nil
+			case token.LSS:
+				return l < r, nil
+			case token.LEQ:
+				return l <= r, nil
+			case token.GTR:
+				return l > r, nil
+			case token.GEQ:
+				return l >= r, nil
+			}
+		}
+	}
+	if ue, ok := expr.(*ast.UnaryExpr); ok && ue.Op == token.NOT {
+		v, err := evalCond(ue.X, env)
+		return !v, err
+	}
+	if pe, ok := expr.(*ast.ParenExpr); ok {
+		return evalCond(pe.X, env)
+	}
+	return false, fmt.Errorf("unsupported condition %T", expr)
+}
+
+func evalExpr(expr ast.Expr, env map[string]float64) (float64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT, token.FLOAT:
+			var f float64
+			if _, err := fmt.Sscanf(e.Value, "%g", &f); err != nil {
+				return 0, fmt.Errorf("invalid numeric literal %q", e.Value)
+			}
+			return f, nil
+		default:
+			return 0, fmt.Errorf("unsupported literal %q", e.Value)
+		}
+
+	case *ast.Ident:
+		if v, ok := env[e.Name]; ok {
+			return v, nil
+		}
+		return 0, fmt.Errorf("undefined variable %q", e.Name)
+
+	case *ast.ParenExpr:
+		return evalExpr(e.X, env)
+
+	case *ast.UnaryExpr:
+		v, err := evalExpr(e.X, env)
+		if err != nil {
+			return 0, err
+		}
+		if e.Op == token.SUB {
+			return -v, nil
+		}
+		if e.Op == token.ADD {
+			return v, nil
+		}
+		return 0, fmt.Errorf("unsupported unary operator %s", e.Op)
+
+	case *ast.BinaryExpr:
+		l, err := evalExpr(e.X, env)
+		if err != nil {
+			return 0, err
+		}
+		r, err := evalExpr(e.Y, env)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return l + r, nil
+		case token.SUB:
+			return l - r, nil
+		case token.MUL:
+			return l * r, nil
+		case token.QUO:
+			if r == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return l / r, nil
+		case token.REM:
+			return math.Mod(l, r), nil
+		default:
+			if cond, err := evalCond(e, env); err == nil {
+				if cond {
+					return 1, nil
+				}
+				return 0, nil
+			}
+			return 0, fmt.Errorf("unsupported operator %s", e.Op)
+		}
+
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return 0, fmt.Errorf("only calls to whitelisted functions are allowed")
+		}
+		fn, ok := sandboxFuncs[ident.Name]
+		if !ok {
+			return 0, fmt.Errorf("%q is not a whitelisted sandbox function", ident.Name)
+		}
+		args := make([]float64, len(e.Args))
+		for i, a := range e.Args {
+			v, err := evalExpr(a, env)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = v
+		}
+		return fn(args)
+
+	default:
+		return 0, fmt.Errorf("unsupported expression %T", expr)
+	}
+}
+
+// toFloatArg converts a govaluate argument value into the float64 domain the
+// sandbox interpreter operates in.
+func toFloatArg(v any) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("expected a numeric value, got %T", v)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// registry.go implements a pluggable registry of user-defined formula
+// functions. A UDF's body is either a plain expression evaluated by the
+// existing govaluate engine, or a small subset of Go statements run through
+// the sandboxed interpreter in gosandbox.go.
+
+package udf
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Knetic/govaluate"
+)
+
+// Kind selects how a UDF's Body is interpreted.
+type Kind int
+
+const (
+	// KindExpression evaluates Body as a single govaluate expression, with
+	// Params bound as its parameters.
+	KindExpression Kind = iota
+	// KindGo evaluates Body as a sandboxed Go function body (see
+	// gosandbox.go) — no imports, no goroutines, no unbounded loops.
+	KindGo
+)
+
+// ArgType documents what kind of value a UDF parameter expects, for HELP
+// text and for List()'s consumers (e.g. an autocomplete panel) — it isn't
+// enforced against the untyped `any` args Call actually receives.
+type ArgType string
+
+const (
+	ArgNumber ArgType = "number"
+	ArgString ArgType = "string"
+	ArgBool   ArgType = "bool"
+	ArgRange  ArgType = "range"
+	ArgAny    ArgType = "any"
+)
+
+// FunctionMeta is the descriptive and validation metadata attached to a
+// UDF, playing the same role for user-defined functions that the
+// validateArgs(name, args, min, max) calls scattered through
+// govaluatefunc.go play for builtins - except here it's data, so Call can
+// check arg counts generically instead of every builtin hand-rolling its
+// own check.
+type FunctionMeta struct {
+	// MinArgs/MaxArgs bound the accepted argument count, the same
+	// (min, max) pair validateArgs takes; MaxArgs -1 means unbounded. Left
+	// at the zero value, Call falls back to requiring exactly len(Params)
+	// arguments, matching this registry's original (pre-FunctionMeta)
+	// behavior.
+	MinArgs int
+	MaxArgs int
+	// ArgTypes documents each parameter's expected kind, in Params order;
+	// it may be shorter than Params (or nil) when a UDF is untyped.
+	ArgTypes []ArgType
+	// Category groups related UDFs for a function-picker UI, e.g.
+	// "User-Defined (LAMBDA)".
+	Category string
+	// Doc is the text HELP(name) returns.
+	Doc string
+}
+
+// UDF is a single user-defined function.
+type UDF struct {
+	Name   string
+	Params []string
+	Kind   Kind
+	Body   string
+	Meta   FunctionMeta
+
+	// AllowOverride permits Register to redefine a name that collides with
+	// a builtin; without it, Register rejects the collision.
+	AllowOverride bool
+
+	expr *govaluate.EvaluableExpression // compiled once for KindExpression
+	prog *program                       // compiled once for KindGo
+}
+
+// FunctionInfo is the read-only view List() returns: enough to render a
+// function picker or HELP output without exposing the compiled expr/prog.
+type FunctionInfo struct {
+	Name   string
+	Params []string
+	Kind   Kind
+	Meta   FunctionMeta
+}
+
+// Registry holds every registered UDF, keyed by upper-cased name so lookups
+// match the case-insensitive convention the rest of the formula engine uses.
+type Registry struct {
+	mu   sync.RWMutex
+	udfs map[string]*UDF
+}
+
+// NewRegistry creates an empty UDF registry.
+func NewRegistry() *Registry {
+	return &Registry{udfs: make(map[string]*UDF)}
+}
+
+// Register compiles and stores a UDF. It fails if the name collides with a
+// builtin function (builtins is the set of names already provided by
+// utils.GovalFuncs) or if the body doesn't compile.
+func Register(reg *Registry, builtins map[string]struct{}, udf *UDF) error {
+	name := normalizeName(udf.Name)
+	if _, isBuiltin := builtins[name]; isBuiltin && !udf.AllowOverride {
+		return fmt.Errorf("%q is a builtin function and cannot be overridden (set AllowOverride to redefine it)", udf.Name)
+	}
+
+	switch udf.Kind {
+	case KindExpression:
+		expr, err := govaluate.NewEvaluableExpression(udf.Body)
+		if err != nil {
+			return fmt.Errorf("invalid expression body: %w", err)
+		}
+		udf.expr = expr
+
+	case KindGo:
+		prog, err := compileGo(udf.Body, udf.Params)
+		if err != nil {
+			return fmt.Errorf("invalid Go body: %w", err)
+		}
+		udf.prog = prog
+
+	default:
+		return fmt.Errorf("unknown UDF kind %d", udf.Kind)
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.udfs[name] = udf
+	return nil
+}
+
+// Unregister removes a UDF by name.
+func Unregister(reg *Registry, name string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	delete(reg.udfs, normalizeName(name))
+}
+
+// Get returns the UDF registered under name, if any.
+func (r *Registry) Get(name string) (*UDF, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	udf, ok := r.udfs[normalizeName(name)]
+	return udf, ok
+}
+
+// Names returns every registered UDF name.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.udfs))
+	for name := range r.udfs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// List returns FunctionInfo for every registered UDF, e.g. for a
+// function-picker panel or to enumerate what HELP can answer for.
+func (r *Registry) List() []FunctionInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]FunctionInfo, 0, len(r.udfs))
+	for _, udf := range r.udfs {
+		out = append(out, FunctionInfo{Name: udf.Name, Params: udf.Params, Kind: udf.Kind, Meta: udf.Meta})
+	}
+	return out
+}
+
+// Help returns the registered UDF's FunctionMeta.Doc, for HELP(name).
+func (r *Registry) Help(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	udf, ok := r.udfs[normalizeName(name)]
+	if !ok || udf.Meta.Doc == "" {
+		return "", false
+	}
+	return udf.Meta.Doc, true
+}
+
+// AsGovalFuncs adapts every UDF in the registry into govaluate functions,
+// ready to be merged into the map returned by utils.GovalFuncs.
+func (r *Registry) AsGovalFuncs() map[string]govaluate.ExpressionFunction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make(map[string]govaluate.ExpressionFunction, len(r.udfs))
+	for name, udf := range r.udfs {
+		udf := udf
+		out[name] = func(args ...any) (any, error) {
+			return Call(udf, args)
+		}
+	}
+	return out
+}
+
+// Call invokes a compiled UDF with positional arguments bound to its
+// declared Params, in order. Arg count is checked against udf.Meta's
+// MinArgs/MaxArgs when either is set; otherwise it falls back to requiring
+// exactly len(udf.Params) arguments, this registry's original behavior.
+func Call(udf *UDF, args []any) (any, error) {
+	if err := validateArgCount(udf, args); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]any, len(udf.Params))
+	for i := 0; i < len(udf.Params) && i < len(args); i++ {
+		params[udf.Params[i]] = args[i]
+	}
+
+	switch udf.Kind {
+	case KindExpression:
+		return udf.expr.Evaluate(params)
+	case KindGo:
+		return run(udf.prog, params)
+	default:
+		return nil, fmt.Errorf("unknown UDF kind %d", udf.Kind)
+	}
+}
+
+// validateArgCount checks args against udf.Meta.MinArgs/MaxArgs, or against
+// an exact match on len(udf.Params) when Meta carries no bounds.
+func validateArgCount(udf *UDF, args []any) error {
+	min, max := udf.Meta.MinArgs, udf.Meta.MaxArgs
+	if min == 0 && max == 0 {
+		if len(args) != len(udf.Params) {
+			return fmt.Errorf("%s requires exactly %d argument(s), got %d", udf.Name, len(udf.Params), len(args))
+		}
+		return nil
+	}
+	if len(args) < min {
+		return fmt.Errorf("%s requires at least %d argument(s), got %d", udf.Name, min, len(args))
+	}
+	if max != -1 && len(args) > max {
+		return fmt.Errorf("%s accepts at most %d argument(s), got %d", udf.Name, max, len(args))
+	}
+	return nil
+}
+
+func normalizeName(name string) string {
+	out := make([]byte, len(name))
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		out[i] = c
+	}
+	return string(out)
+}
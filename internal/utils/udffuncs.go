@@ -0,0 +1,134 @@
+// Copyright (c) 2025 @drclcomputers. All rights reserved.
+//
+// This work is licensed under the terms of the MIT license.
+// For a copy, see <https://opensource.org/licenses/MIT>.
+
+// udffuncs.go wires the pluggable user-defined function registry (see
+// internal/utils/udf) into the formula engine, following the same
+// merge-into-GovalFuncs pattern as financialfuncs.go and complexfuncs.go.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"gosheet/internal/utils/udf"
+)
+
+// userFunctions holds every UDF registered for the running session. Like
+// defaultSession in the table package, this is process-wide state: formulas
+// are evaluated from many call sites that have no natural place to thread a
+// registry handle through.
+var userFunctions = udf.NewRegistry()
+
+// RegisterUserFunction compiles and adds a user-defined function, rejecting
+// any name that collides with a builtin unless u.AllowOverride is set.
+func RegisterUserFunction(u *udf.UDF) error {
+	builtins := make(map[string]struct{})
+	for name := range GovalFuncs() {
+		builtins[name] = struct{}{}
+	}
+	for _, name := range userFunctions.Names() {
+		delete(builtins, name)
+	}
+	return udf.Register(userFunctions, builtins, u)
+}
+
+// UnregisterUserFunction removes a previously registered UDF by name.
+func UnregisterUserFunction(name string) {
+	udf.Unregister(userFunctions, name)
+}
+
+// UserFunctionNames returns the names of every currently registered UDF.
+func UserFunctionNames() []string {
+	return userFunctions.Names()
+}
+
+// UserFunctionList returns FunctionInfo for every currently registered UDF,
+// e.g. for a function-picker panel.
+func UserFunctionList() []udf.FunctionInfo {
+	return userFunctions.List()
+}
+
+// UserFunctionHelp returns the HELP text for a registered UDF, the
+// user-defined counterpart to builtinDocs in helpfuncs.go.
+func UserFunctionHelp(name string) (string, bool) {
+	return userFunctions.Help(name)
+}
+
+// splitLambdaParams splits LAMBDA's comma-separated parameter list into
+// trimmed parameter names, e.g. "x, y" -> ["x", "y"].
+func splitLambdaParams(paramList string) []string {
+	if strings.TrimSpace(paramList) == "" {
+		return nil
+	}
+	parts := strings.Split(paramList, ",")
+	params := make([]string, len(parts))
+	for i, p := range parts {
+		params[i] = strings.TrimSpace(p)
+	}
+	return params
+}
+
+// RegisterLambda registers a formula-defined function of the form a user
+// writes as LAMBDA("x,y", "x*x + y*y"): paramList is split into positional
+// parameter names and body is compiled once as a govaluate expression (via
+// the existing KindExpression path), then bound as a closure over those
+// parameter names on every call. Redefining a builtin is rejected unless
+// allowOverride is set.
+func RegisterLambda(name, paramList, body string, allowOverride bool) error {
+	u := &udf.UDF{
+		Name:          name,
+		Params:        splitLambdaParams(paramList),
+		Kind:          udf.KindExpression,
+		Body:          body,
+		AllowOverride: allowOverride,
+		Meta: udf.FunctionMeta{
+			Category: "User-Defined (LAMBDA)",
+			Doc:      fmt.Sprintf("%s(%s) = %s", strings.ToUpper(name), paramList, body),
+		},
+	}
+	return RegisterUserFunction(u)
+}
+
+// LambdaDef is the persistable (name, params, body) triple for a
+// KindExpression UDF registered via RegisterLambda - the utils-side
+// counterpart of fileop.SavedFunction, kept separate so this package
+// doesn't need to import fileop just to round-trip its own state.
+type LambdaDef struct {
+	Name   string
+	Params []string
+	Body   string
+}
+
+// SnapshotUserLambdas returns every registered KindExpression UDF as a
+// LambdaDef, ready to persist into the workbook file (see
+// fileop.SavedFunction); KindGo UDFs aren't included, since LAMBDA only
+// ever defines KindExpression UDFs and the sandboxed-Go form has no
+// surface for a user to create one through the saved-workbook format.
+func SnapshotUserLambdas() []LambdaDef {
+	infos := userFunctions.List()
+	defs := make([]LambdaDef, 0, len(infos))
+	for _, info := range infos {
+		if info.Kind != udf.KindExpression {
+			continue
+		}
+		udfPtr, ok := userFunctions.Get(info.Name)
+		if !ok {
+			continue
+		}
+		defs = append(defs, LambdaDef{Name: udfPtr.Name, Params: info.Params, Body: udfPtr.Body})
+	}
+	return defs
+}
+
+// RestoreUserLambdas re-registers every def as a LAMBDA, e.g. right after a
+// workbook loads. AllowOverride is set so reopening a file that already
+// registered these names (or a name that happens to match a previous
+// session's UDF) doesn't fail on the collision.
+func RestoreUserLambdas(defs []LambdaDef) {
+	for _, def := range defs {
+		_ = RegisterLambda(def.Name, strings.Join(def.Params, ","), def.Body, true)
+	}
+}
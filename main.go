@@ -9,9 +9,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"gosheet/internal/services/report"
 	"gosheet/internal/services/table"
 	"gosheet/internal/services/ui"
+	"gosheet/internal/services/ui/keybindings"
 	"gosheet/internal/utils"
 	"os"
 	"runtime/debug"
@@ -22,58 +25,112 @@ import (
 	"flag"
 )
 
+// runReportCommand implements the "report" subcommand: render a .gsheet
+// template against a JSON data file without opening the TUI, for scripting
+// recurring exports (invoices, weekly summaries, ...) from a shell or cron.
+func runReportCommand(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	templatePath := fs.String("template", "", "Path to the .gsheet/.json/.xlsx template")
+	dataPath := fs.String("data", "", "Path to a JSON file bound to the template's {{field}} placeholders")
+	out := fs.String("out", "", "Path to write the rendered workbook to")
+	fs.Parse(args)
+
+	if *templatePath == "" || *out == "" {
+		fmt.Fprintln(os.Stderr, "usage: gosheet report -template <file> -data <data.json> -out <file>")
+		os.Exit(1)
+	}
+
+	var data interface{}
+	if *dataPath != "" {
+		raw, err := os.ReadFile(*dataPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "report: reading data file: %v\n", err)
+			os.Exit(1)
+		}
+		if err := json.Unmarshal(raw, &data); err != nil {
+			fmt.Fprintf(os.Stderr, "report: parsing data file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := report.Render(*templatePath, data, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 // main is the entry point of the application, where the tview application is initialized, it is checked for command-line arguments to open a file or create a new table
 func main() {
 	//runtime.MemProfileRate = 1
 
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		runReportCommand(os.Args[2:])
+		return
+	}
+
 	utils.UpdateNrCellsOnScrn()
 
 	app := tview.NewApplication()
 
-	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyCtrlC {
-			app.Stop()
-			fmt.Println("\nCtrl+C detected. Exiting gracefully...\nUnsaved edits may be lost.")
-			return nil
-		}
-		return event
-	})
+	globalKeybindings := keybindings.Load()
 
 	defer func() {
-	    if r := recover(); r != nil {
-	        fmt.Fprintf(os.Stderr, "Application crashed: %v\n", r)
-	        fmt.Fprintf(os.Stderr, "Stack trace:\n%s\n", debug.Stack())
-	        os.Exit(1)
-	    }
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Application crashed: %v\n", r)
+			fmt.Fprintf(os.Stderr, "Stack trace:\n%s\n", debug.Stack())
+			os.Exit(1)
+		}
 	}()
-	
+
+	session := table.GetSession()
+
 	var filename string
 
-	flag.StringVar(&filename, "file", "", "Path to .gsheet or .json file to open")
+	flag.StringVar(&filename, "file", "", "Path to .gsheet, .json, or .xlsx file to open")
 	flag.Parse()
 
 	var t *tview.Table
 	if filename != "" {
 		ui.AddToRecentFiles(filename)
-		t = table.OpenTable(app, filename)
+		t = table.OpenTable(session, app, filename)
 	} else {
 		filename = ui.StartMenuUI(app)
 
 		if filename == "QUIT" {
-        	return
-    	}
+			return
+		}
 
 		if filename == "THERE_IS_NO_FILE_SELECTED" {
-			t = table.NewTable(app, 1000, 702)
+			t = table.NewTable(session, app)
 		} else {
 			ui.AddToRecentFiles(filename)
-			t = table.OpenTable(app, filename)
+			t = table.OpenTable(session, app, filename)
+		}
+	}
+
+	tabBar := table.BuildTabBar(session)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tabBar, 1, 0, false).
+		AddItem(t, 0, 1, true)
+
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if action, ok := globalKeybindings.Lookup(event); ok && action == keybindings.Quit {
+			app.Stop()
+			fmt.Println("\nQuit detected. Exiting gracefully...\nUnsaved edits may be lost.")
+			return nil
 		}
-    }
+		if action, ok := globalKeybindings.Lookup(event); ok && action == keybindings.WorkbookSwitch {
+			session.NextWorkbook()
+			table.RefreshTabBar(tabBar, session)
+			return nil
+		}
+		return event
+	})
 
 	t.Select(1, 1)
 
-	app.SetRoot(t, true).SetFocus(t)
+	app.SetRoot(layout, true).SetFocus(t)
 
 	if err := app.Run(); err != nil {
 		panic(err)
@@ -82,13 +139,13 @@ func main() {
 	//fmt.Println(utils.TOBEPRINTED)
 
 	/*
-    // Print memory stats
-    var m runtime.MemStats
-    runtime.ReadMemStats(&m)
-    fmt.Printf("\nMemory Stats:\n")
-    fmt.Printf("Alloc = %v MB\n", m.Alloc/1024/1024)
-    fmt.Printf("TotalAlloc = %v MB\n", m.TotalAlloc/1024/1024)
-    fmt.Printf("Sys = %v MB\n", m.Sys/1024/1024)
-    fmt.Printf("NumGC = %v\n", m.NumGC)
+	   // Print memory stats
+	   var m runtime.MemStats
+	   runtime.ReadMemStats(&m)
+	   fmt.Printf("\nMemory Stats:\n")
+	   fmt.Printf("Alloc = %v MB\n", m.Alloc/1024/1024)
+	   fmt.Printf("TotalAlloc = %v MB\n", m.TotalAlloc/1024/1024)
+	   fmt.Printf("Sys = %v MB\n", m.Sys/1024/1024)
+	   fmt.Printf("NumGC = %v\n", m.NumGC)
 	*/
 }